@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/admin"
+	"github.com/urfave/cli"
+)
+
+// AdminCommand contains the definition of the admin command-line subcommand
+// and its own subcommands.
+var AdminCommand cli.Command
+
+const banFlag = "ban"
+
+const adminDescription = `The admin command allows to inspect and adjust the
+   state of a running client through its admin API. The API has to be enabled
+   in the [Admin] section of the config file. Requests are authenticated with
+   a token the running client writes to the admin.cookie file in its data
+   directory, so the command has to be executed by a user who can read it.
+   When the client runs multiple operators, the cookie file is written to the
+   data directory of the first operator in the [[Operators]] section and the
+   operator the subcommands refer to has to be selected with the --operator
+   flag; the log level and goroutine dump are shared by all the operators.
+
+   All subcommands print their results in JSON format.`
+
+func init() {
+	AdminCommand = cli.Command{
+		Name:        "admin",
+		Usage:       `Inspects and adjusts the state of a running client.`,
+		Description: adminDescription,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: operatorFlag,
+				Usage: "address of the operator the subcommand refers to " +
+					"when the client runs multiple operators",
+			},
+		},
+		Subcommands: []cli.Command{
+			{
+				Name:   "operators",
+				Usage:  "Lists operators run by the client.",
+				Action: adminOperators,
+			},
+			{
+				Name:   "groups",
+				Usage:  "Lists groups the client is a member of.",
+				Action: adminGroups,
+			},
+			{
+				Name:   "sweep-stale-groups",
+				Usage:  "Archives groups which are stale on-chain.",
+				Action: adminSweepStaleGroups,
+			},
+			{
+				Name:   "sessions",
+				Usage:  "Lists in-flight DKG and relay entry signing sessions.",
+				Action: adminSessions,
+			},
+			{
+				Name: "reliability",
+				Usage: "Lists reliability statistics of other operators " +
+					"observed in DKG and relay entry signing.",
+				Action: adminReliability,
+			},
+			{
+				Name:      "log-level",
+				Usage:     "Changes log levels of the running client.",
+				ArgsUsage: "<directives>",
+				Description: "Accepts the same space-delimited level " +
+					"directives as the LOG_LEVEL environment variable, " +
+					"e.g. \"keep*=debug keep-net-libp2p=info\".",
+				Action: adminLogLevel,
+			},
+			{
+				Name:   "peers",
+				Usage:  "Lists connected and banned peers.",
+				Action: adminPeers,
+			},
+			{
+				Name:      "disconnect",
+				Usage:     "Disconnects the peer with the given network ID.",
+				ArgsUsage: "<network-id>",
+				Action:    adminDisconnect,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name: banFlag,
+						Usage: "bans the peer's operator address until " +
+							"the client is restarted",
+					},
+				},
+			},
+			{
+				Name:      "unban",
+				Usage:     "Removes the given operator address from the ban list.",
+				ArgsUsage: "<address>",
+				Action:    adminUnban,
+			},
+		},
+	}
+}
+
+func adminOperators(c *cli.Context) error {
+	return adminRequest(c, func(client *admin.Client) (interface{}, error) {
+		addresses := make([]string, 0)
+		err := client.Get(admin.OperatorsPath, &addresses)
+		return addresses, err
+	})
+}
+
+func adminGroups(c *cli.Context) error {
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		groups := make([]admin.Group, 0)
+		err := client.Get(admin.GroupsPath, &groups)
+		return groups, err
+	})
+}
+
+func adminSweepStaleGroups(c *cli.Context) error {
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		groups := make([]admin.Group, 0)
+		err := client.Post(admin.SweepStaleGroupsPath, nil, &groups)
+		return groups, err
+	})
+}
+
+func adminSessions(c *cli.Context) error {
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		sessions := make([]admin.Session, 0)
+		err := client.Get(admin.SessionsPath, &sessions)
+		return sessions, err
+	})
+}
+
+func adminReliability(c *cli.Context) error {
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		operators := make([]admin.OperatorReliability, 0)
+		err := client.Get(admin.ReliabilityPath, &operators)
+		return operators, err
+	})
+}
+
+func adminLogLevel(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with level directives")
+	}
+
+	return adminRequest(c, func(client *admin.Client) (interface{}, error) {
+		result := &admin.LogLevelRequest{}
+		err := client.Post(
+			admin.LogLevelPath,
+			&admin.LogLevelRequest{Directives: c.Args().First()},
+			result,
+		)
+		return result, err
+	})
+}
+
+func adminPeers(c *cli.Context) error {
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		peers := &admin.Peers{}
+		err := client.Get(admin.PeersPath, peers)
+		return peers, err
+	})
+}
+
+func adminDisconnect(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with peer network ID")
+	}
+
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		peers := &admin.Peers{}
+		err := client.Post(
+			admin.DisconnectPeerPath,
+			&admin.DisconnectPeerRequest{
+				NetworkID: c.Args().First(),
+				Ban:       c.Bool(banFlag),
+			},
+			peers,
+		)
+		return peers, err
+	})
+}
+
+func adminUnban(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with operator address")
+	}
+
+	return adminOperatorRequest(c, func(client *admin.Client) (interface{}, error) {
+		peers := &admin.Peers{}
+		err := client.Post(
+			admin.UnbanPeerPath,
+			&admin.UnbanPeerRequest{EthereumAddress: c.Args().First()},
+			peers,
+		)
+		return peers, err
+	})
+}
+
+// adminRequest connects to the admin API of the running client configured
+// in the config file, executes the given request and prints its result.
+func adminRequest(
+	c *cli.Context,
+	request func(client *admin.Client) (interface{}, error),
+) error {
+	_, client, err := connectAdmin(c)
+	if err != nil {
+		return err
+	}
+
+	return executeAdminRequest(client, request)
+}
+
+// adminOperatorRequest executes the given request of the operator selected
+// with the --operator flag when the client runs multiple operators, and of
+// the only operator of the client otherwise.
+func adminOperatorRequest(
+	c *cli.Context,
+	request func(client *admin.Client) (interface{}, error),
+) error {
+	cfg, client, err := connectAdmin(c)
+	if err != nil {
+		return err
+	}
+
+	operator := c.GlobalString(operatorFlag)
+	if cfg.IsMultiOperator() {
+		if operator == "" {
+			return fmt.Errorf(
+				"--%v has to be set when the client runs multiple "+
+					"operators; use the operators subcommand to list them",
+				operatorFlag,
+			)
+		}
+
+		client = client.ForOperator(operator)
+	} else if operator != "" {
+		return fmt.Errorf(
+			"--%v can be set only when the client runs multiple operators",
+			operatorFlag,
+		)
+	}
+
+	return executeAdminRequest(client, request)
+}
+
+func connectAdmin(c *cli.Context) (*config.Config, *admin.Client, error) {
+	cfg, err := config.ReadConfigWithoutPassword(c.GlobalString("config"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	client, err := admin.NewClient(
+		cfg.Admin.Port,
+		cfg.Admin.Socket,
+		adminCookiePath(cfg),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, client, nil
+}
+
+func executeAdminRequest(
+	client *admin.Client,
+	request func(client *admin.Client) (interface{}, error),
+) error {
+	result, err := request(client)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(result)
+}
+
+// adminCookiePath returns the path of the admin API cookie file. The file is
+// kept in the data directory of the client or, when the client runs multiple
+// operators, in the data directory of the first operator.
+func adminCookiePath(cfg *config.Config) string {
+	dataDir := cfg.Storage.DataDir
+	if cfg.IsMultiOperator() {
+		dataDir = cfg.Operators[0].DataDir
+	}
+
+	return filepath.Join(dataDir, admin.CookieFileName)
+}
+
+func printJSON(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
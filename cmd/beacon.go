@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// BeaconCommand contains the definition of the beacon command-line
+// subcommand and its own subcommands.
+var BeaconCommand cli.Command
+
+const operatorFlag = "operator"
+
+// Possible states of a group registered on-chain.
+const (
+	groupStateActive     = "active"
+	groupStateExpired    = "expired"
+	groupStateStale      = "stale"
+	groupStateTerminated = "terminated"
+)
+
+const beaconDescription = `The beacon command provides a high-level view of
+   the random beacon state registered on-chain.
+
+   The "groups" subcommand lists all groups registered in the operator
+   contract with their index, public key, registration block and state.
+   A group is active until it expires; an expired group becomes stale once
+   it can no longer be selected for any pending relay request. A group
+   terminated because of misbehavior is reported as terminated regardless of
+   its age. Each group member operator is listed once with the number of
+   seats it has in the group.`
+
+func init() {
+	BeaconCommand = cli.Command{
+		Name:        "beacon",
+		Usage:       `Provides a view of the random beacon state on-chain.`,
+		Description: beaconDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "groups",
+				Usage:  "Lists groups registered on-chain.",
+				Action: beaconGroups,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name: operatorFlag,
+						Usage: "lists only groups the operator with the " +
+							"given address is a member of",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "prints the output in JSON format",
+					},
+				},
+			},
+		},
+	}
+}
+
+// onChainGroup describes a group registered on-chain.
+type onChainGroup struct {
+	Index             uint64           `json:"index"`
+	PublicKey         string           `json:"publicKey"`
+	RegistrationBlock uint64           `json:"registrationBlock"`
+	State             string           `json:"state"`
+	Members           []*groupOperator `json:"members"`
+}
+
+// groupOperator describes an operator and the number of seats it has in
+// the group.
+type groupOperator struct {
+	Address string `json:"address"`
+	Seats   int    `json:"seats"`
+}
+
+func beaconGroups(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	numberOfGroups, err := utility.NumberOfGroups()
+	if err != nil {
+		return fmt.Errorf("could not get number of groups: [%v]", err)
+	}
+
+	firstActiveGroupIndex, err := utility.FirstActiveGroupIndex()
+	if err != nil {
+		return fmt.Errorf("could not get first active group index: [%v]", err)
+	}
+
+	operator := strings.ToLower(c.String(operatorFlag))
+	if operator != "" && !common.IsHexAddress(operator) {
+		return fmt.Errorf("invalid operator address [%v]", operator)
+	}
+
+	groups := make([]*onChainGroup, 0)
+	for index := uint64(0); index < numberOfGroups; index++ {
+		group, err := utility.GetGroup(index)
+		if err != nil {
+			return fmt.Errorf("could not get group [%v]: [%v]", index, err)
+		}
+
+		onChain := describeOnChainGroup(group, firstActiveGroupIndex)
+		if operator != "" && !onChain.hasMember(operator) {
+			continue
+		}
+
+		groups = append(groups, onChain)
+	}
+
+	if c.Bool(jsonFlag) {
+		return printJSON(groups)
+	}
+
+	return printOnChainGroups(groups)
+}
+
+func describeOnChainGroup(
+	group *chain.Group,
+	firstActiveGroupIndex uint64,
+) *onChainGroup {
+	state := groupStateActive
+	switch {
+	case group.Terminated:
+		state = groupStateTerminated
+	case group.Stale:
+		state = groupStateStale
+	case group.Index < firstActiveGroupIndex:
+		state = groupStateExpired
+	}
+
+	members := make([]*groupOperator, 0)
+	membersByAddress := make(map[string]*groupOperator)
+	for _, member := range group.Members {
+		address := common.BytesToAddress(member).Hex()
+
+		groupMember, ok := membersByAddress[address]
+		if !ok {
+			groupMember = &groupOperator{Address: address}
+			membersByAddress[address] = groupMember
+			members = append(members, groupMember)
+		}
+
+		groupMember.Seats++
+	}
+
+	return &onChainGroup{
+		Index:             group.Index,
+		PublicKey:         "0x" + hex.EncodeToString(group.PublicKey),
+		RegistrationBlock: group.RegistrationBlock,
+		State:             state,
+		Members:           members,
+	}
+}
+
+func (ocg *onChainGroup) hasMember(address string) bool {
+	for _, member := range ocg.Members {
+		if strings.ToLower(member.Address) == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printOnChainGroups(groups []*onChainGroup) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, group := range groups {
+		fmt.Fprintf(
+			writer,
+			"Group [%v]\tstate: %v\tregistration block: %v\n",
+			group.Index,
+			group.State,
+			group.RegistrationBlock,
+		)
+		fmt.Fprintf(writer, "  public key: %v\n", group.PublicKey)
+		for _, member := range group.Members {
+			fmt.Fprintf(
+				writer,
+				"  %v\tseats: %v\n",
+				member.Address,
+				member.Seats,
+			)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/logging"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/ping"
+	"github.com/keep-network/keep-core/pkg/net/retransmission"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/urfave/cli"
+)
+
+// BootstrapCommand contains the definition of the bootstrap command-line
+// subcommand.
+var BootstrapCommand cli.Command
+
+const (
+	networkKeyFlag = "network-key"
+	allowListFlag  = "allowlist"
+)
+
+// bootstrapNetworkKeyFileName is the name of the file in the storage
+// directory holding the network key of the bootstrap node, unless another
+// path is given with the --network-key flag.
+const bootstrapNetworkKeyFileName = "bootstrap_network.key"
+
+// defaultBootstrapDisseminationTime is the dissemination time of messages in
+// topics the bootstrap node is not subscribed to, in seconds, used if it is
+// not set in the config.
+const defaultBootstrapDisseminationTime = 60
+
+const bootstrapDescription = `The bootstrap command starts a bootstrap node in the
+   foreground. The node only routes the DHT and pubsub traffic of other peers;
+   it needs neither an operator key nor a stake.
+
+   The network identity of the node is kept in the file given with the
+   --network-key flag, by default ` + bootstrapNetworkKeyFileName + ` in the
+   storage directory. The key is generated when the file does not exist.
+
+   Remote peers are validated to have the minimum stake over a read-only
+   connection to the chain. If the --allowlist flag is given, the node does
+   not connect to the chain and accepts only peers whose operator addresses
+   are listed in the file, one per line. The file is re-read on SIGHUP.
+
+   Courteous dissemination is always enabled; if LibP2P.DisseminationTime is
+   not set, messages are forwarded for 60 seconds. Signature shares of relay
+   entries are forwarded only with the chain connection, as the node learns
+   about relay requests from the chain.`
+
+func init() {
+	BootstrapCommand = cli.Command{
+		Name:        "bootstrap",
+		Usage:       "Starts a bootstrap node in the foreground.",
+		Description: bootstrapDescription,
+		Action:      runBootstrap,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name: portFlag + "," + portShort,
+			},
+			&cli.StringFlag{
+				Name:  networkKeyFlag,
+				Usage: "path to the file with the network key of the node",
+			},
+			&cli.StringFlag{
+				Name:  allowListFlag,
+				Usage: "path to the file with addresses of allowed operators",
+			},
+		},
+	}
+}
+
+func runBootstrap(c *cli.Context) error {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	go cancelOnShutdownSignal(cancelCtx)
+
+	config, err := config.ReadConfigWithoutPassword(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: %v", err)
+	}
+
+	if c.Int(portFlag) > 0 {
+		config.LibP2P.Port = c.Int(portFlag)
+	}
+
+	if config.Logging.Level != "" && os.Getenv("LOG_LEVEL") == "" {
+		if err := logging.Configure(config.Logging.Level); err != nil {
+			return fmt.Errorf("could not configure logging: [%v]", err)
+		}
+	}
+
+	networkKeyPath := c.String(networkKeyFlag)
+	if networkKeyPath == "" {
+		networkKeyPath = filepath.Join(
+			config.Storage.DataDir,
+			bootstrapNetworkKeyFileName,
+		)
+	}
+
+	networkPrivateKey, err := readOrGenerateNetworkKey(networkKeyPath)
+	if err != nil {
+		return err
+	}
+
+	var (
+		chainProvider chain.ReadOnlyHandle
+		netFirewall   net.Firewall
+		ticker        *retransmission.Ticker
+	)
+
+	if allowListPath := c.String(allowListFlag); allowListPath != "" {
+		addresses, err := firewall.ReadAllowListFile(allowListPath)
+		if err != nil {
+			return err
+		}
+
+		allowList := firewall.NewAllowList(addresses)
+		logger.Infof(
+			"validating peers against [%v] allowed operators",
+			allowList.Len(),
+		)
+
+		go reloadAllowListOnSignal(ctx, allowListPath, allowList)
+
+		netFirewall = allowList
+		// The bootstrap node does not broadcast messages on its own so
+		// there is nothing to retransmit.
+		ticker = retransmission.NewTicker(make(chan uint64))
+	} else {
+		chainProvider, err = ethereum.ConnectReadOnly(ctx, config.Ethereum)
+		if err != nil {
+			return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		}
+
+		stakeMonitor, err := chainProvider.StakeMonitor()
+		if err != nil {
+			return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
+		}
+
+		blockCounter, err := chainProvider.BlockCounter()
+		if err != nil {
+			return err
+		}
+
+		netFirewall = firewall.MinimumStakePolicy(stakeMonitor)
+		ticker = retransmission.NewTicker(blockCounter.WatchBlocks(ctx))
+	}
+
+	netConfig := config.LibP2P
+	if netConfig.DisseminationTime == 0 {
+		netConfig.DisseminationTime = defaultBootstrapDisseminationTime
+	}
+
+	netProvider, err := libp2p.Connect(
+		ctx,
+		netConfig,
+		networkPrivateKey,
+		libp2p.ProtocolBeacon,
+		netFirewall,
+		ticker,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Answer connectivity probes sent with the "net ping" command.
+	ping.Respond(ctx, netProvider)
+
+	if chainProvider != nil {
+		beacon.ForwardSignatureShares(
+			ctx,
+			chainProvider.ThresholdRelay(),
+			netProvider,
+		)
+	}
+
+	nodeHeader(
+		netProvider.ConnectionManager().AddrStrings(),
+		config.LibP2P.Port,
+	)
+
+	reloader := newConfigReloader(
+		c.GlobalString("config"),
+		c.Int(portFlag),
+		config,
+		[]net.Provider{netProvider},
+		chainProvider,
+	)
+
+	registry, isConfigured := metrics.Initialize(config.Metrics.Port)
+	if isConfigured {
+		logger.Infof("enabled metrics on port [%v]", config.Metrics.Port)
+		observeBootstrapMetrics(ctx, registry, config, netProvider, reloader)
+	} else {
+		logger.Infof("metrics are not configured")
+	}
+
+	initializeDiagnostics(config, netProvider, nil)
+
+	go reloader.reloadOnSignal(ctx)
+
+	<-ctx.Done()
+
+	if err := netProvider.Close(); err != nil {
+		logger.Warningf("could not close network provider: [%v]", err)
+	}
+
+	logger.Infof("shutdown completed")
+
+	return nil
+}
+
+// readOrGenerateNetworkKey reads the network key of the bootstrap node from
+// the file. If the file does not exist, a new key is generated and written
+// to the file.
+func readOrGenerateNetworkKey(filePath string) (libp2pcrypto.PrivKey, error) {
+	keyBytes, err := ioutil.ReadFile(filePath)
+	if err == nil {
+		privateKey, err := libp2pcrypto.UnmarshalPrivateKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not read network key from file [%v]: [%v]",
+				filePath,
+				err,
+			)
+		}
+
+		if _, ok := privateKey.(*key.NetworkPrivate); !ok {
+			return nil, fmt.Errorf(
+				"network key in file [%v] is not a secp256k1 key",
+				filePath,
+			)
+		}
+
+		return privateKey, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf(
+			"could not read network key file [%v]: [%v]",
+			filePath,
+			err,
+		)
+	}
+
+	privateKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate network key: [%v]", err)
+	}
+
+	keyBytes, err = libp2pcrypto.MarshalPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal network key: [%v]", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return nil, fmt.Errorf(
+			"could not create network key directory: [%v]",
+			err,
+		)
+	}
+
+	if err := ioutil.WriteFile(filePath, keyBytes, 0600); err != nil {
+		return nil, fmt.Errorf(
+			"could not write network key file [%v]: [%v]",
+			filePath,
+			err,
+		)
+	}
+
+	logger.Infof("generated new network key in file [%v]", filePath)
+
+	return privateKey, nil
+}
+
+// reloadAllowListOnSignal re-reads the allow list file every time SIGHUP is
+// received, until the context is done. The previous list is kept if the
+// file could not be read.
+func reloadAllowListOnSignal(
+	ctx context.Context,
+	filePath string,
+	allowList *firewall.AllowList,
+) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-signals:
+			addresses, err := firewall.ReadAllowListFile(filePath)
+			if err != nil {
+				logger.Errorf("could not reload allow list: [%v]", err)
+				continue
+			}
+
+			allowList.Replace(addresses)
+			logger.Infof(
+				"reloaded allow list with [%v] allowed operators",
+				allowList.Len(),
+			)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// observeBootstrapMetrics starts observations of the metrics of the
+// bootstrap node. The observations are kept by the reloader so that their
+// ticks can be changed when the configuration is reloaded.
+func observeBootstrapMetrics(
+	ctx context.Context,
+	registry metrics.Registry,
+	config *config.Config,
+	netProvider net.Provider,
+	reloader *configReloader,
+) {
+	tick := time.Duration(config.Metrics.NetworkMetricsTick) * time.Second
+
+	networkObservations := []*metrics.Observation{
+		metrics.ObserveConnectedPeersCount(ctx, registry, netProvider, tick),
+		metrics.ObserveConnectedBootstrapCount(
+			ctx,
+			registry,
+			netProvider,
+			reloader.bootstrapPeers,
+			tick,
+		),
+	}
+
+	if routingSource, ok := netProvider.(metrics.RoutingSource); ok {
+		networkObservations = append(
+			networkObservations,
+			metrics.ObserveRoutingTableSize(ctx, registry, routingSource, tick),
+			metrics.ObserveForwardedMessagesCount(
+				ctx,
+				registry,
+				routingSource,
+				tick,
+			),
+		)
+	}
+
+	reloader.addObservations(networkObservations, nil)
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/keep-network/keep-core/config"
+	"github.com/urfave/cli"
+)
+
+// ConfigCommand contains the definition of the config command-line
+// subcommand and its own subcommands.
+var ConfigCommand cli.Command
+
+const (
+	networkFlag = "network"
+	outputFlag  = "output"
+	formatFlag  = "format"
+)
+
+// contractCodeCheckTimeout is the maximum time the validation waits for the
+// Ethereum node to return code of all the configured contracts.
+const contractCodeCheckTimeout = 30 * time.Second
+
+const configDescription = `The config command helps to prepare and check the
+   client configuration file.
+
+   The "validate" subcommand checks the configuration file passed with the
+   global --config flag and reports all the problems found at once. Besides
+   checking the values in the file, it connects to the configured Ethereum
+   node and checks that every configured contract address holds code, unless
+   the --offline flag is set.
+
+   The "init" subcommand writes an annotated configuration file template for
+   the chosen network.
+
+   The "print" subcommand prints the effective configuration, that is the
+   configuration file with values overridden by KEEP_<SECTION>_<FIELD>
+   environment variables applied, with passwords redacted.
+
+   The configuration file can be written in TOML, JSON (.json) or YAML
+   (.yaml, .yml) format.`
+
+func init() {
+	ConfigCommand = cli.Command{
+		Name:        "config",
+		Usage:       `Prepares and checks the configuration file.`,
+		Description: configDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "validate",
+				Usage:  "Checks the configuration file for problems.",
+				Action: validateConfig,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  offlineFlag,
+						Usage: "does not check contracts on the chain",
+					},
+				},
+			},
+			{
+				Name:   "init",
+				Usage:  "Writes a configuration file template.",
+				Action: initConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  networkFlag,
+						Value: "mainnet",
+						Usage: "network of the template; one of: " +
+							strings.Join(config.Networks(), ", "),
+					},
+					&cli.StringFlag{
+						Name:  outputFlag,
+						Usage: "writes the template to the given file instead of stdout",
+					},
+				},
+			},
+			{
+				Name:   "print",
+				Usage:  "Prints the effective configuration.",
+				Action: printConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  formatFlag,
+						Value: "toml",
+						Usage: "output format; one of: " +
+							strings.Join(config.Formats(), ", "),
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateConfig(c *cli.Context) error {
+	cfg, problems := config.Validate(c.GlobalString("config"))
+
+	if cfg != nil && !c.Bool(offlineFlag) && cfg.Ethereum.URL != "" {
+		problems = append(problems, checkContractsCode(cfg)...)
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("Configuration is valid.")
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("- %v\n", problem)
+	}
+
+	return fmt.Errorf("configuration has [%v] problems", len(problems))
+}
+
+// checkContractsCode connects to the configured Ethereum node and checks
+// that every configured contract address holds code.
+func checkContractsCode(cfg *config.Config) []error {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		contractCodeCheckTimeout,
+	)
+	defer cancelCtx()
+
+	client, err := ethclient.DialContext(ctx, cfg.Ethereum.URL)
+	if err != nil {
+		return []error{
+			fmt.Errorf(
+				"could not connect to Ethereum node [%v]: [%v]",
+				cfg.Ethereum.URL,
+				err,
+			),
+		}
+	}
+	defer client.Close()
+
+	contractNames := make([]string, 0, len(cfg.Ethereum.ContractAddresses))
+	for contractName := range cfg.Ethereum.ContractAddresses {
+		contractNames = append(contractNames, contractName)
+	}
+	sort.Strings(contractNames)
+
+	var problems []error
+	for _, contractName := range contractNames {
+		address := cfg.Ethereum.ContractAddresses[contractName]
+		if !common.IsHexAddress(address) {
+			// Already reported by the configuration validation.
+			continue
+		}
+
+		code, err := client.CodeAt(ctx, common.HexToAddress(address), nil)
+		if err != nil {
+			problems = append(problems, fmt.Errorf(
+				"could not get code of [%v] at [%v]: [%v]",
+				contractName,
+				address,
+				err,
+			))
+			continue
+		}
+
+		if len(code) == 0 {
+			problems = append(problems, fmt.Errorf(
+				"no contract code at address [%v] of [%v]",
+				address,
+				contractName,
+			))
+		}
+	}
+
+	return problems
+}
+
+func initConfig(c *cli.Context) error {
+	output := c.String(outputFlag)
+	if output == "" {
+		return config.WriteTemplate(os.Stdout, c.String(networkFlag))
+	}
+
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create config file: [%v]", err)
+	}
+	defer file.Close()
+
+	if err := config.WriteTemplate(file, c.String(networkFlag)); err != nil {
+		os.Remove(output)
+		return err
+	}
+
+	return nil
+}
+
+func printConfig(c *cli.Context) error {
+	cfg, err := config.Load(c.GlobalString("config"))
+	if err != nil {
+		return err
+	}
+
+	return config.Encode(os.Stdout, cfg, c.String(formatFlag))
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/logging"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/simulation"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/urfave/cli"
+)
+
+// DKGCommand contains the definition of the dkg command-line subcommand and
+// its own subcommands.
+var DKGCommand cli.Command
+
+const (
+	groupSizeFlag       = "group-size"
+	honestThresholdFlag = "honest-threshold"
+	misbehaveFlag       = "misbehave"
+	seedFlag            = "seed"
+)
+
+const dkgDescription = `The dkg command helps to understand the distributed key
+   generation protocol.
+
+   The "simulate" subcommand runs the full distributed key generation,
+   including the result publication, between members of a group connected
+   with a local network and a local chain. It does not need the configuration
+   file nor connect to any external service. Selected members can be made to
+   misbehave with the repeatable --misbehave flag in one of the forms:
+
+      <member>:drop[:<percentage>]  drops all or the given percentage of
+                                    messages sent by the member
+      <member>:corrupt-shares       corrupts shares the member sends to
+                                    other members
+      <member>:inactive:<phase>     stops sending messages starting from the
+                                    given protocol phase (1-13)
+
+   The simulation reports which members ended up inactive or disqualified,
+   the published group public key, the number of result signatures and the
+   wall-clock time of protocol phases. Blocks of the local chain are mined
+   every 500 milliseconds so the simulation takes about 40 seconds.`
+
+func init() {
+	DKGCommand = cli.Command{
+		Name:        "dkg",
+		Usage:       `Explores the distributed key generation protocol.`,
+		Description: dkgDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "simulate",
+				Usage:  "Simulates the distributed key generation offline.",
+				Action: simulateDKG,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Value: 5,
+						Usage: "number of group members",
+					},
+					&cli.IntFlag{
+						Name:  honestThresholdFlag,
+						Value: 3,
+						Usage: "minimum number of honest members",
+					},
+					&cli.StringSliceFlag{
+						Name:  misbehaveFlag,
+						Usage: "misbehaviour of a member; can be repeated",
+					},
+					&cli.Int64Flag{
+						Name:  seedFlag,
+						Usage: "seed to repeat the simulation with; random if not set",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "prints the output in JSON format",
+					},
+				},
+			},
+		},
+	}
+}
+
+// dkgSimulationResult describes the outcome of the DKG simulation.
+type dkgSimulationResult struct {
+	Seed            int64               `json:"seed"`
+	GroupSize       int                 `json:"groupSize"`
+	HonestThreshold int                 `json:"honestThreshold"`
+	Misbehaviours   []string            `json:"misbehaviours"`
+	Published       bool                `json:"published"`
+	GroupPublicKey  string              `json:"groupPublicKey,omitempty"`
+	SignaturesCount int                 `json:"signaturesCount"`
+	Inactive        []group.MemberIndex `json:"inactive"`
+	Disqualified    []group.MemberIndex `json:"disqualified"`
+	MemberFailures  map[string]string   `json:"memberFailures"`
+	Phases          []*dkgPhaseTiming   `json:"phases"`
+	Duration        string              `json:"duration"`
+}
+
+type dkgPhaseTiming struct {
+	Phases   string `json:"phases"`
+	Name     string `json:"name"`
+	Duration string `json:"duration"`
+}
+
+func simulateDKG(c *cli.Context) error {
+	var misbehaviours []*simulation.Misbehaviour
+	for _, value := range c.StringSlice(misbehaveFlag) {
+		misbehaviour, err := simulation.ParseMisbehaviour(value)
+		if err != nil {
+			return err
+		}
+		misbehaviours = append(misbehaviours, misbehaviour)
+	}
+
+	seed := c.Int64(seedFlag)
+	if seed == 0 {
+		randomSeed, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+		if err != nil {
+			return fmt.Errorf("could not generate seed: [%v]", err)
+		}
+		seed = randomSeed.Int64()
+	}
+
+	// Protocol logs of all the simulated members would drown the report so
+	// only errors are logged unless log levels are set explicitly.
+	if os.Getenv("LOG_LEVEL") == "" {
+		if err := logging.Configure("keep*=error"); err != nil {
+			return err
+		}
+	}
+
+	if !c.Bool(jsonFlag) {
+		fmt.Fprintf(os.Stderr, "Simulating DKG with seed [%v]...\n", seed)
+	}
+
+	result, err := simulation.Run(
+		c.Int(groupSizeFlag),
+		c.Int(honestThresholdFlag),
+		misbehaviours,
+		big.NewInt(seed),
+	)
+	if err != nil {
+		return err
+	}
+
+	output := &dkgSimulationResult{
+		Seed:            seed,
+		GroupSize:       c.Int(groupSizeFlag),
+		HonestThreshold: c.Int(honestThresholdFlag),
+		Misbehaviours:   make([]string, 0, len(misbehaviours)),
+		Published:       result.Published,
+		SignaturesCount: result.SignaturesCount,
+		Inactive:        result.Inactive,
+		Disqualified:    result.Disqualified,
+		MemberFailures:  make(map[string]string),
+		Duration:        result.Duration.Round(time.Millisecond).String(),
+	}
+	for _, misbehaviour := range misbehaviours {
+		output.Misbehaviours = append(output.Misbehaviours, misbehaviour.String())
+	}
+	if result.Published {
+		output.GroupPublicKey = hex.EncodeToString(result.GroupPublicKey)
+	}
+	for memberIndex, failure := range result.MemberFailures {
+		output.MemberFailures[fmt.Sprintf("%v", memberIndex)] = failure.Error()
+	}
+	for _, phase := range result.Phases {
+		phases := fmt.Sprintf("%v", phase.FirstPhase)
+		if phase.LastPhase != phase.FirstPhase {
+			phases = fmt.Sprintf("%v-%v", phase.FirstPhase, phase.LastPhase)
+		}
+
+		output.Phases = append(output.Phases, &dkgPhaseTiming{
+			Phases:   phases,
+			Name:     phase.Name,
+			Duration: phase.Duration.Round(time.Millisecond).String(),
+		})
+	}
+
+	if c.Bool(jsonFlag) {
+		return printJSON(output)
+	}
+
+	return printDKGSimulationResult(output, result.MemberFailures)
+}
+
+func printDKGSimulationResult(
+	result *dkgSimulationResult,
+	memberFailures map[group.MemberIndex]error,
+) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "Seed:\t%v\n", result.Seed)
+	fmt.Fprintf(writer, "Group size:\t%v\n", result.GroupSize)
+	fmt.Fprintf(writer, "Honest threshold:\t%v\n", result.HonestThreshold)
+	fmt.Fprintf(writer, "Misbehaviours:\t%v\n", formatList(result.Misbehaviours))
+	fmt.Fprintf(writer, "Result published:\t%v\n", result.Published)
+	if result.Published {
+		fmt.Fprintf(writer, "Group public key:\t%v\n", result.GroupPublicKey)
+		fmt.Fprintf(writer, "Result signatures:\t%v\n", result.SignaturesCount)
+	}
+	fmt.Fprintf(writer, "Inactive members:\t%v\n", formatMemberIndexes(result.Inactive))
+	fmt.Fprintf(writer, "Disqualified members:\t%v\n", formatMemberIndexes(result.Disqualified))
+	fmt.Fprintf(writer, "Duration:\t%v\n", result.Duration)
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+
+	writer = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "PHASES\tNAME\tDURATION\n")
+	for _, phase := range result.Phases {
+		fmt.Fprintf(writer, "%v\t%v\t%v\n", phase.Phases, phase.Name, phase.Duration)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if len(memberFailures) > 0 {
+		fmt.Println()
+
+		memberIndexes := make([]group.MemberIndex, 0, len(memberFailures))
+		for memberIndex := range memberFailures {
+			memberIndexes = append(memberIndexes, memberIndex)
+		}
+		sort.Slice(memberIndexes, func(i, j int) bool {
+			return memberIndexes[i] < memberIndexes[j]
+		})
+
+		writer = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(writer, "MEMBER\tFAILURE\n")
+		for _, memberIndex := range memberIndexes {
+			fmt.Fprintf(writer, "%v\t%v\n", memberIndex, memberFailures[memberIndex])
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatMemberIndexes(memberIndexes []group.MemberIndex) string {
+	values := make([]string, len(memberIndexes))
+	for i, memberIndex := range memberIndexes {
+		values[i] = fmt.Sprintf("%v", memberIndex)
+	}
+	return formatList(values)
+}
+
+func formatList(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+
+	return strings.Join(values, ", ")
+}
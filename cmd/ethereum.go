@@ -1,6 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/eventlog"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	chaincmd "github.com/keep-network/keep-core/pkg/chain/gen/cmd"
 	"github.com/urfave/cli"
 )
@@ -16,11 +29,205 @@ const ethereumDescription = `The ethereum command allows interacting with Keep's
 
     See the subcommand help for additional details.`
 
+const (
+	fromBlockFlag     = "from-block"
+	untilBlockFlag    = "until-block"
+	confirmationsFlag = "confirmations"
+)
+
+// watchChunkSize is the maximum number of blocks from which event logs are
+// fetched with a single query.
+const watchChunkSize = 5000
+
+const watchDescription = `The watch command streams events emitted by the given
+   contract, one JSON object per line, with decoded event fields, the block
+   number and the transaction hash. Only the given events are streamed or all
+   events of the contract if no events are given.
+
+   By default, events are streamed starting from the next confirmed block.
+   Events from past blocks are streamed first if the --from-block flag is
+   given. The command follows new blocks until it is interrupted or until it
+   processes the block given with the --until-block flag.
+
+   Events are streamed from a block only once the number of blocks given with
+   the --confirmations flag have been mined on top of it, so that events from
+   blocks which are later reorganized out of the chain are not streamed.`
+
+// watchableContract describes a contract whose events can be watched.
+type watchableContract struct {
+	// addressKey is the key of the contract address in the configuration.
+	addressKey string
+	abi        string
+}
+
+// watchableContracts are contracts whose events can be watched, keyed by
+// names of their generated subcommands. The service contract is upgradeable
+// so events are decoded with the ABI of its implementation.
+var watchableContracts = map[string]*watchableContract{
+	"keep-random-beacon-operator": {
+		"KeepRandomBeaconOperator",
+		abi.KeepRandomBeaconOperatorABI,
+	},
+	"keep-random-beacon-service": {
+		"KeepRandomBeaconService",
+		abi.KeepRandomBeaconServiceImplV1ABI,
+	},
+	"token-staking": {
+		"TokenStaking",
+		abi.TokenStakingABI,
+	},
+	"token-grant": {
+		"TokenGrant",
+		abi.TokenGrantABI,
+	},
+}
+
 func init() {
 	EthereumCommand = cli.Command{
 		Name:        "ethereum",
 		Usage:       `Provides access to Keep network Ethereum contracts.`,
 		Description: ethereumDescription,
-		Subcommands: chaincmd.AvailableCommands,
+		Subcommands: append(
+			[]cli.Command{
+				{
+					Name:        "watch",
+					Usage:       "Streams contract events as JSON lines.",
+					Description: watchDescription,
+					ArgsUsage: fmt.Sprintf(
+						"<%v> [event...]",
+						strings.Join(watchableContractNames(), "|"),
+					),
+					Action: watchEvents,
+					Flags: []cli.Flag{
+						&cli.Uint64Flag{
+							Name:  fromBlockFlag,
+							Usage: "streams events from the given past block first",
+						},
+						&cli.Uint64Flag{
+							Name:  untilBlockFlag,
+							Usage: "stops after streaming events from the given block",
+						},
+						&cli.DurationFlag{
+							Name:  intervalFlag,
+							Value: 15 * time.Second,
+							Usage: "time between checks for new blocks",
+						},
+						&cli.Uint64Flag{
+							Name:  confirmationsFlag,
+							Value: 6,
+							Usage: "number of blocks mined on top of a block " +
+								"before its events are streamed",
+						},
+					},
+				},
+			},
+			chaincmd.AvailableCommands...,
+		),
+	}
+}
+
+func watchableContractNames() []string {
+	names := make([]string, 0, len(watchableContracts))
+	for name := range watchableContracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func watchEvents(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return fmt.Errorf(
+			"contract must be one of [%v]",
+			strings.Join(watchableContractNames(), ", "),
+		)
+	}
+
+	contractName := c.Args().First()
+	contract, ok := watchableContracts[contractName]
+	if !ok {
+		return fmt.Errorf(
+			"unknown contract [%v]; contract must be one of [%v]",
+			contractName,
+			strings.Join(watchableContractNames(), ", "),
+		)
 	}
+
+	decoder, err := eventlog.NewDecoder(
+		contract.addressKey,
+		contract.abi,
+		c.Args().Tail()...,
+	)
+	if err != nil {
+		return err
+	}
+
+	options := &eventlog.StreamOptions{
+		PollInterval:  c.Duration(intervalFlag),
+		ChunkSize:     watchChunkSize,
+		Confirmations: c.Uint64(confirmationsFlag),
+	}
+	if c.IsSet(fromBlockFlag) {
+		fromBlock := c.Uint64(fromBlockFlag)
+		options.FromBlock = &fromBlock
+	}
+	if c.IsSet(untilBlockFlag) {
+		untilBlock := c.Uint64(untilBlockFlag)
+		options.UntilBlock = &untilBlock
+	}
+	if options.FromBlock != nil && options.UntilBlock != nil &&
+		*options.FromBlock > *options.UntilBlock {
+		return fmt.Errorf(
+			"--%v must not be greater than --%v",
+			fromBlockFlag,
+			untilBlockFlag,
+		)
+	}
+
+	cfg, err := config.ReadConfigWithoutPassword(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config from file: [%v]", err)
+	}
+	ethereumConfig := cfg.Ethereum
+
+	addressHex, ok := ethereumConfig.ContractAddresses[contract.addressKey]
+	if !ok || !common.IsHexAddress(addressHex) {
+		return fmt.Errorf(
+			"no valid address of contract [%v] in the configuration",
+			contract.addressKey,
+		)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	go cancelOnShutdownSignal(cancelCtx)
+
+	client, err := ethclient.DialContext(ctx, ethereumConfig.URL)
+	if err != nil {
+		return fmt.Errorf(
+			"could not connect to Ethereum node [%v]: [%v]",
+			ethereumConfig.URL,
+			err,
+		)
+	}
+	defer client.Close()
+
+	encoder := json.NewEncoder(os.Stdout)
+
+	err = eventlog.Stream(
+		ctx,
+		client,
+		common.HexToAddress(addressHex),
+		decoder,
+		options,
+		func(event *eventlog.Event) error {
+			return encoder.Encode(event)
+		},
+	)
+	if err != nil && ctx.Err() != nil {
+		// The error is caused by the interrupted request to the node.
+		return nil
+	}
+
+	return err
 }
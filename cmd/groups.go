@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/config"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// GroupsCommand contains the definition of the groups command-line
+// subcommand and its own subcommands.
+var GroupsCommand cli.Command
+
+const (
+	jsonFlag    = "json"
+	offlineFlag = "offline"
+)
+
+// Names of the directories in which the disk persistence keeps the current
+// and the archived data.
+const (
+	currentDataDir  = "current"
+	archivedDataDir = "archive"
+)
+
+const groupsDescription = `The groups command inspects the group registry
+   stored on disk in the data directory configured in the config file. The
+   memberships are decrypted with the operator account password, so the
+   password has to be provided the same way as for the start command.
+
+   The "list" subcommand prints all the groups the client is a member of,
+   the "archived" subcommand prints groups which have been archived as stale,
+   and the "show" subcommand prints a single group. Unless the --offline flag
+   is set, each group is cross-checked against the chain to determine whether
+   it is registered and whether it is stale.
+
+   The command only reads the data directory and can be executed while the
+   client is running.`
+
+func init() {
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:  jsonFlag,
+			Usage: "prints the output in JSON format",
+		},
+		&cli.BoolFlag{
+			Name:  offlineFlag,
+			Usage: "does not cross-check groups against the chain",
+		},
+	}
+
+	GroupsCommand = cli.Command{
+		Name:        "groups",
+		Usage:       `Inspects the group registry stored on disk.`,
+		Description: groupsDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Lists groups the client is a member of.",
+				Action: listGroups,
+				Flags:  flags,
+			},
+			{
+				Name:   "archived",
+				Usage:  "Lists archived groups.",
+				Action: listArchivedGroups,
+				Flags:  flags,
+			},
+			{
+				Name:      "show",
+				Usage:     "Shows a single group.",
+				ArgsUsage: "<compressed-public-key-prefix>",
+				Action:    showGroup,
+				Flags:     flags,
+			},
+		},
+	}
+}
+
+// localGroup describes a group stored in the local registry.
+type localGroup struct {
+	PublicKey             string  `json:"publicKey"`
+	UncompressedPublicKey string  `json:"uncompressedPublicKey"`
+	ChannelName           string  `json:"channelName"`
+	MemberIndexes         []uint8 `json:"memberIndexes"`
+	Archived              bool    `json:"archived"`
+	Registered            *bool   `json:"registered,omitempty"`
+	Stale                 *bool   `json:"stale,omitempty"`
+}
+
+func listGroups(c *cli.Context) error {
+	groups, err := readLocalGroups(c, currentDataDir)
+	if err != nil {
+		return err
+	}
+
+	return printLocalGroups(c, groups)
+}
+
+func listArchivedGroups(c *cli.Context) error {
+	groups, err := readLocalGroups(c, archivedDataDir)
+	if err != nil {
+		return err
+	}
+
+	return printLocalGroups(c, groups)
+}
+
+func showGroup(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with group public key")
+	}
+
+	prefix := strings.ToLower(strings.TrimPrefix(c.Args().First(), "0x"))
+
+	var matching []*localGroup
+	for _, directory := range []string{currentDataDir, archivedDataDir} {
+		groups, err := readLocalGroups(c, directory)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			if strings.HasPrefix(group.PublicKey, prefix) {
+				matching = append(matching, group)
+			}
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		return fmt.Errorf("no group with public key [%v] found", prefix)
+	case 1:
+		return printLocalGroups(c, matching)
+	default:
+		return fmt.Errorf(
+			"public key [%v] is ambiguous; matches [%v] groups",
+			prefix,
+			len(matching),
+		)
+	}
+}
+
+// readLocalGroups reads memberships stored in the given directory of the
+// data directory and groups them by the group public key. Groups are
+// cross-checked against the chain unless the offline flag is set.
+func readLocalGroups(c *cli.Context, directory string) ([]*localGroup, error) {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	handle := persistence.NewEncryptedPersistence(
+		&readOnlyDirectoryHandle{
+			path: filepath.Join(cfg.Storage.DataDir, directory),
+		},
+		cfg.StoragePassword(),
+	)
+
+	memberships, errors := registry.ReadMemberships(handle)
+	for _, err := range errors {
+		fmt.Fprintf(os.Stderr, "could not read membership: [%v]\n", err)
+	}
+	if len(errors) > 0 && len(memberships) == 0 {
+		return nil, fmt.Errorf(
+			"could not read any membership; is the password correct?",
+		)
+	}
+
+	groupsByKey := make(map[string]*localGroup)
+	for _, membership := range memberships {
+		publicKey := hex.EncodeToString(
+			membership.Signer.GroupPublicKeyBytesCompressed(),
+		)
+
+		group, ok := groupsByKey[publicKey]
+		if !ok {
+			group = &localGroup{
+				PublicKey: publicKey,
+				UncompressedPublicKey: hex.EncodeToString(
+					membership.Signer.GroupPublicKeyBytes(),
+				),
+				ChannelName: membership.ChannelName,
+				Archived:    directory == archivedDataDir,
+			}
+			groupsByKey[publicKey] = group
+		}
+
+		group.MemberIndexes = append(
+			group.MemberIndexes,
+			uint8(membership.Signer.MemberID()),
+		)
+	}
+
+	groups := make([]*localGroup, 0, len(groupsByKey))
+	for _, group := range groupsByKey {
+		sort.Slice(group.MemberIndexes, func(i, j int) bool {
+			return group.MemberIndexes[i] < group.MemberIndexes[j]
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].PublicKey < groups[j].PublicKey
+	})
+
+	if c.Bool(offlineFlag) || len(groups) == 0 {
+		return groups, nil
+	}
+
+	chainHandle, err := ethereum.ConnectReadOnly(
+		context.Background(),
+		cfg.Ethereum,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	relayChain := chainHandle.ThresholdRelay()
+	for _, group := range groups {
+		if err := crossCheckGroup(relayChain, group); err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"could not cross-check group [%v] against the chain: [%v]\n",
+				group.PublicKey,
+				err,
+			)
+		}
+	}
+
+	return groups, nil
+}
+
+func crossCheckGroup(relayChain relaychain.Interface, group *localGroup) error {
+	publicKey, err := hex.DecodeString(group.UncompressedPublicKey)
+	if err != nil {
+		return err
+	}
+
+	isRegistered, err := relayChain.IsGroupRegistered(publicKey)
+	if err != nil {
+		return err
+	}
+	group.Registered = &isRegistered
+
+	if !isRegistered {
+		return nil
+	}
+
+	isStale, err := relayChain.IsStaleGroup(publicKey)
+	if err != nil {
+		return err
+	}
+	group.Stale = &isStale
+
+	return nil
+}
+
+func printLocalGroups(c *cli.Context, groups []*localGroup) error {
+	if c.Bool(jsonFlag) {
+		return printJSON(groups)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "PUBLIC KEY\tMEMBER INDEXES\tREGISTERED\tSTALE\tCHANNEL")
+	for _, group := range groups {
+		fmt.Fprintf(
+			writer,
+			"%s\t%v\t%s\t%s\t%s\n",
+			group.PublicKey,
+			group.MemberIndexes,
+			formatOptionalBool(group.Registered),
+			formatOptionalBool(group.Stale),
+			group.ChannelName,
+		)
+	}
+
+	return writer.Flush()
+}
+
+func formatOptionalBool(value *bool) string {
+	if value == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%v", *value)
+}
+
+// readOnlyDirectoryHandle is a persistence handle reading data stored by
+// the disk persistence in the given directory. Unlike the disk persistence
+// handle, it never modifies the data directory. All write operations fail.
+type readOnlyDirectoryHandle struct {
+	path string
+}
+
+func (rodh *readOnlyDirectoryHandle) Save(_ []byte, _, _ string) error {
+	return fmt.Errorf("handle is read-only")
+}
+
+func (rodh *readOnlyDirectoryHandle) Snapshot(_ []byte, _, _ string) error {
+	return fmt.Errorf("handle is read-only")
+}
+
+func (rodh *readOnlyDirectoryHandle) Archive(_ string) error {
+	return fmt.Errorf("handle is read-only")
+}
+
+func (rodh *readOnlyDirectoryHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	dataChannel := make(chan persistence.DataDescriptor)
+	errorChannel := make(chan error)
+
+	go func() {
+		defer close(dataChannel)
+		defer close(errorChannel)
+
+		directories, err := ioutil.ReadDir(rodh.path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errorChannel <- err
+			}
+			return
+		}
+
+		for _, directory := range directories {
+			if !directory.IsDir() {
+				continue
+			}
+
+			directoryPath := filepath.Join(rodh.path, directory.Name())
+			files, err := ioutil.ReadDir(directoryPath)
+			if err != nil {
+				errorChannel <- err
+				continue
+			}
+
+			for _, file := range files {
+				dataChannel <- &fileDataDescriptor{
+					directory: directory.Name(),
+					name:      file.Name(),
+					path:      filepath.Join(directoryPath, file.Name()),
+				}
+			}
+		}
+	}()
+
+	return dataChannel, errorChannel
+}
+
+type fileDataDescriptor struct {
+	directory string
+	name      string
+	path      string
+}
+
+func (fdd *fileDataDescriptor) Name() string {
+	return fdd.name
+}
+
+func (fdd *fileDataDescriptor) Directory() string {
+	return fdd.directory
+}
+
+func (fdd *fileDataDescriptor) Content() ([]byte, error) {
+	return ioutil.ReadFile(fdd.path)
+}
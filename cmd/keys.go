@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// KeysCommand contains the definition of the keys command-line subcommand
+// and its own subcommands.
+var KeysCommand cli.Command
+
+const hostFlag = "host"
+
+// Environment variables holding key file passwords. Set to "prompt" to be
+// prompted for the password instead.
+const (
+	keyFilePasswordEnvVariable    = "KEEP_ETHEREUM_PASSWORD"
+	newKeyFilePasswordEnvVariable = "KEEP_ETHEREUM_NEW_PASSWORD"
+)
+
+const keysDescription = `The keys command manages operator key files.
+
+   The "generate" subcommand creates a new encrypted key file in the given
+   directory, the "inspect" subcommand prints the operator address and the
+   network identity derived from the key, and the "change-password"
+   subcommand re-encrypts the key file with a new password.
+
+   The key file password is read from the KEEP_ETHEREUM_PASSWORD environment
+   variable and the new password for the "change-password" subcommand from the
+   KEEP_ETHEREUM_NEW_PASSWORD environment variable. When a variable is not set
+   or set to "prompt", the password is prompted for.`
+
+func init() {
+	KeysCommand = cli.Command{
+		Name:        "keys",
+		Usage:       `Manages operator key files.`,
+		Description: keysDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:      "generate",
+				Usage:     "Generates a new encrypted key file.",
+				ArgsUsage: "<directory>",
+				Action:    generateKey,
+			},
+			{
+				Name: "inspect",
+				Usage: "Prints the operator address, public key and network " +
+					"identity of the key.",
+				ArgsUsage: "<key-file>",
+				Action:    inspectKey,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  hostFlag,
+						Value: "127.0.0.1",
+						Usage: "IP address or DNS name of the node in the multiaddr",
+					},
+					&cli.IntFlag{
+						Name:  portFlag,
+						Value: 3919,
+						Usage: "port of the node in the multiaddr",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "prints the output in JSON format",
+					},
+				},
+			},
+			{
+				Name:      "change-password",
+				Usage:     "Re-encrypts the key file with a new password.",
+				ArgsUsage: "<key-file>",
+				Action:    changeKeyPassword,
+			},
+		},
+	}
+}
+
+// operatorKeyInfo describes the operator key and the network identity
+// derived from it.
+type operatorKeyInfo struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"publicKey"`
+	PeerID    string `json:"peerId"`
+	Multiaddr string `json:"multiaddr"`
+}
+
+func generateKey(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with directory")
+	}
+
+	password, err := readKeyFilePassword(
+		keyFilePasswordEnvVariable,
+		"Enter Key File Password: ",
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	path, err := operator.GenerateKeyFile(c.Args().First(), password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(path)
+
+	return nil
+}
+
+func inspectKey(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with key file")
+	}
+
+	password, err := readKeyFilePassword(
+		keyFilePasswordEnvVariable,
+		"Enter Key File Password: ",
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	privateKey, publicKey, err := operator.ReadKeyFile(
+		c.Args().First(),
+		password,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not derive peer ID: [%v]", err)
+	}
+
+	info := &operatorKeyInfo{
+		Address:   operator.PubkeyToAddress(*publicKey).Hex(),
+		PublicKey: hex.EncodeToString(operator.CompressPublicKey(publicKey)),
+		PeerID:    peerID.Pretty(),
+		Multiaddr: fmt.Sprintf(
+			"%v/tcp/%v/p2p/%v",
+			hostMultiaddr(c.String(hostFlag)),
+			c.Int(portFlag),
+			peerID.Pretty(),
+		),
+	}
+
+	if c.Bool(jsonFlag) {
+		return printJSON(info)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Operator address:\t%v\n", info.Address)
+	fmt.Fprintf(writer, "Public key:\t%v\n", info.PublicKey)
+	fmt.Fprintf(writer, "Peer ID:\t%v\n", info.PeerID)
+	fmt.Fprintf(writer, "Multiaddr:\t%v\n", info.Multiaddr)
+
+	return writer.Flush()
+}
+
+func changeKeyPassword(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with key file")
+	}
+
+	password, err := readKeyFilePassword(
+		keyFilePasswordEnvVariable,
+		"Enter Current Key File Password: ",
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := readKeyFilePassword(
+		newKeyFilePasswordEnvVariable,
+		"Enter New Key File Password: ",
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	return operator.ChangeKeyFilePassword(
+		c.Args().First(),
+		password,
+		newPassword,
+	)
+}
+
+// hostMultiaddr returns the multiaddr prefix for the given IP address or
+// DNS name.
+func hostMultiaddr(host string) string {
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return "/dns4/" + host
+	case ip.To4() != nil:
+		return "/ip4/" + host
+	default:
+		return "/ip6/" + host
+	}
+}
+
+// readKeyFilePassword reads the password from the given environment
+// variable. If the variable is not set or set to "prompt", the password is
+// prompted for and, optionally, has to be confirmed.
+func readKeyFilePassword(
+	envVariable string,
+	prompt string,
+	confirm bool,
+) (string, error) {
+	password := os.Getenv(envVariable)
+	if password != "" && password != "prompt" {
+		return password, nil
+	}
+
+	password, err := promptPassword(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		return "", fmt.Errorf("password must not be empty")
+	}
+
+	if confirm {
+		confirmation, err := promptPassword("Repeat Password: ")
+		if err != nil {
+			return "", err
+		}
+
+		if confirmation != password {
+			return "", fmt.Errorf("passwords do not match")
+		}
+	}
+
+	return password, nil
+}
+
+func promptPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("could not read password: [%v]", err)
+	}
+
+	return string(bytePassword), nil
+}
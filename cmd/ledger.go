@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
+	"github.com/urfave/cli"
+)
+
+// LedgerCommand contains the definition of the ledger command-line
+// subcommand.
+var LedgerCommand cli.Command
+
+const (
+	typeFlag    = "type"
+	dataDirFlag = "data-dir"
+)
+
+const ledgerDescription = `The ledger command prints the participation ledger
+   stored in the data directory configured in the config file: a record of
+   every ticket submission for group selection, DKG and relay entry signing
+   the client took part in. Each record contains the start and end block of
+   the session, indexes of the members which took part in it, its outcome,
+   members observed as inactive and disqualified during DKG, and the
+   transactions sent.
+
+   The ledger does not contain any secrets, so no password is needed. The
+   command only reads the data directory and can be executed while the
+   client is running.`
+
+func init() {
+	LedgerCommand = cli.Command{
+		Name:        "ledger",
+		Usage:       "Prints the participation ledger stored on disk.",
+		Description: ledgerDescription,
+		Action:      printLedger,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  jsonFlag,
+				Usage: "prints the output in JSON format",
+			},
+			&cli.StringFlag{
+				Name: typeFlag,
+				Usage: fmt.Sprintf(
+					"prints only sessions of the given type: %v, %v or %v",
+					ledger.TicketSubmissionSession,
+					ledger.DKGSession,
+					ledger.SigningSession,
+				),
+			},
+			&cli.Uint64Flag{
+				Name:  fromBlockFlag,
+				Usage: "prints only sessions started at or after the given block",
+			},
+			&cli.StringFlag{
+				Name:  dataDirFlag,
+				Usage: "data directory of the operator; storage data directory from the config by default",
+			},
+		},
+	}
+}
+
+func printLedger(c *cli.Context) error {
+	dataDir := c.String(dataDirFlag)
+	if dataDir == "" {
+		cfg, err := config.ReadConfigWithoutPassword(c.GlobalString("config"))
+		if err != nil {
+			return fmt.Errorf("error reading config file: [%v]", err)
+		}
+		dataDir = cfg.Storage.DataDir
+	}
+
+	sessionType := c.String(typeFlag)
+	switch sessionType {
+	case "", ledger.TicketSubmissionSession, ledger.DKGSession, ledger.SigningSession:
+	default:
+		return fmt.Errorf("unknown session type [%v]", sessionType)
+	}
+
+	records, errors := ledger.ReadRecords(
+		&readOnlyDirectoryHandle{
+			path: filepath.Join(dataDir, ledger.DirectoryName, currentDataDir),
+		},
+	)
+	for _, err := range errors {
+		fmt.Fprintf(os.Stderr, "could not read ledger record: [%v]\n", err)
+	}
+
+	filtered := make([]*ledger.Record, 0, len(records))
+	for _, record := range records {
+		if sessionType != "" && record.Type != sessionType {
+			continue
+		}
+		if record.StartBlock < c.Uint64(fromBlockFlag) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
+	if c.Bool(jsonFlag) {
+		return printJSON(filtered)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(
+		writer,
+		"START BLOCK\tEND BLOCK\tTYPE\tMEMBER INDEXES\tOUTCOME\t"+
+			"TRANSACTIONS\tINACTIVE\tDISQUALIFIED",
+	)
+	for _, record := range filtered {
+		fmt.Fprintf(
+			writer,
+			"%v\t%v\t%s\t%v\t%s\t%s\t%v\t%v\n",
+			record.StartBlock,
+			record.EndBlock,
+			record.Type,
+			record.MemberIndexes,
+			record.Outcome,
+			formatLedgerTransactions(record.Transactions),
+			record.InactiveMembers,
+			record.DisqualifiedMembers,
+		)
+	}
+
+	return writer.Flush()
+}
+
+// formatLedgerTransactions summarizes the transactions of a session as the
+// number of transactions of each kind in each status.
+func formatLedgerTransactions(transactions []*ledger.Transaction) string {
+	if len(transactions) == 0 {
+		return "-"
+	}
+
+	var keys []string
+	counts := make(map[string]int)
+	for _, transaction := range transactions {
+		key := transaction.Kind + " " + transaction.Status
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+
+	summary := make([]string, len(keys))
+	for i, key := range keys {
+		summary[i] = fmt.Sprintf("%v %v", counts[key], key)
+	}
+
+	return strings.Join(summary, ", ")
+}
@@ -2,345 +2,452 @@ package cmd
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"encoding/json"
 	"fmt"
-	"math/big"
 	"os"
-	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/crypto/secp256k1"
-	"github.com/google/uuid"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/admin"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/firewall"
-	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/ping"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/urfave/cli"
 )
 
-// PingCommand contains the definition of the ping command-line subcommand.
-var PingCommand cli.Command
+// NetCommand contains the definition of the net command-line subcommand and
+// its own subcommands.
+var NetCommand cli.Command
 
 const (
-	ping         = "PING"
-	pong         = "PONG"
-	messageCount = 64 * 64
+	countFlag    = "count"
+	intervalFlag = "interval"
 )
 
-const pingDescription = `The ping command conducts a simple peer-to-peer test
-   between a bootstrap node and another peer: can known peers communicate over
-   a peer-to-peer network. Both peers send a "PING" and expect to receive a
-   corresponding "PONG". Notably, this does not exercise peer discovery.`
+const netDescription = `The net command helps to debug the connectivity with
+   other peers of the network.
+
+   The "ping" subcommand opens a unicast channel with the given peer using
+   the regular handshake and minimum stake firewall, and sends it a number of
+   probes. It reports the round-trip time statistics, the packet loss and
+   whether the remote peer passes the minimum stake policy. The remote peer
+   answers the probes only if it runs the client with the "start" command.
+
+   If the client configured in the configuration file is running and its
+   admin API is enabled, the probes are sent by the running client through
+   the admin API, so that they do not come from a second peer with the same
+   ID. The peer has to be given by its ID then. Otherwise, the command
+   connects to the network with the operator key from the configuration
+   file; the peer can be given by its ID, in which case it is looked up
+   through the configured bootstrap peers, or by its full multiaddr. Do not
+   run the command this way with the key of a running client without the
+   admin API.`
 
 func init() {
-	PingCommand =
-		cli.Command{
-			Name:        "ping",
-			Usage:       `bidirectional send between two peers to test the network`,
-			ArgsUsage:   "[multiaddr]",
-			Description: pingDescription,
-			Action:      pingRequest,
-		}
-}
-
-func isBootstrapNode(args cli.Args) (bool, []string) {
-	var bootstrapPeers []string
-
-	// Not a bootstrap node
-	if len(args) > 0 {
-		bootstrapPeers = append(bootstrapPeers, args.Get(0))
+	NetCommand = cli.Command{
+		Name:        "net",
+		Usage:       `Debugs the connectivity with other peers.`,
+		Description: netDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:      "ping",
+				Usage:     "Measures the round-trip time to the given peer.",
+				ArgsUsage: "<peer-id|multiaddr>",
+				Action:    pingPeer,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  countFlag,
+						Value: 10,
+						Usage: "number of probes to send",
+					},
+					&cli.DurationFlag{
+						Name:  intervalFlag,
+						Value: time.Second,
+						Usage: "time between consecutive probes",
+					},
+					&cli.DurationFlag{
+						Name:  timeoutFlag,
+						Value: 5 * time.Second,
+						Usage: "time after which an unanswered probe is lost",
+					},
+					&cli.IntFlag{
+						Name:  portFlag,
+						Usage: "port to listen on; random if not set",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "prints the output in JSON format",
+					},
+				},
+			},
+		},
 	}
-
-	return len(bootstrapPeers) == 0, bootstrapPeers
 }
 
-// pingRequest tests the functionality and availability of Keep's libp2p
-// network layer.
-func pingRequest(c *cli.Context) error {
-	isBootstrapNode, bootstrapPeers := isBootstrapNode(c.Args())
-	var (
-		libp2pConfig = libp2p.Config{Peers: bootstrapPeers}
-		ctx          = context.Background()
-		privKey      *key.NetworkPrivate
-	)
+// pingResult describes the outcome of probing the remote peer.
+type pingResult struct {
+	PeerID                   string  `json:"peerId"`
+	OperatorAddress          string  `json:"operatorAddress"`
+	Sent                     int     `json:"sent"`
+	Received                 int     `json:"received"`
+	Loss                     float64 `json:"loss"`
+	MinRTT                   float64 `json:"minRttMs"`
+	AverageRTT               float64 `json:"avgRttMs"`
+	P99RTT                   float64 `json:"p99RttMs"`
+	MinimumStakePolicyPassed bool    `json:"minimumStakePolicyPassed"`
+	MinimumStakePolicyError  string  `json:"minimumStakePolicyError,omitempty"`
+}
 
-	bootstrapPeerPrivKey, _ := getBootstrapPeerNetworkKey()
-	standardPeerPrivKey, _ := getStandardPeerNetworkKey()
+func pingPeer(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument with peer ID or multiaddr")
+	}
 
-	if isBootstrapNode {
-		privKey = bootstrapPeerPrivKey
-	} else {
-		privKey = standardPeerPrivKey
+	if c.Int(countFlag) < 1 {
+		return fmt.Errorf("number of probes must be positive")
 	}
 
-	netProvider, err := libp2p.Connect(
-		ctx,
-		libp2pConfig,
-		privKey,
-		libp2p.ProtocolBeacon,
-		firewall.Disabled,
-		retransmission.NewTimeTicker(ctx, 50*time.Millisecond),
-	)
+	remotePeerID, remoteAddress, err := parsePeer(c.Args().First())
 	if err != nil {
 		return err
 	}
 
-	if isBootstrapNode {
-		var bootstrapAddr string
-		for _, addr := range netProvider.ConnectionManager().AddrStrings() {
-			if strings.Contains(addr, "ip4") && !strings.Contains(addr, "127.0.0.1") {
-				bootstrapAddr = addr
-				break
-			}
-		}
-
-		fmt.Printf("You can ping this node using:\n"+
-			"    %s ping %s\n\n",
-			c.App.Name,
-			bootstrapAddr,
+	remotePublicKey, err := remotePeerID.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf(
+			"could not extract public key from peer ID [%v]: [%v]",
+			remotePeerID,
+			err,
 		)
 	}
+	remoteNetworkPublicKey := key.Libp2pKeyToNetworkKey(remotePublicKey)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	go cancelOnShutdownSignal(cancelCtx)
 
-	// When we call ChannelFor, we create a coordination point for peers
-	broadcastChannel, err := netProvider.BroadcastChannelFor(ping)
+	// The password is read only if the command connects to the network by
+	// itself; probing through the running client needs neither the operator
+	// key nor the connection to the chain.
+	cfg, err := config.ReadConfigWithoutPassword(c.GlobalString("config"))
 	if err != nil {
-		return err
+		return fmt.Errorf("error reading config file: %v", err)
 	}
 
-	// PingMessage and PongMessage conform to the net.Message interface
-	// (Type, Unmarshal, Marshal); ensure our network knows how to serialize
-	// them when sending over the wire
-	broadcastChannel.SetUnmarshaler(
-		func() net.TaggedUnmarshaler { return &PingMessage{} },
-	)
-	broadcastChannel.SetUnmarshaler(
-		func() net.TaggedUnmarshaler { return &PongMessage{} },
-	)
-
-	var (
-		pingChan = make(chan net.Message)
-		pongChan = make(chan net.Message)
-	)
+	result := &pingResult{
+		PeerID:          remotePeerID.Pretty(),
+		OperatorAddress: key.NetworkPubKeyToChainAddress(remoteNetworkPublicKey),
+	}
 
-	broadcastChannel.Recv(ctx, func(msg net.Message) {
-		// Do some message routing
-		if msg.Type() == pong {
-			pongChan <- msg
+	printJSONOutput := c.Bool(jsonFlag)
+	onProbe := func(sequence uint64, rtt time.Duration, err error) {
+		if printJSONOutput {
+			return
 		}
-	})
 
-	broadcastChannel.Recv(ctx, func(msg net.Message) {
-		// Do some message routing
-		if msg.Type() == ping {
-			pingChan <- msg
+		if err != nil {
+			fmt.Printf("probe [%v]: lost: %v\n", sequence, err)
+			return
 		}
-	})
-
-	// Give ourselves a moment to form a mesh with the other peer
-	for {
-		time.Sleep(3 * time.Second)
-		peers := netProvider.ConnectionManager().ConnectedPeers()
-		if len(peers) < 1 {
-			fmt.Println("waiting for peer...")
-			continue
-		}
-		break
+
+		fmt.Printf("probe [%v]: rtt [%v]\n", sequence, rtt)
 	}
 
-	start := make(chan struct{})
-	receivedMessages := make(map[string]bool)
+	var probeResult *ping.Result
+	if adminClient := runningClientAdmin(cfg); adminClient != nil {
+		if remoteAddress != "" {
+			return fmt.Errorf(
+				"the running client looks peers up by their IDs; " +
+					"give the peer ID instead of the multiaddr",
+			)
+		}
 
-	for i := 1; i <= messageCount; i++ {
-		message := &PingMessage{
-			Sender:  netProvider.ID().String(),
-			Payload: ping + " number " + strconv.Itoa(i),
+		probeResult, err = probeThroughAdmin(
+			c,
+			adminClient,
+			remotePeerID,
+			result,
+		)
+	} else {
+		cfg, err = config.ReadConfig(c.GlobalString("config"))
+		if err != nil {
+			return fmt.Errorf("error reading config file: %v", err)
 		}
 
-		go func(msg *PingMessage) {
-			<-start
-			err := broadcastChannel.Send(ctx, message)
-			if err != nil {
-				fmt.Fprintf(
-					os.Stderr,
-					"Error while sending PING with payload [%v]: [%v]\n",
-					message.Payload,
-					err,
-				)
-			} else {
-				fmt.Printf("Sent PING with payload [%v]\n", message.Payload)
-			}
-		}(message)
-	}
-
-	close(start)
-
-	for {
-		select {
-		case msg := <-pingChan:
-			// don't read our own ping
-			if msg.TransportSenderID().String() == netProvider.ID().String() {
-				continue
-			}
-			pingPayload, ok := msg.Payload().(*PingMessage)
-			if !ok {
-				return fmt.Errorf(
-					"expected: payload type PingMessage\nactual:   payload type [%v]",
-					pingPayload,
-				)
-			}
-
-			message := &PongMessage{
-				Sender:  netProvider.ID().String(),
-				Payload: pong + " corresponding to " + pingPayload.Payload,
-			}
-			err := broadcastChannel.Send(ctx, message)
-			if err != nil {
-				return err
-			}
-		case msg := <-pongChan:
-			// don't read our own pong
-			if msg.TransportSenderID().String() == netProvider.ID().String() {
-				continue
-			}
-			// if you read a pong message, go ahead and ack and close out
-			pongPayload, ok := msg.Payload().(*PongMessage)
-			if !ok {
-				return fmt.Errorf(
-					"expected: payload type PongMessage\nactual:   payload type [%v]",
-					pongPayload,
-				)
-			}
-
-			fmt.Printf(
-				"Received PONG from [%s] with payload [%v]\n",
-				msg.TransportSenderID().String(),
-				pongPayload.Payload,
+		probeResult, err = probeStandalone(
+			ctx,
+			c,
+			cfg,
+			remoteAddress,
+			remoteNetworkPublicKey,
+			result,
+			onProbe,
+		)
+	}
+	if err != nil && probeResult == nil {
+		if result.MinimumStakePolicyError != "" {
+			return fmt.Errorf(
+				"%v; remote peer does not pass the minimum stake policy: [%v]",
+				err,
+				result.MinimumStakePolicyError,
 			)
-
-			receivedMessages[pongPayload.Payload] = true
-
-			if len(receivedMessages) == messageCount {
-				fmt.Println("All expected messages received")
-			}
-		case <-ctx.Done():
-			err := ctx.Err()
-			if err != nil {
-				fmt.Fprintf(
-					os.Stderr,
-					"Request errored out: [%v].\n",
-					err,
-				)
-			} else {
-				fmt.Fprintf(os.Stderr, "Request errored for unknown reason.\n")
-			}
-
-			os.Exit(1)
 		}
+		return err
+	}
+	// When interrupted, the statistics of the probes sent so far are still
+	// reported.
+	if err != nil && err != context.Canceled {
+		return err
 	}
-}
-
-// PingMessage is a network message sent between bootstrap peer and
-// non-bootstrap peer in order to test the connection.
-type PingMessage struct {
-	Sender  string
-	Payload string
-}
 
-// Type returns a string type of the `PingMessage` so that it conforms to
-// `net.Message` interface.
-func (pm *PingMessage) Type() string {
-	return ping
-}
+	result.Sent = probeResult.Sent
+	result.Received = probeResult.Received
+	result.Loss = probeResult.Loss()
+	result.MinRTT = milliseconds(probeResult.Min())
+	result.AverageRTT = milliseconds(probeResult.Average())
+	result.P99RTT = milliseconds(probeResult.Percentile(99))
 
-// Marshal converts this PingMessage to a byte array suitable for network
-// communication.
-func (pm *PingMessage) Marshal() ([]byte, error) {
-	return json.Marshal(pm)
-}
-
-// Unmarshal converts a byte array produced by Marshal to a PingMessage.
-func (pm *PingMessage) Unmarshal(bytes []byte) error {
-	var message PingMessage
-	if err := json.Unmarshal(bytes, &message); err != nil {
+	if printJSONOutput {
+		err = printJSON(result)
+	} else {
+		err = printPingResult(result)
+	}
+	if err != nil {
 		return err
 	}
-	pm.Sender = message.Sender
-	pm.Payload = message.Payload
+
+	if result.Received == 0 {
+		return fmt.Errorf("peer [%v] did not answer any probe", result.PeerID)
+	}
 
 	return nil
 }
 
-// PongMessage is a network message sent between bootstrap peer and
-// non-bootstrap peer in order to test the connection.
-type PongMessage struct {
-	Sender  string
-	Payload string
-}
+// runningClientAdmin returns the admin API client of the client configured
+// in the config file if that client is running. It returns nil if the admin
+// API is not configured or does not answer.
+func runningClientAdmin(cfg *config.Config) *admin.Client {
+	if cfg.IsMultiOperator() {
+		return nil
+	}
 
-// Type returns a string type of the `PongMessage` so that it conforms to
-// `net.Message` interface.
-func (pm *PongMessage) Type() string {
-	return pong
-}
+	client, err := admin.NewClient(
+		cfg.Admin.Port,
+		cfg.Admin.Socket,
+		adminCookiePath(cfg),
+	)
+	if err != nil {
+		return nil
+	}
+
+	// The cookie file may be left by a client which has not shut down
+	// gracefully, so the API is asked whether the client is still running.
+	if err := client.Get(admin.GoroutinesPath, &admin.Goroutines{}); err != nil {
+		return nil
+	}
 
-// Marshal converts this PongMessage to a byte array suitable for network
-// communication.
-func (pm *PongMessage) Marshal() ([]byte, error) {
-	return json.Marshal(pm)
+	return client
 }
 
-// Unmarshal converts a byte array produced by Marshal to a PongMessage.
-func (pm *PongMessage) Unmarshal(bytes []byte) error {
-	var message PongMessage
-	if err := json.Unmarshal(bytes, &message); err != nil {
-		return err
+// probeThroughAdmin makes the running client probe the remote peer with its
+// own network identity and check the remote peer against its minimum stake
+// policy.
+func probeThroughAdmin(
+	c *cli.Context,
+	adminClient *admin.Client,
+	remotePeerID peer.ID,
+	result *pingResult,
+) (*ping.Result, error) {
+	if !c.Bool(jsonFlag) {
+		fmt.Println("probing the peer from the running client...")
 	}
-	pm.Sender = message.Sender
-	pm.Payload = message.Payload
 
-	return nil
-}
+	pingRequest := &admin.PingPeerRequest{
+		NetworkID: remotePeerID.Pretty(),
+		Count:     c.Int(countFlag),
+		Interval:  c.Duration(intervalFlag),
+		Timeout:   c.Duration(timeoutFlag),
+	}
 
-// getBootstrapPeerNetworkKey returns hardcoded public and private network key
-// of the bootstrap peer. We hardcode those values because we need to initialize
-// stakes on both sides of the connection using the local, stubbed `StakeMonitor`.
-func getBootstrapPeerNetworkKey() (*key.NetworkPrivate, *key.NetworkPublic) {
-	return getPeerNetworkKey(big.NewInt(128838122312))
-}
+	pingResult := &admin.PingResult{}
+	err := adminClient.WithRequestDuration(pingRequest.Duration()).Post(
+		admin.PingPeerPath,
+		pingRequest,
+		pingResult,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-// getStandardPeerNetworkKey returns hardcoded public and private network key
-// of the standard peer. We hardcode those values because we need to initialize
-// stake on both sides of the connection using local, stubbed `StakeMonitor`.
-func getStandardPeerNetworkKey() (*key.NetworkPrivate, *key.NetworkPublic) {
-	return getPeerNetworkKey(big.NewInt(6743262236222))
+	result.MinimumStakePolicyError = pingResult.MinimumStakePolicyError
+	result.MinimumStakePolicyPassed = pingResult.MinimumStakePolicyError == ""
+
+	return &ping.Result{
+		Sent:           pingResult.Sent,
+		Received:       pingResult.Received,
+		RoundTripTimes: pingResult.RoundTripTimes,
+	}, nil
 }
 
-func getPeerNetworkKey(privateEcdsaKey *big.Int) (
-	*key.NetworkPrivate,
-	*key.NetworkPublic,
-) {
-	curve := secp256k1.S256()
-
-	ecdsaKey := new(ecdsa.PrivateKey)
-	ecdsaKey.PublicKey.Curve = curve
-	ecdsaKey.D = privateEcdsaKey
-	ecdsaKey.PublicKey.X, ecdsaKey.PublicKey.Y = curve.ScalarBaseMult(
-		ecdsaKey.D.Bytes(),
+// probeStandalone connects to the network with the operator key from the
+// config file, checks the remote peer against the minimum stake policy and
+// probes it.
+func probeStandalone(
+	ctx context.Context,
+	c *cli.Context,
+	config *config.Config,
+	remoteAddress string,
+	remoteNetworkPublicKey *key.NetworkPublic,
+	result *pingResult,
+	onProbe func(sequence uint64, rtt time.Duration, err error),
+) (*ping.Result, error) {
+	chainProvider, err := ethereum.Connect(ctx, config.Ethereum)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	stakeMonitor, err := chainProvider.StakeMonitor()
+	if err != nil {
+		return nil, fmt.Errorf("error obtaining stake monitor handle [%v]", err)
+	}
+
+	minimumStakePolicy := firewall.MinimumStakePolicy(stakeMonitor)
+	err = minimumStakePolicy.Validate(
+		key.NetworkKeyToECDSAKey(remoteNetworkPublicKey),
+	)
+	if err != nil {
+		result.MinimumStakePolicyError = err.Error()
+	} else {
+		result.MinimumStakePolicyPassed = true
+	}
+
+	ethereumKey, err := ethutil.DecryptKeyFile(
+		config.Ethereum.Account.KeyFile,
+		config.Ethereum.Account.KeyFilePassword,
 	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read key file [%s]: [%v]",
+			config.Ethereum.Account.KeyFile,
+			err,
+		)
+	}
 
-	ethereumKey := &keystore.Key{
-		Id:         uuid.New(),
-		Address:    crypto.PubkeyToAddress(ecdsaKey.PublicKey),
-		PrivateKey: ecdsaKey,
+	blockCounter, err := chainProvider.BlockCounter()
+	if err != nil {
+		return nil, err
+	}
+
+	libp2pConfig := config.LibP2P
+	libp2pConfig.Port = c.Int(portFlag)
+	if remoteAddress != "" {
+		libp2pConfig.Peers = append(libp2pConfig.Peers, remoteAddress)
 	}
 
-	return key.OperatorKeyToNetworkKey(
+	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
 		operator.ChainKeyToOperatorKey(ethereumKey),
 	)
+	netProvider, err := libp2p.Connect(
+		ctx,
+		libp2pConfig,
+		networkPrivateKey,
+		libp2p.ProtocolBeacon,
+		minimumStakePolicy,
+		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer netProvider.Close()
+
+	transportID, err := netProvider.CreateTransportIdentifier(
+		*key.NetworkKeyToECDSAKey(remoteNetworkPublicKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ping.Probe(
+		ctx,
+		netProvider,
+		transportID,
+		c.Int(countFlag),
+		c.Duration(intervalFlag),
+		c.Duration(timeoutFlag),
+		onProbe,
+	)
+}
+
+// parsePeer parses the peer given either as a peer ID or as a multiaddr
+// ending with the peer ID. The multiaddr is returned only if it was given.
+func parsePeer(value string) (peer.ID, string, error) {
+	if !strings.HasPrefix(value, "/") {
+		peerID, err := peer.IDB58Decode(value)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid peer ID [%v]: [%v]", value, err)
+		}
+
+		return peerID, "", nil
+	}
+
+	address, err := multiaddr.NewMultiaddr(value)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid multiaddr [%v]: [%v]", value, err)
+	}
+
+	addrInfo, err := peer.AddrInfoFromP2pAddr(address)
+	if err != nil {
+		return "", "", fmt.Errorf(
+			"multiaddr [%v] does not contain a valid peer ID: [%v]",
+			value,
+			err,
+		)
+	}
+
+	return addrInfo.ID, value, nil
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+func printPingResult(result *pingResult) error {
+	stakePolicy := "passed"
+	if !result.MinimumStakePolicyPassed {
+		stakePolicy = "failed: " + result.MinimumStakePolicyError
+	}
+
+	fmt.Println()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Peer ID:\t%v\n", result.PeerID)
+	fmt.Fprintf(writer, "Operator address:\t%v\n", result.OperatorAddress)
+	fmt.Fprintf(writer, "Minimum stake policy:\t%v\n", stakePolicy)
+	fmt.Fprintf(
+		writer,
+		"Probes:\t%v sent, %v received, %.1f%% loss\n",
+		result.Sent,
+		result.Received,
+		result.Loss*100,
+	)
+	if result.Received > 0 {
+		fmt.Fprintf(
+			writer,
+			"RTT min/avg/p99:\t%.3f/%.3f/%.3f ms\n",
+			result.MinRTT,
+			result.AverageRTT,
+			result.P99RTT,
+		)
+	}
+
+	return writer.Flush()
 }
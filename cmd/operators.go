@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/urfave/cli"
+)
+
+// startOperators starts a node for each operator listed in the Operators
+// section of the config. All the operators share one Ethereum client, block
+// counter and event subscriptions; each of them has its own network
+// identity, storage and beacon node. An operator which could not be started
+// is skipped so that it does not affect the other operators. The nodes run
+// until the context is done and then they are shut down gracefully.
+func startOperators(
+	ctx context.Context,
+	c *cli.Context,
+	config *config.Config,
+) error {
+	var operatorKeys []*keystore.Key
+	var operatorSettingsList []operatorSettings
+
+	for i, operator := range config.Operators {
+		keyFilePassword, storagePassword := config.OperatorPasswords(i)
+
+		ethereumKey, err := ethutil.DecryptKeyFile(
+			operator.KeyFile,
+			keyFilePassword,
+		)
+		if err != nil {
+			logger.Errorf(
+				"skipping operator with key file [%s]; "+
+					"failed to read key file: [%v]",
+				operator.KeyFile,
+				err,
+			)
+			continue
+		}
+
+		operatorKeys = append(operatorKeys, ethereumKey)
+		operatorSettingsList = append(operatorSettingsList, operatorSettings{
+			port:               operator.Port,
+			announcedAddresses: operator.AnnouncedAddresses,
+			dataDir:            operator.DataDir,
+			storagePassword:    storagePassword,
+		})
+	}
+
+	if len(operatorKeys) == 0 {
+		return fmt.Errorf("could not read key file of any operator")
+	}
+
+	chainProviders, err := ethereum.ConnectOperators(
+		ctx,
+		config.Ethereum,
+		operatorKeys,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	// The network must outlive the root context so that the in-flight work
+	// can still communicate with other group members during the shutdown.
+	// It is closed explicitly once that work is done.
+	netCtx, cancelNetCtx := context.WithCancel(context.Background())
+	defer cancelNetCtx()
+
+	startedOperators := make([]*operatorNode, len(operatorKeys))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(operatorKeys))
+	for i := range operatorKeys {
+		go func(i int) {
+			defer wg.Done()
+
+			operatorNode, err := startOperator(
+				ctx,
+				netCtx,
+				c,
+				config,
+				operatorSettingsList[i],
+				chainProviders[i],
+				operatorKeys[i].Address.Hex(),
+				networkKey(operatorKeys[i]),
+			)
+			if err != nil {
+				logger.Errorf(
+					"skipping operator [%v]; failed to start: [%v]",
+					operatorKeys[i].Address.Hex(),
+					err,
+				)
+				return
+			}
+
+			startedOperators[i] = operatorNode
+		}(i)
+	}
+	wg.Wait()
+
+	var operatorNodes []*operatorNode
+	var netProviders []net.Provider
+	for _, operatorNode := range startedOperators {
+		if operatorNode == nil {
+			continue
+		}
+
+		logger.Infof(
+			"started operator [%v] listening on %v",
+			operatorNode.address,
+			operatorNode.netProvider.ConnectionManager().AddrStrings(),
+		)
+
+		operatorNodes = append(operatorNodes, operatorNode)
+		netProviders = append(netProviders, operatorNode.netProvider)
+	}
+
+	if len(operatorNodes) == 0 {
+		return fmt.Errorf("could not start any operator")
+	}
+
+	logger.Infof(
+		"started [%v] out of [%v] operators",
+		len(operatorNodes),
+		len(config.Operators),
+	)
+
+	reloader := newConfigReloader(
+		c.GlobalString("config"),
+		c.Int(portFlag),
+		config,
+		netProviders,
+		chainProviders[0],
+	)
+
+	registry, isConfigured := metrics.InitializeLabelled(config.Metrics.Port)
+	if isConfigured {
+		logger.Infof("enabled metrics on port [%v]", config.Metrics.Port)
+		for _, operatorNode := range operatorNodes {
+			observeOperatorMetrics(
+				ctx,
+				registry.WithLabel("operator", operatorNode.address),
+				config,
+				operatorNode,
+				reloader,
+			)
+		}
+	} else {
+		logger.Infof("metrics are not configured")
+	}
+
+	initializeOperatorsDiagnostics(config, operatorNodes)
+
+	// The admin API stays available until the in-flight work is done so
+	// that the operator can observe the shutdown progress.
+	err = initializeAdmin(netCtx, config, operatorNodes...)
+	if err != nil {
+		return fmt.Errorf("error initializing admin API: [%v]", err)
+	}
+
+	go reloader.reloadOnSignal(ctx)
+
+	<-ctx.Done()
+
+	shutdownTimeout := c.Duration(shutdownTimeoutFlag)
+	logger.Infof(
+		"shutting down; waiting up to [%v] for in-flight work to complete",
+		shutdownTimeout,
+	)
+
+	shutdownOperators(operatorNodes, shutdownTimeout)
+	cancelNetCtx()
+	for _, operatorNode := range operatorNodes {
+		operatorNode.close()
+	}
+
+	logger.Infof("shutdown completed")
+
+	return nil
+}
+
+// shutdownOperators waits for the in-flight work of all the operators
+// concurrently so that the whole shutdown takes no longer than the timeout.
+func shutdownOperators(
+	operatorNodes []*operatorNode,
+	timeout time.Duration,
+) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(operatorNodes))
+	for _, node := range operatorNodes {
+		go func(node *operatorNode) {
+			defer wg.Done()
+			node.waitForInFlightWork(timeout)
+		}(node)
+	}
+	wg.Wait()
+}
@@ -1,12 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"os"
+	"text/tabwriter"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-core/config"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/urfave/cli"
 )
@@ -15,12 +23,24 @@ import (
 // its own subcommands.
 var RelayCommand cli.Command
 
+const (
+	timeoutFlag = "timeout"
+	verifyFlag  = "verify"
+	watchFlag   = "watch"
+)
+
 const relayDescription = `The relay command allows interacting with Keep's
 	threshold relay. The "request" subcommand allows for requesting a new entry
 	from the relay, which is equivalent to asking for a new random number. This
-	subcommand waits for the entry to appear on-chain and then reports the value.
+	subcommand waits for the entry to appear on-chain and then reports the value
+	along with the request details. With the --verify flag, the entry is
+	checked to be a valid signature of the previous entry created by the group
+	which generated it and the command fails if it is not.
 	The "genesis" subcommand triggers the first group selection. This action 
-    can be done only once when there are no groups on the chain.`
+    can be done only once when there are no groups on the chain.
+	The "status" subcommand prints the state of the relay request currently
+	in progress, including the block at which each member of the selected
+	group becomes eligible to submit the entry and the timeout block.`
 
 func init() {
 	RelayCommand = cli.Command{
@@ -32,19 +52,47 @@ func init() {
 				Name:   "request",
 				Usage:  "Requests a new entry from the relay.",
 				Action: relayRequest,
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name: timeoutFlag,
+						Usage: "fails if the entry is not generated within " +
+							"the given time; waits indefinitely if not set",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "prints the output in JSON format",
+					},
+					&cli.BoolFlag{
+						Name: verifyFlag,
+						Usage: "verifies the entry is a valid group signature " +
+							"of the previous entry",
+					},
+				},
 			},
 			{
 				Name:   "genesis",
 				Usage:  "Performs genesis. Can be executed only one time.",
 				Action: genesis,
 			},
+			{
+				Name:   "status",
+				Usage:  "Prints the state of the relay request in progress.",
+				Action: relayStatus,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  watchFlag,
+						Usage: "prints the state again on every new block",
+					},
+				},
+			},
 		},
 	}
 }
 
-// relayRequest requests a new entry from the threshold relay and prints the
-// request id. By default, it also waits until the associated relay entry is
-// generated and prints out the entry.
+// relayRequest requests a new entry from the threshold relay and waits until
+// the associated relay entry is generated. Then, it prints out the entry along
+// with the request details and, optionally, verifies the entry is a valid
+// group signature of the previous entry.
 func relayRequest(c *cli.Context) error {
 	cfg, err := config.ReadConfig(c.GlobalString("config"))
 	if err != nil {
@@ -56,32 +104,149 @@ func relayRequest(c *cli.Context) error {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
 
-	wait := make(chan struct{})
+	entryChannel := make(chan *event.EntryGenerated, 1)
+	errorChannel := make(chan error, 1)
 
-	fmt.Printf("Requesting for a new relay entry at [%s]\n", time.Now())
+	fmt.Fprintf(
+		os.Stderr,
+		"Requesting for a new relay entry at [%s]\n",
+		time.Now(),
+	)
 
 	utility.RequestRelayEntry().
-		OnSuccess(func(event *event.EntryGenerated) {
-			fmt.Fprintf(
-				os.Stderr,
-				"Relay entry generated with value: [%v].\n",
-				event.Value,
-			)
-			wait <- struct{}{}
+		OnSuccess(func(entry *event.EntryGenerated) {
+			entryChannel <- entry
 		}).
 		OnFailure(func(err error) {
-			fmt.Fprintf(
-				os.Stderr,
-				"Error in requesting relay entry: [%v].\n",
-				err,
-			)
-			wait <- struct{}{}
+			errorChannel <- err
 		})
 
+	var timeoutChannel <-chan time.Time
+	if timeout := c.Duration(timeoutFlag); timeout > 0 {
+		timeoutChannel = time.After(timeout)
+	}
+
+	var generatedEntry *event.EntryGenerated
 	select {
-	case <-wait:
-		return nil
+	case generatedEntry = <-entryChannel:
+	case err := <-errorChannel:
+		return fmt.Errorf("error in requesting relay entry: [%v]", err)
+	case <-timeoutChannel:
+		return fmt.Errorf(
+			"relay entry not generated within [%v]",
+			c.Duration(timeoutFlag),
+		)
+	}
+
+	result := &relayEntryResult{
+		RequestID:          generatedEntry.RequestID.String(),
+		TransactionHash:    generatedEntry.Request.TransactionHash,
+		Fee:                generatedEntry.Request.Fee.String(),
+		RequestBlockNumber: generatedEntry.Request.BlockNumber,
+		PreviousEntry:      fmt.Sprintf("0x%x", generatedEntry.Request.PreviousEntry),
+		GroupPublicKey:     fmt.Sprintf("0x%x", generatedEntry.Request.GroupPublicKey),
+		SigningGroup:       fmt.Sprintf("0x%x", generatedEntry.GroupPublicKey),
+		EntryBlockNumber:   generatedEntry.BlockNumber,
+		Entry:              fmt.Sprintf("0x%x", generatedEntry.Entry),
+		Value:              generatedEntry.Value.String(),
+	}
+
+	var verificationErr error
+	if c.Bool(verifyFlag) {
+		verificationErr = verifyRelayEntry(generatedEntry)
+		isVerified := verificationErr == nil
+		result.Verified = &isVerified
+	}
+
+	if c.Bool(jsonFlag) {
+		err = printJSON(result)
+	} else {
+		err = printRelayEntryResult(result)
+	}
+	if err != nil {
+		return err
 	}
+
+	if verificationErr != nil {
+		return fmt.Errorf(
+			"relay entry verification failed: [%v]",
+			verificationErr,
+		)
+	}
+
+	return nil
+}
+
+// relayEntryResult describes a relay entry generated in response to the
+// relay request.
+type relayEntryResult struct {
+	RequestID          string `json:"requestId"`
+	TransactionHash    string `json:"transactionHash"`
+	Fee                string `json:"fee"`
+	RequestBlockNumber uint64 `json:"requestBlockNumber"`
+	PreviousEntry      string `json:"previousEntry"`
+	GroupPublicKey     string `json:"groupPublicKey"`
+	SigningGroup       string `json:"signingGroupPublicKey"`
+	EntryBlockNumber   uint64 `json:"entryBlockNumber"`
+	Entry              string `json:"entry"`
+	Value              string `json:"value"`
+	Verified           *bool  `json:"verified,omitempty"`
+}
+
+// verifyRelayEntry checks whether the generated entry is a valid signature of
+// the previous entry created by the group which generated the entry and
+// whether the entry value reported by the service contract has been derived
+// from that signature.
+func verifyRelayEntry(generatedEntry *event.EntryGenerated) error {
+	isValid, err := entry.Verify(
+		generatedEntry.Entry,
+		generatedEntry.PreviousEntry,
+		generatedEntry.GroupPublicKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !isValid {
+		return fmt.Errorf(
+			"entry is not a valid signature of the previous entry",
+		)
+	}
+
+	value := new(big.Int).SetBytes(crypto.Keccak256(generatedEntry.Entry))
+	if value.Cmp(generatedEntry.Value) != 0 {
+		return fmt.Errorf(
+			"entry value [%v] does not match the entry; expected [%v]",
+			generatedEntry.Value,
+			value,
+		)
+	}
+
+	return nil
+}
+
+func printRelayEntryResult(result *relayEntryResult) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "Request ID:\t%v\n", result.RequestID)
+	fmt.Fprintf(writer, "Transaction hash:\t%v\n", result.TransactionHash)
+	fmt.Fprintf(writer, "Fee paid (wei):\t%v\n", result.Fee)
+	fmt.Fprintf(writer, "Request block:\t%v\n", result.RequestBlockNumber)
+	fmt.Fprintf(writer, "Previous entry:\t%v\n", result.PreviousEntry)
+	fmt.Fprintf(writer, "Group public key:\t%v\n", result.GroupPublicKey)
+	fmt.Fprintf(
+		writer,
+		"Signing group public key:\t%v\n",
+		result.SigningGroup,
+	)
+	fmt.Fprintf(writer, "Entry block:\t%v\n", result.EntryBlockNumber)
+	fmt.Fprintf(writer, "Entry:\t%v\n", result.Entry)
+	fmt.Fprintf(writer, "Entry value:\t%v\n", result.Value)
+	if result.Verified != nil {
+		fmt.Fprintf(writer, "Verified:\t%v\n", *result.Verified)
+	}
+
+	return writer.Flush()
 }
 
 // genesis kicks off protocol to create the first group.
@@ -102,3 +267,138 @@ func genesis(c *cli.Context) error {
 	}
 	return nil
 }
+
+// relayStatus prints the state of the relay request currently in progress.
+// In the watch mode, the state is printed again on every new block until the
+// process is terminated.
+func relayStatus(c *cli.Context) error {
+	cfg, err := config.ReadConfigWithoutPassword(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	ctx := context.Background()
+
+	chainHandle, err := ethereum.ConnectReadOnly(ctx, cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	blockCounter, err := chainHandle.BlockCounter()
+	if err != nil {
+		return fmt.Errorf("error getting block counter: [%v]", err)
+	}
+
+	relayChain := chainHandle.ThresholdRelay()
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("error getting current block: [%v]", err)
+	}
+
+	if err := printRelayStatus(relayChain, currentBlock); err != nil {
+		return err
+	}
+
+	if !c.Bool(watchFlag) {
+		return nil
+	}
+
+	for currentBlock := range blockCounter.WatchBlocks(ctx) {
+		fmt.Println()
+		if err := printRelayStatus(relayChain, currentBlock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printRelayStatus(
+	relayChain relaychain.Interface,
+	currentBlock uint64,
+) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer writer.Flush()
+
+	fmt.Fprintf(writer, "Current block:\t%v\n", currentBlock)
+
+	isEntryInProgress, err := relayChain.IsEntryInProgress()
+	if err != nil {
+		return fmt.Errorf("error checking if entry is in progress: [%v]", err)
+	}
+
+	fmt.Fprintf(writer, "Entry in progress:\t%v\n", isEntryInProgress)
+	if !isEntryInProgress {
+		return nil
+	}
+
+	startBlock, err := relayChain.CurrentRequestStartBlock()
+	if err != nil {
+		return fmt.Errorf("error getting current request start block: [%v]", err)
+	}
+
+	previousEntry, err := relayChain.CurrentRequestPreviousEntry()
+	if err != nil {
+		return fmt.Errorf(
+			"error getting current request previous entry: [%v]",
+			err,
+		)
+	}
+
+	groupPublicKey, err := relayChain.CurrentRequestGroupPublicKey()
+	if err != nil {
+		return fmt.Errorf(
+			"error getting current request group public key: [%v]",
+			err,
+		)
+	}
+
+	members, err := relayChain.GetGroupMembers(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("error getting group members: [%v]", err)
+	}
+
+	chainConfig := relayChain.GetConfig()
+	timeoutBlock := startBlock.Uint64() + chainConfig.RelayEntryTimeout
+
+	fmt.Fprintf(writer, "Request start block:\t%v\n", startBlock)
+	fmt.Fprintf(writer, "Previous entry:\t0x%x\n", previousEntry)
+	fmt.Fprintf(writer, "Selected group:\t0x%x\n", groupPublicKey)
+	fmt.Fprintf(
+		writer,
+		"Timeout block:\t%v\t(%v)\n",
+		timeoutBlock,
+		describeBlockDistance(currentBlock, timeoutBlock),
+	)
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "MEMBER\tOPERATOR\tELIGIBLE AT BLOCK\t")
+	for i, member := range members {
+		memberIndex := group.MemberIndex(i + 1)
+		eligibilityBlock := entry.SubmissionEligibilityBlock(
+			startBlock.Uint64(),
+			memberIndex,
+			chainConfig.ResultPublicationBlockStep,
+		)
+
+		fmt.Fprintf(
+			writer,
+			"%v\t%v\t%v\t(%v)\n",
+			memberIndex,
+			common.BytesToAddress(member).Hex(),
+			eligibilityBlock,
+			describeBlockDistance(currentBlock, eligibilityBlock),
+		)
+	}
+
+	return nil
+}
+
+func describeBlockDistance(currentBlock, block uint64) string {
+	if block > currentBlock {
+		return fmt.Sprintf("in %v blocks", block-currentBlock)
+	}
+
+	return fmt.Sprintf("%v blocks ago", currentBlock-block)
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/logging"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// reconfigurableNetwork is implemented by network providers whose bootstrap
+// peers and dissemination time can be changed while they run.
+type reconfigurableNetwork interface {
+	SetBootstrapPeers(ctx context.Context, peers []string) error
+	SetDisseminationTime(disseminationTime int) error
+}
+
+// reconfigurableChain is implemented by chain handles whose request rate
+// limits can be changed while they run.
+type reconfigurableChain interface {
+	SetRateLimits(requestsPerSecondLimit int, concurrencyLimit int)
+}
+
+var errNotReconfigurable = fmt.Errorf("provider can not be reconfigured")
+
+// configReloader re-reads the configuration file on SIGHUP and applies the
+// changes which can be made safely without a restart. Changes requiring a
+// restart are logged and refused; the client keeps running with the previous
+// values of the changed fields.
+type configReloader struct {
+	configPath   string
+	portOverride int
+
+	mutex  sync.Mutex
+	config *config.Config
+
+	// netProviders are the network providers of all the operators run by
+	// the client.
+	netProviders []net.Provider
+	// chainProvider is the chain handle of any of the operators as all of
+	// them share the same Ethereum client; nil if the client is not
+	// connected to the chain.
+	chainProvider chain.ReadOnlyHandle
+
+	networkObservations  []*metrics.Observation
+	ethereumObservations []*metrics.Observation
+}
+
+func newConfigReloader(
+	configPath string,
+	portOverride int,
+	config *config.Config,
+	netProviders []net.Provider,
+	chainProvider chain.ReadOnlyHandle,
+) *configReloader {
+	return &configReloader{
+		configPath:    configPath,
+		portOverride:  portOverride,
+		config:        config,
+		netProviders:  netProviders,
+		chainProvider: chainProvider,
+	}
+}
+
+// addObservations registers metric observations whose ticks are changed
+// when the configuration is reloaded. Observations which could not be
+// started are skipped.
+func (cr *configReloader) addObservations(
+	networkObservations []*metrics.Observation,
+	ethereumObservations []*metrics.Observation,
+) {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+
+	for _, observation := range networkObservations {
+		if observation != nil {
+			cr.networkObservations = append(
+				cr.networkObservations,
+				observation,
+			)
+		}
+	}
+	for _, observation := range ethereumObservations {
+		if observation != nil {
+			cr.ethereumObservations = append(
+				cr.ethereumObservations,
+				observation,
+			)
+		}
+	}
+}
+
+// bootstrapPeers returns the current list of bootstrap peers.
+func (cr *configReloader) bootstrapPeers() []string {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+
+	return cr.config.LibP2P.Peers
+}
+
+// reloadOnSignal reloads the configuration every time SIGHUP is received,
+// until the context is done.
+func (cr *configReloader) reloadOnSignal(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-signals:
+			logger.Infof("received SIGHUP signal; reloading configuration")
+			cr.reload(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (cr *configReloader) reload(ctx context.Context) {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+
+	updated, err := config.ReadConfigWithoutPassword(cr.configPath)
+	if err != nil {
+		logger.Errorf("could not reload configuration: [%v]", err)
+		return
+	}
+
+	if cr.portOverride > 0 {
+		updated.LibP2P.Port = cr.portOverride
+	}
+
+	changes := config.Diff(cr.config, updated)
+	if len(changes) == 0 {
+		logger.Infof("configuration has not changed")
+		return
+	}
+
+	// Start from the current config so that the refused and failed changes
+	// are reported again on the next reload.
+	next := *cr.config
+	for _, change := range changes {
+		if !change.IsReloadable() {
+			logger.Warningf(
+				"refusing configuration change requiring a restart: [%v]",
+				change,
+			)
+			continue
+		}
+
+		if err := cr.apply(ctx, change.Field, updated, &next); err != nil {
+			logger.Errorf(
+				"could not apply configuration change [%v]: [%v]",
+				change,
+				err,
+			)
+			continue
+		}
+
+		logger.Infof("applied configuration change: [%v]", change)
+	}
+
+	cr.config = &next
+}
+
+// apply applies the change of the given reloadable field from the updated
+// config and records the new value in the next config.
+func (cr *configReloader) apply(
+	ctx context.Context,
+	field string,
+	updated *config.Config,
+	next *config.Config,
+) error {
+	switch field {
+	case "LibP2P.Peers":
+		err := cr.reconfigureNetworks(func(network reconfigurableNetwork) error {
+			return network.SetBootstrapPeers(ctx, updated.LibP2P.Peers)
+		})
+		if err != nil {
+			return err
+		}
+		next.LibP2P.Peers = updated.LibP2P.Peers
+
+	case "LibP2P.DisseminationTime":
+		err := cr.reconfigureNetworks(func(network reconfigurableNetwork) error {
+			return network.SetDisseminationTime(
+				updated.LibP2P.DisseminationTime,
+			)
+		})
+		if err != nil {
+			return err
+		}
+		next.LibP2P.DisseminationTime = updated.LibP2P.DisseminationTime
+
+	case "Metrics.NetworkMetricsTick":
+		tick := time.Duration(updated.Metrics.NetworkMetricsTick) * time.Second
+		for _, observation := range cr.networkObservations {
+			observation.SetTick(tick)
+		}
+		next.Metrics.NetworkMetricsTick = updated.Metrics.NetworkMetricsTick
+
+	case "Metrics.EthereumMetricsTick":
+		tick := time.Duration(updated.Metrics.EthereumMetricsTick) * time.Second
+		for _, observation := range cr.ethereumObservations {
+			observation.SetTick(tick)
+		}
+		next.Metrics.EthereumMetricsTick = updated.Metrics.EthereumMetricsTick
+
+	case "Ethereum.RequestsPerSecondLimit", "Ethereum.ConcurrencyLimit":
+		if next.Ethereum.RequestsPerSecondLimit == updated.Ethereum.RequestsPerSecondLimit &&
+			next.Ethereum.ConcurrencyLimit == updated.Ethereum.ConcurrencyLimit {
+			// Both limits have been already applied with the other field.
+			return nil
+		}
+
+		chain, ok := cr.chainProvider.(reconfigurableChain)
+		if !ok {
+			return errNotReconfigurable
+		}
+		chain.SetRateLimits(
+			updated.Ethereum.RequestsPerSecondLimit,
+			updated.Ethereum.ConcurrencyLimit,
+		)
+		next.Ethereum.RequestsPerSecondLimit = updated.Ethereum.RequestsPerSecondLimit
+		next.Ethereum.ConcurrencyLimit = updated.Ethereum.ConcurrencyLimit
+
+	case "Logging.Level":
+		if os.Getenv("LOG_LEVEL") != "" {
+			logger.Warningf(
+				"LOG_LEVEL environment variable is set and takes " +
+					"precedence over the configured log level",
+			)
+		} else if err := logging.Configure(updated.Logging.Level); err != nil {
+			return err
+		}
+		next.Logging.Level = updated.Logging.Level
+	}
+
+	return nil
+}
+
+// reconfigureNetworks applies the change to the network providers of all the
+// operators. The change is considered applied if it has been applied to at
+// least one of them; failures of other providers are logged.
+func (cr *configReloader) reconfigureNetworks(
+	reconfigure func(network reconfigurableNetwork) error,
+) error {
+	var lastErr error
+	applied := 0
+
+	for _, netProvider := range cr.netProviders {
+		network, ok := netProvider.(reconfigurableNetwork)
+		if !ok {
+			lastErr = errNotReconfigurable
+			continue
+		}
+
+		if err := reconfigure(network); err != nil {
+			logger.Errorf(
+				"could not reconfigure network provider [%v]: [%v]",
+				netProvider.ID(),
+				err,
+			)
+			lastErr = err
+			continue
+		}
+
+		applied++
+	}
+
+	if applied == 0 {
+		return lastErr
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// cancelOnShutdownSignal blocks until SIGINT or SIGTERM is received and then
+// cancels the context using the provided cancel function. When a second
+// signal is received, the process is terminated immediately without waiting
+// for the graceful shutdown to complete.
+func cancelOnShutdownSignal(cancelCtx context.CancelFunc) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	receivedSignal := <-signals
+	logger.Infof(
+		"received [%v] signal; shutting down gracefully; "+
+			"send the signal again to terminate immediately",
+		receivedSignal,
+	)
+	cancelCtx()
+
+	receivedSignal = <-signals
+	logger.Warningf("received [%v] signal; terminating immediately", receivedSignal)
+	os.Exit(1)
+}
+
+// closablePersistence wraps a persistence handle so that it can be closed on
+// shutdown. Close waits for all the pending operations on the handle to
+// complete and makes all the subsequent operations fail. This way, the
+// process never exits in the middle of writing data to the disk.
+type closablePersistence struct {
+	mutex  sync.RWMutex
+	closed bool
+
+	handle persistence.Handle
+}
+
+func newClosablePersistence(handle persistence.Handle) *closablePersistence {
+	return &closablePersistence{handle: handle}
+}
+
+func (cp *closablePersistence) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	if cp.closed {
+		return fmt.Errorf("persistence handle is closed")
+	}
+
+	return cp.handle.Save(data, directory, name)
+}
+
+func (cp *closablePersistence) Snapshot(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	if cp.closed {
+		return fmt.Errorf("persistence handle is closed")
+	}
+
+	return cp.handle.Snapshot(data, directory, name)
+}
+
+func (cp *closablePersistence) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	return cp.handle.ReadAll()
+}
+
+func (cp *closablePersistence) Archive(directory string) error {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	if cp.closed {
+		return fmt.Errorf("persistence handle is closed")
+	}
+
+	return cp.handle.Archive(directory)
+}
+
+// Close waits for the pending write operations to complete and closes the
+// handle for all the subsequent write operations.
+func (cp *closablePersistence) Close() {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.closed = true
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/signer"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/urfave/cli"
+)
+
+// SignerCommand contains the definition of the signer command-line
+// subcommand.
+var SignerCommand cli.Command
+
+const (
+	socketFlag  = "socket"
+	keyFileFlag = "key-file"
+	chainIDFlag = "chain-id"
+	allowFlag   = "allow"
+)
+
+const signerDescription = `The signer command runs a stand-in external signer holding the
+   operator key, for development and tests of the client configured with the
+   Signer section. The signer serves the Clef account API on the given unix
+   socket and signs transactions only to the addresses given with the --allow
+   flag, for example the operator contract address. Transactions to any
+   address are signed if the flag is not given.
+
+   The key file password is read from the KEEP_ETHEREUM_PASSWORD environment
+   variable. When the variable is not set or set to "prompt", the password is
+   prompted for.
+
+   The stand-in keeps the decrypted key in memory. Use a signer such as Clef
+   with its own rules and key storage in production.`
+
+func init() {
+	SignerCommand = cli.Command{
+		Name:        "signer",
+		Usage:       "Runs a stand-in external signer for development.",
+		Description: signerDescription,
+		Action:      runSigner,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  socketFlag,
+				Usage: "path to the unix socket the signer listens on",
+			},
+			&cli.StringFlag{
+				Name:  keyFileFlag,
+				Usage: "path to the operator key file",
+			},
+			&cli.Int64Flag{
+				Name:  chainIDFlag,
+				Value: 1,
+				Usage: "ID of the chain transactions are signed for",
+			},
+			&cli.StringSliceFlag{
+				Name:  allowFlag,
+				Usage: "address transactions can be sent to; can be repeated",
+			},
+		},
+	}
+}
+
+// connectWithSigner connects to the external signer holding the operator key
+// and connects to the chain as the operator. It returns the chain handle, the
+// operator address and the network identity key of the operator backed by
+// the signer.
+func connectWithSigner(
+	ctx context.Context,
+	config *config.Config,
+) (chain.Handle, string, libp2pcrypto.PrivKey, error) {
+	signerClient, err := signer.Dial(ctx, config.Signer.Endpoint)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	address, err := signerClient.Account(ctx, config.SignerAddress())
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	logger.Infof(
+		"signing as [%v] with external signer [%v]",
+		address.Hex(),
+		config.Signer.Endpoint,
+	)
+
+	networkPrivateKey, err := signer.NewNetworkKey(ctx, signerClient, address)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	chainProvider, err := ethereum.ConnectWithSigner(
+		ctx,
+		config.Ethereum,
+		signerClient,
+		address,
+	)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf(
+			"error connecting to Ethereum node: [%v]",
+			err,
+		)
+	}
+
+	return chainProvider, address.Hex(), networkPrivateKey, nil
+}
+
+func runSigner(c *cli.Context) error {
+	if c.String(socketFlag) == "" {
+		return fmt.Errorf("--%v is required", socketFlag)
+	}
+	if c.String(keyFileFlag) == "" {
+		return fmt.Errorf("--%v is required", keyFileFlag)
+	}
+
+	var allowedRecipients []common.Address
+	for _, address := range c.StringSlice(allowFlag) {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("[%v] is not a valid hex address", address)
+		}
+		allowedRecipients = append(
+			allowedRecipients,
+			common.HexToAddress(address),
+		)
+	}
+
+	password, err := readKeyFilePassword(
+		keyFilePasswordEnvVariable,
+		"Enter Key File Password: ",
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	accountKey, err := ethutil.DecryptKeyFile(c.String(keyFileFlag), password)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to read key file [%s]: [%v]",
+			c.String(keyFileFlag),
+			err,
+		)
+	}
+
+	standIn, err := signer.NewStandIn(
+		accountKey,
+		big.NewInt(c.Int64(chainIDFlag)),
+		signer.Policy{AllowedRecipients: allowedRecipients},
+	)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("unix", c.String(socketFlag))
+	if err != nil {
+		return fmt.Errorf("could not listen on socket: [%v]", err)
+	}
+	defer os.Remove(c.String(socketFlag))
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	go cancelOnShutdownSignal(cancelCtx)
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	logger.Infof(
+		"signing for [%v] on socket [%v]",
+		accountKey.Address.Hex(),
+		c.String(socketFlag),
+	)
+
+	// Serve returns once the listener is closed on shutdown.
+	if err := standIn.Serve(listener); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("signer failed: [%v]", err)
+	}
+
+	standIn.Stop()
+
+	return nil
+}
@@ -3,14 +3,21 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/keep-network/keep-core/pkg/admin"
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
 	"github.com/keep-network/keep-core/pkg/diagnostics"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/logging"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
@@ -19,8 +26,10 @@ import (
 	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/ping"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/operator"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/urfave/cli"
 )
 
@@ -31,13 +40,18 @@ var (
 )
 
 const (
-	bootstrapFlag     = "bootstrap"
-	portFlag          = "port"
-	portShort         = "p"
-	waitForStakeFlag  = "wait-for-stake"
-	waitForStakeShort = "w"
+	bootstrapFlag       = "bootstrap"
+	portFlag            = "port"
+	portShort           = "p"
+	waitForStakeFlag    = "wait-for-stake"
+	waitForStakeShort   = "w"
+	shutdownTimeoutFlag = "shutdown-timeout"
 )
 
+// defaultShutdownTimeout is the default time the client waits for the
+// in-flight DKG and relay entry signing to complete before it shuts down.
+const defaultShutdownTimeout = 10 * time.Minute
+
 const startDescription = `Starts the Keep client in the foreground. Currently this only consists of the
    threshold relay client for the Keep random beacon.`
 
@@ -55,14 +69,26 @@ func init() {
 				&cli.IntFlag{
 					Name: waitForStakeFlag + "," + waitForStakeShort,
 				},
+				&cli.DurationFlag{
+					Name:  shutdownTimeoutFlag,
+					Value: defaultShutdownTimeout,
+					Usage: "time to wait for in-flight DKG and relay entry " +
+						"signing to complete on shutdown",
+				},
 			},
 		}
 }
 
 // Start starts a node; if it's not a bootstrap node it will get the Node.URLs
-// from the config file
+// from the config file. The node runs until it receives SIGINT or SIGTERM.
+// Then, it stops accepting new work, waits for the in-flight work to complete
+// and shuts down gracefully. If the config lists multiple operators, a node
+// is started for each of them.
 func Start(c *cli.Context) error {
-	ctx := context.Background()
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	go cancelOnShutdownSignal(cancelCtx)
 
 	config, err := config.ReadConfig(c.GlobalString("config"))
 	if err != nil {
@@ -73,12 +99,117 @@ func Start(c *cli.Context) error {
 		config.LibP2P.Port = c.Int(portFlag)
 	}
 
+	if config.Logging.Level != "" && os.Getenv("LOG_LEVEL") == "" {
+		if err := logging.Configure(config.Logging.Level); err != nil {
+			return fmt.Errorf("could not configure logging: [%v]", err)
+		}
+	}
+
+	if config.IsMultiOperator() {
+		return startOperators(ctx, c, config)
+	}
+
+	connect := connectWithKeyFile
+	if config.UsesExternalSigner() {
+		connect = connectWithSigner
+	}
+
+	chainProvider, operatorAddress, networkPrivateKey, err := connect(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	// The network must outlive the root context so that the in-flight work
+	// can still communicate with other group members during the shutdown.
+	// It is closed explicitly once that work is done.
+	netCtx, cancelNetCtx := context.WithCancel(context.Background())
+	defer cancelNetCtx()
+
+	operatorNode, err := startOperator(
+		ctx,
+		netCtx,
+		c,
+		config,
+		operatorSettings{
+			port:               config.LibP2P.Port,
+			announcedAddresses: config.LibP2P.AnnouncedAddresses,
+			dataDir:            config.Storage.DataDir,
+			storagePassword:    config.StoragePassword(),
+		},
+		chainProvider,
+		operatorAddress,
+		networkPrivateKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	nodeHeader(
+		operatorNode.netProvider.ConnectionManager().AddrStrings(),
+		config.LibP2P.Port,
+	)
+
+	reloader := newConfigReloader(
+		c.GlobalString("config"),
+		c.Int(portFlag),
+		config,
+		[]net.Provider{operatorNode.netProvider},
+		chainProvider,
+	)
+
+	registry, isConfigured := metrics.Initialize(config.Metrics.Port)
+	if isConfigured {
+		logger.Infof("enabled metrics on port [%v]", config.Metrics.Port)
+		observeOperatorMetrics(ctx, registry, config, operatorNode, reloader)
+	} else {
+		logger.Infof("metrics are not configured")
+	}
+
+	initializeDiagnostics(
+		config,
+		operatorNode.netProvider,
+		operatorNode.ledger,
+	)
+
+	// The admin API stays available until the in-flight work is done so
+	// that the operator can observe the shutdown progress.
+	err = initializeAdmin(netCtx, config, operatorNode)
+	if err != nil {
+		return fmt.Errorf("error initializing admin API: [%v]", err)
+	}
+
+	go reloader.reloadOnSignal(ctx)
+
+	<-ctx.Done()
+
+	shutdownTimeout := c.Duration(shutdownTimeoutFlag)
+	logger.Infof(
+		"shutting down; waiting up to [%v] for in-flight work to complete",
+		shutdownTimeout,
+	)
+
+	operatorNode.waitForInFlightWork(shutdownTimeout)
+	cancelNetCtx()
+	operatorNode.close()
+
+	logger.Infof("shutdown completed")
+
+	return nil
+}
+
+// connectWithKeyFile decrypts the operator key file and connects to the
+// chain as the operator. It returns the chain handle, the operator address
+// and the network identity key of the operator.
+func connectWithKeyFile(
+	ctx context.Context,
+	config *config.Config,
+) (chain.Handle, string, libp2pcrypto.PrivKey, error) {
 	ethereumKey, err := ethutil.DecryptKeyFile(
 		config.Ethereum.Account.KeyFile,
 		config.Ethereum.Account.KeyFilePassword,
 	)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, "", nil, fmt.Errorf(
 			"failed to read key file [%s]: [%v]",
 			config.Ethereum.Account.KeyFile,
 			err,
@@ -87,32 +218,81 @@ func Start(c *cli.Context) error {
 
 	chainProvider, err := ethereum.Connect(ctx, config.Ethereum)
 	if err != nil {
-		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		return nil, "", nil, fmt.Errorf(
+			"error connecting to Ethereum node: [%v]",
+			err,
+		)
 	}
 
+	return chainProvider, ethereumKey.Address.Hex(), networkKey(ethereumKey), nil
+}
+
+// networkKey derives the network identity key of the operator from the
+// operator key.
+func networkKey(ethereumKey *keystore.Key) *key.NetworkPrivate {
+	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+		operator.ChainKeyToOperatorKey(ethereumKey),
+	)
+	return networkPrivateKey
+}
+
+// operatorSettings holds the values of the config which differ between
+// operators run by one client.
+type operatorSettings struct {
+	port               int
+	announcedAddresses []string
+	dataDir            string
+	storagePassword    string
+}
+
+// operatorNode is the beacon node of a single operator along with the
+// resources it uses.
+type operatorNode struct {
+	address      string
+	node         *relay.Node
+	netProvider  net.Provider
+	banList      *firewall.BanList
+	stakeMonitor chain.StakeMonitor
+	persistence  *closablePersistence
+
+	ledger            *ledger.Ledger
+	ledgerPersistence *closablePersistence
+}
+
+// startOperator checks the stake of the operator, connects the operator to
+// the network with its own identity and initializes its beacon node with
+// its own storage.
+func startOperator(
+	ctx context.Context,
+	netCtx context.Context,
+	c *cli.Context,
+	config *config.Config,
+	settings operatorSettings,
+	chainProvider chain.Handle,
+	address string,
+	networkPrivateKey libp2pcrypto.PrivKey,
+) (*operatorNode, error) {
 	blockCounter, err := chainProvider.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	stakeMonitor, err := chainProvider.StakeMonitor()
 	if err != nil {
-		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
+		return nil, fmt.Errorf("error obtaining stake monitor handle [%v]", err)
 	}
 	if c.Int(waitForStakeFlag) != 0 {
-		err = waitForStake(stakeMonitor, ethereumKey.Address.Hex(), c.Int(waitForStakeFlag))
+		err = waitForStake(stakeMonitor, address, c.Int(waitForStakeFlag))
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
-		ethereumKey.Address.Hex(),
-	)
+	hasMinimumStake, err := stakeMonitor.HasMinimumStake(address)
 	if err != nil {
-		return fmt.Errorf("could not check the stake [%v]", err)
+		return nil, fmt.Errorf("could not check the stake [%v]", err)
 	}
 	if !hasMinimumStake {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"no minimum KEEP stake or operator is not authorized to use it; " +
 				"please make sure the operator address in the configuration " +
 				"is correct and it has KEEP tokens delegated and the operator " +
@@ -120,54 +300,109 @@ func Start(c *cli.Context) error {
 		)
 	}
 
-	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
-		operator.ChainKeyToOperatorKey(ethereumKey),
-	)
+	banList := firewall.NewBanList(firewall.MinimumStakePolicy(stakeMonitor))
+
+	netConfig := config.LibP2P
+	netConfig.Port = settings.port
+	netConfig.AnnouncedAddresses = settings.announcedAddresses
+
 	netProvider, err := libp2p.Connect(
-		ctx,
-		config.LibP2P,
+		netCtx,
+		netConfig,
 		networkPrivateKey,
 		libp2p.ProtocolBeacon,
-		firewall.MinimumStakePolicy(stakeMonitor),
-		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		banList,
+		retransmission.NewTicker(blockCounter.WatchBlocks(netCtx)),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	nodeHeader(netProvider.ConnectionManager().AddrStrings(), config.LibP2P.Port)
+	// Answer connectivity probes sent with the "net ping" command.
+	ping.Respond(netCtx, netProvider)
 
-	handle, err := persistence.NewDiskHandle(config.Storage.DataDir)
+	// The ledger is kept in its own directory so that it is never read by
+	// the group registry. It contains only public information and is not
+	// encrypted, so that it can be inspected without the account password.
+	err = persistence.EnsureDirectoryExists(
+		settings.dataDir,
+		ledger.DirectoryName,
+	)
 	if err != nil {
-		return fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+		netProvider.Close()
+		return nil, fmt.Errorf("failed while creating a ledger directory: [%v]", err)
 	}
-	persistence := persistence.NewEncryptedPersistence(
-		handle,
-		config.Ethereum.Account.KeyFilePassword,
+	ledgerHandle, err := persistence.NewDiskHandle(
+		filepath.Join(settings.dataDir, ledger.DirectoryName),
+	)
+	if err != nil {
+		netProvider.Close()
+		return nil, fmt.Errorf("failed while creating a ledger disk handler: [%v]", err)
+	}
+	ledgerPersistence := newClosablePersistence(ledgerHandle)
+	participationLedger := ledger.NewLedger(ledgerPersistence)
+
+	handle, err := persistence.NewDiskHandle(settings.dataDir)
+	if err != nil {
+		netProvider.Close()
+		return nil, fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+	}
+	persistence := newClosablePersistence(
+		persistence.NewEncryptedPersistence(
+			handle,
+			settings.storagePassword,
+		),
 	)
 
-	err = beacon.Initialize(
+	node, err := beacon.Initialize(
 		ctx,
-		ethereumKey.Address.Hex(),
+		address,
 		chainProvider,
 		netProvider,
 		persistence,
+		participationLedger,
 	)
 	if err != nil {
-		return fmt.Errorf("error initializing beacon: [%v]", err)
+		netProvider.Close()
+		return nil, fmt.Errorf("error initializing beacon: [%v]", err)
 	}
 
-	initializeMetrics(ctx, config, netProvider, stakeMonitor, ethereumKey.Address.Hex())
-	initializeDiagnostics(ctx, config, netProvider)
+	return &operatorNode{
+		address:           address,
+		node:              node,
+		netProvider:       netProvider,
+		banList:           banList,
+		stakeMonitor:      stakeMonitor,
+		persistence:       persistence,
+		ledger:            participationLedger,
+		ledgerPersistence: ledgerPersistence,
+	}, nil
+}
 
-	select {
-	case <-ctx.Done():
-		if err != nil {
-			return err
-		}
+// waitForInFlightWork waits up to the given timeout for the DKG and relay
+// entry signing in progress to complete.
+func (on *operatorNode) waitForInFlightWork(timeout time.Duration) {
+	if err := on.node.WaitForInFlightWork(timeout); err != nil {
+		logger.Warningf(
+			"abandoning in-flight work of operator [%v]: [%v]",
+			on.address,
+			err,
+		)
+	}
+}
 
-		return fmt.Errorf("uh-oh, we went boom boom for no reason")
+// close closes the network provider and the storage of the operator.
+func (on *operatorNode) close() {
+	if err := on.netProvider.Close(); err != nil {
+		logger.Warningf(
+			"could not close network provider of operator [%v]: [%v]",
+			on.address,
+			err,
+		)
 	}
+
+	on.persistence.Close()
+	on.ledgerPersistence.Close()
 }
 
 func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int) error {
@@ -187,54 +422,104 @@ func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int)
 	return fmt.Errorf("timed out waiting for %s to have required minimum stake", address)
 }
 
-func initializeMetrics(
+// observeOperatorMetrics starts observations of the metrics of the
+// operator. The observations are kept by the reloader so that their ticks
+// can be changed when the configuration is reloaded.
+func observeOperatorMetrics(
 	ctx context.Context,
+	registry metrics.Registry,
+	config *config.Config,
+	operatorNode *operatorNode,
+	reloader *configReloader,
+) {
+	networkObservations := []*metrics.Observation{
+		metrics.ObserveConnectedPeersCount(
+			ctx,
+			registry,
+			operatorNode.netProvider,
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		),
+		metrics.ObserveConnectedBootstrapCount(
+			ctx,
+			registry,
+			operatorNode.netProvider,
+			reloader.bootstrapPeers,
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		),
+		metrics.ObserveObservedOperatorsCount(
+			ctx,
+			registry,
+			operatorNode.node.Reliability(),
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		),
+		metrics.ObserveUnreliableOperatorsCount(
+			ctx,
+			registry,
+			operatorNode.node.Reliability(),
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		),
+		metrics.ObserveLowestShareDeliveryRate(
+			ctx,
+			registry,
+			operatorNode.node.Reliability(),
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		),
+		metrics.ObservePeerOperatorsReliability(
+			ctx,
+			registry,
+			operatorNode.node.Reliability(),
+			time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		),
+	}
+	ethereumObservations := []*metrics.Observation{
+		metrics.ObserveEthConnectivity(
+			ctx,
+			registry,
+			operatorNode.stakeMonitor,
+			operatorNode.address,
+			time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
+		),
+	}
+
+	reloader.addObservations(networkObservations, ethereumObservations)
+}
+
+// initializeDiagnostics enables diagnostics if they are configured. The
+// participation ledger source is registered only if the ledger is not nil.
+func initializeDiagnostics(
 	config *config.Config,
 	netProvider net.Provider,
-	stakeMonitor chain.StakeMonitor,
-	ethereumAddress string,
+	participationLedger *ledger.Ledger,
 ) {
-	registry, isConfigured := metrics.Initialize(
-		config.Metrics.Port,
+	registry, isConfigured := diagnostics.Initialize(
+		config.Diagnostics.Port,
 	)
 	if !isConfigured {
-		logger.Infof("metrics are not configured")
+		logger.Infof("diagnostics are not configured")
 		return
 	}
 
 	logger.Infof(
-		"enabled metrics on port [%v]",
-		config.Metrics.Port,
-	)
-
-	metrics.ObserveConnectedPeersCount(
-		ctx,
-		registry,
-		netProvider,
-		time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
+		"enabled diagnostics on port [%v]",
+		config.Diagnostics.Port,
 	)
 
-	metrics.ObserveConnectedBootstrapCount(
-		ctx,
-		registry,
-		netProvider,
-		config.LibP2P.Peers,
-		time.Duration(config.Metrics.NetworkMetricsTick)*time.Second,
-	)
+	diagnostics.RegisterConnectedPeersSource(registry, netProvider)
+	diagnostics.RegisterClientInfoSource(registry, netProvider)
 
-	metrics.ObserveEthConnectivity(
-		ctx,
-		registry,
-		stakeMonitor,
-		ethereumAddress,
-		time.Duration(config.Metrics.EthereumMetricsTick)*time.Second,
-	)
+	if participationLedger != nil {
+		diagnostics.RegisterParticipationLedgerSource(
+			registry,
+			participationLedger,
+		)
+	}
 }
 
-func initializeDiagnostics(
-	ctx context.Context,
+// initializeOperatorsDiagnostics enables diagnostics of all the operators
+// run by the client if they are configured.
+func initializeOperatorsDiagnostics(
 	config *config.Config,
-	netProvider net.Provider,
+	operatorNodes []*operatorNode,
 ) {
 	registry, isConfigured := diagnostics.Initialize(
 		config.Diagnostics.Port,
@@ -249,6 +534,83 @@ func initializeDiagnostics(
 		config.Diagnostics.Port,
 	)
 
-	diagnostics.RegisterConnectedPeersSource(registry, netProvider)
-	diagnostics.RegisterClientInfoSource(registry, netProvider)
+	operators := make([]*diagnostics.Operator, len(operatorNodes))
+	for i, operatorNode := range operatorNodes {
+		operators[i] = &diagnostics.Operator{
+			Address:             operatorNode.address,
+			NetProvider:         operatorNode.netProvider,
+			ParticipationLedger: operatorNode.ledger,
+		}
+	}
+
+	diagnostics.RegisterOperatorsSource(registry, operators)
+}
+
+// initializeAdmin enables the admin API if it is configured. When the client
+// runs multiple operators, handlers of each operator are registered under
+// the path of the operator address; handlers of the whole client, such as
+// the log level one, are shared by all the operators.
+func initializeAdmin(
+	ctx context.Context,
+	config *config.Config,
+	operatorNodes ...*operatorNode,
+) error {
+	server, isConfigured, err := admin.Initialize(
+		ctx,
+		config.Admin.Port,
+		config.Admin.Socket,
+		adminCookiePath(config),
+	)
+	if err != nil {
+		return err
+	}
+	if !isConfigured {
+		logger.Infof("admin API is not configured")
+		return nil
+	}
+
+	if config.Admin.Socket != "" {
+		logger.Infof("enabled admin API on socket [%v]", config.Admin.Socket)
+	} else {
+		logger.Infof("enabled admin API on port [%v]", config.Admin.Port)
+	}
+
+	admin.RegisterLogLevelHandler(server)
+	admin.RegisterGoroutinesHandler(server)
+
+	if !config.IsMultiOperator() {
+		registerOperatorAdminHandlers(server, operatorNodes[0])
+		return nil
+	}
+
+	addresses := make([]string, len(operatorNodes))
+	for i, operatorNode := range operatorNodes {
+		addresses[i] = operatorNode.address
+		registerOperatorAdminHandlers(
+			server.ForOperator(operatorNode.address),
+			operatorNode,
+		)
+	}
+	admin.RegisterOperatorsHandler(server, addresses)
+
+	return nil
+}
+
+func registerOperatorAdminHandlers(
+	server *admin.Server,
+	operatorNode *operatorNode,
+) {
+	admin.RegisterGroupsHandlers(server, operatorNode.node.GroupRegistry())
+	admin.RegisterSessionsHandler(server, operatorNode.node)
+	admin.RegisterReliabilityHandler(server, operatorNode.node.Reliability())
+	admin.RegisterPeersHandlers(
+		server,
+		operatorNode.netProvider.ConnectionManager(),
+		operatorNode.banList,
+	)
+	admin.RegisterPingPeerHandler(
+		server,
+		operatorNode.netProvider,
+		firewall.MinimumStakePolicy(operatorNode.stakeMonitor),
+	)
 }
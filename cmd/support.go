@@ -0,0 +1,504 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/admin"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/support"
+	"github.com/urfave/cli"
+)
+
+// SupportBundleCommand contains the definition of the support-bundle
+// command-line subcommand.
+var SupportBundleCommand cli.Command
+
+const (
+	logFileFlag  = "log-file"
+	logLinesFlag = "log-lines"
+)
+
+// supportChainTimeout is the maximum time the support bundle waits for the
+// chain information.
+const supportChainTimeout = 1 * time.Minute
+
+const supportBundleDescription = `The support-bundle command collects the
+   information needed to troubleshoot a failed DKG or a missed relay entry
+   into a single archive an operator can share:
+
+   - the effective config with secrets redacted,
+   - the client version and revision,
+   - connected peers with their chain addresses,
+   - the summary of the local group registry,
+   - the current chain config, current block and Ethereum node sync state,
+   - the last lines of the client log file given with the --log-file flag,
+   - the goroutine dump of the running client.
+
+   Peers, groups and the goroutine dump are read from the running client
+   through its admin API; peers are read from the diagnostics endpoint if the
+   admin API is not configured. Without the admin API, the registry summary
+   is built from names of files in the data directory. Information which
+   could not be collected is listed in the problems.txt file of the archive.
+
+   When the client runs multiple operators, peers and groups of each of the
+   operators are collected into the operators/<address> directory of the
+   archive.
+
+   The archive never contains key material nor group membership shares: no
+   files are copied from the data directory.`
+
+func init() {
+	SupportBundleCommand = cli.Command{
+		Name:        "support-bundle",
+		Usage:       "Collects troubleshooting information into an archive.",
+		Description: supportBundleDescription,
+		Action:      createSupportBundle,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  outputFlag,
+				Usage: "path to the archive; keep-support-<time>.tar.gz by default",
+			},
+			&cli.StringFlag{
+				Name:  logFileFlag,
+				Usage: "path to the file the client output is written to",
+			},
+			&cli.IntFlag{
+				Name:  logLinesFlag,
+				Value: 1000,
+				Usage: "number of the last lines of the log file to include",
+			},
+		},
+	}
+}
+
+// supportChainState describes the state of the chain as seen by the
+// configured Ethereum node.
+type supportChainState struct {
+	CurrentBlock uint64      `json:"currentBlock"`
+	Syncing      bool        `json:"syncing"`
+	SyncProgress interface{} `json:"syncProgress,omitempty"`
+}
+
+// supportRegistrySummary summarizes the local group registry. Groups are
+// read from the running client if possible, otherwise groups stored in the
+// data directory are listed.
+type supportRegistrySummary struct {
+	Source         string               `json:"source"`
+	Groups         []admin.Group        `json:"groups,omitempty"`
+	CurrentGroups  []supportStoredGroup `json:"currentGroups,omitempty"`
+	ArchivedGroups []supportStoredGroup `json:"archivedGroups,omitempty"`
+}
+
+// supportStoredGroup describes a group stored in the data directory,
+// derived only from the names of the directory and of the membership files.
+type supportStoredGroup struct {
+	PublicKey     string `json:"publicKey"`
+	MemberIndexes []int  `json:"memberIndexes"`
+}
+
+// supportOperator describes one of the operators run by the client whose
+// peers and groups are collected.
+type supportOperator struct {
+	// address is the address of the operator if the client runs multiple
+	// operators and is empty otherwise.
+	address     string
+	dataDir     string
+	adminClient *admin.Client
+}
+
+// file returns the path of the file with the given name in the archive.
+func (so *supportOperator) file(name string) string {
+	if so.address == "" {
+		return name
+	}
+
+	return fmt.Sprintf("operators/%v/%v", so.address, name)
+}
+
+// problem returns the description of the information which could not be
+// collected.
+func (so *supportOperator) problem(description string) string {
+	if so.address == "" {
+		return description
+	}
+
+	return fmt.Sprintf("%v of operator [%v]", description, so.address)
+}
+
+func createSupportBundle(c *cli.Context) error {
+	cfg, err := config.Load(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	output := c.String(outputFlag)
+	if output == "" {
+		output = fmt.Sprintf(
+			"keep-support-%v.tar.gz",
+			time.Now().UTC().Format("20060102-150405"),
+		)
+	}
+
+	bundle, err := support.Create(output)
+	if err != nil {
+		return err
+	}
+
+	addSupportConfig(bundle, cfg)
+
+	if err := bundle.AddFile("version.txt", []byte(c.App.Version+"\n")); err != nil {
+		bundle.AddProblem("version", err)
+	}
+
+	adminClient, adminErr := admin.NewClient(
+		cfg.Admin.Port,
+		cfg.Admin.Socket,
+		adminCookiePath(cfg),
+	)
+	if adminErr != nil {
+		adminClient = nil
+	}
+
+	for _, operator := range supportOperators(bundle, cfg, adminClient) {
+		addSupportPeers(bundle, cfg, operator)
+		addSupportRegistry(bundle, operator)
+	}
+	addSupportChain(bundle, cfg)
+	addSupportLog(bundle, c.String(logFileFlag), c.Int(logLinesFlag))
+	addSupportGoroutines(bundle, adminClient, adminErr)
+
+	problems := bundle.Problems()
+	if err := bundle.Close(); err != nil {
+		return err
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "not collected: %v\n", problem)
+	}
+	fmt.Printf("Support bundle written to [%v].\n", output)
+
+	return nil
+}
+
+func addSupportConfig(bundle *support.Bundle, cfg *config.Config) {
+	buffer := &bytes.Buffer{}
+	if err := config.Encode(buffer, cfg, "toml"); err != nil {
+		bundle.AddProblem("config", err)
+		return
+	}
+
+	if err := bundle.AddFile("config.toml", buffer.Bytes()); err != nil {
+		bundle.AddProblem("config", err)
+	}
+}
+
+// supportOperators lists the operators run by the client. When the client
+// runs multiple operators, their addresses are read from the key files
+// without decrypting them.
+func supportOperators(
+	bundle *support.Bundle,
+	cfg *config.Config,
+	adminClient *admin.Client,
+) []*supportOperator {
+	if !cfg.IsMultiOperator() {
+		return []*supportOperator{{
+			dataDir:     cfg.Storage.DataDir,
+			adminClient: adminClient,
+		}}
+	}
+
+	var operators []*supportOperator
+	for i, operator := range cfg.Operators {
+		address, err := keyFileAddress(operator.KeyFile)
+		if err != nil {
+			bundle.AddProblem(fmt.Sprintf("address of Operators[%v]", i), err)
+			continue
+		}
+
+		supportOperator := &supportOperator{
+			address: address,
+			dataDir: operator.DataDir,
+		}
+		if adminClient != nil {
+			supportOperator.adminClient = adminClient.ForOperator(address)
+		}
+
+		operators = append(operators, supportOperator)
+	}
+
+	return operators
+}
+
+// keyFileAddress reads the address of the account from the given key file.
+// The address is stored in the key file in plain text.
+func keyFileAddress(keyFile string) (string, error) {
+	content, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return "", fmt.Errorf("could not read key file: [%v]", err)
+	}
+
+	key := &struct {
+		Address string `json:"address"`
+	}{}
+	if err := json.Unmarshal(content, key); err != nil {
+		return "", fmt.Errorf("could not decode key file: [%v]", err)
+	}
+
+	if !common.IsHexAddress(key.Address) {
+		return "", fmt.Errorf("key file has no valid address")
+	}
+
+	return common.HexToAddress(key.Address).Hex(), nil
+}
+
+func addSupportPeers(
+	bundle *support.Bundle,
+	cfg *config.Config,
+	operator *supportOperator,
+) {
+	if operator.adminClient != nil {
+		peers := &admin.Peers{}
+		if err := operator.adminClient.Get(admin.PeersPath, peers); err != nil {
+			bundle.AddProblem(operator.problem("connected peers"), err)
+			return
+		}
+
+		if err := bundle.AddJSON(operator.file("peers.json"), peers); err != nil {
+			bundle.AddProblem(operator.problem("connected peers"), err)
+		}
+		return
+	}
+
+	if cfg.Diagnostics.Port == 0 {
+		bundle.AddProblem(
+			operator.problem("connected peers"),
+			fmt.Errorf("neither admin API nor diagnostics are configured"),
+		)
+		return
+	}
+
+	peers, err := readDiagnosticsPeers(cfg.Diagnostics.Port, operator.address)
+	if err != nil {
+		bundle.AddProblem(operator.problem("connected peers"), err)
+		return
+	}
+
+	if err := bundle.AddFile(operator.file("peers.json"), peers); err != nil {
+		bundle.AddProblem(operator.problem("connected peers"), err)
+	}
+}
+
+// readDiagnosticsPeers reads connected peers from the diagnostics endpoint
+// of the running client. Peers of the operator with the given address are
+// read from the operators source; if the address is empty, the
+// connected_peers source is read.
+func readDiagnosticsPeers(port int, address string) ([]byte, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	response, err := httpClient.Get(
+		fmt.Sprintf("http://127.0.0.1:%d/diagnostics", port),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not read diagnostics: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	var sources map[string]json.RawMessage
+	if err := json.NewDecoder(response.Body).Decode(&sources); err != nil {
+		return nil, fmt.Errorf("could not decode diagnostics: [%v]", err)
+	}
+
+	if address != "" {
+		var operators map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(sources["operators"], &operators); err != nil {
+			return nil, fmt.Errorf("could not decode operators: [%v]", err)
+		}
+
+		sources = operators[address]
+	}
+
+	peers, ok := sources["connected_peers"]
+	if !ok {
+		return nil, fmt.Errorf("no connected peers in diagnostics")
+	}
+
+	return peers, nil
+}
+
+func addSupportRegistry(bundle *support.Bundle, operator *supportOperator) {
+	summary := &supportRegistrySummary{}
+
+	groups := make([]admin.Group, 0)
+	if operator.adminClient != nil &&
+		operator.adminClient.Get(admin.GroupsPath, &groups) == nil {
+		summary.Source = "admin API"
+		summary.Groups = groups
+	} else {
+		summary.Source = "data directory"
+
+		var err error
+		summary.CurrentGroups, err = listStoredGroups(
+			filepath.Join(operator.dataDir, currentDataDir),
+		)
+		if err != nil {
+			bundle.AddProblem(operator.problem("current groups"), err)
+		}
+
+		summary.ArchivedGroups, err = listStoredGroups(
+			filepath.Join(operator.dataDir, archivedDataDir),
+		)
+		if err != nil {
+			bundle.AddProblem(operator.problem("archived groups"), err)
+		}
+	}
+
+	if err := bundle.AddJSON(operator.file("registry.json"), summary); err != nil {
+		bundle.AddProblem(operator.problem("registry summary"), err)
+	}
+}
+
+// listStoredGroups lists groups stored in the given directory of the disk
+// persistence. Only names of the directories and files are read; the
+// membership files are never opened.
+func listStoredGroups(path string) ([]supportStoredGroup, error) {
+	directories, err := ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var groups []supportStoredGroup
+	for _, directory := range directories {
+		if !directory.IsDir() {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(filepath.Join(path, directory.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		group := supportStoredGroup{
+			PublicKey:     directory.Name(),
+			MemberIndexes: []int{},
+		}
+		for _, file := range files {
+			index, err := strconv.Atoi(
+				strings.TrimPrefix(file.Name(), "membership_"),
+			)
+			if err == nil {
+				group.MemberIndexes = append(group.MemberIndexes, index)
+			}
+		}
+		sort.Ints(group.MemberIndexes)
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func addSupportChain(bundle *support.Bundle, cfg *config.Config) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		supportChainTimeout,
+	)
+	defer cancelCtx()
+
+	client, err := ethclient.DialContext(ctx, cfg.Ethereum.URL)
+	if err != nil {
+		bundle.AddProblem(
+			"chain state",
+			fmt.Errorf(
+				"could not connect to Ethereum node: [%v]",
+				err,
+			),
+		)
+		return
+	}
+	defer client.Close()
+
+	chainState := &supportChainState{}
+
+	chainState.CurrentBlock, err = client.BlockNumber(ctx)
+	if err != nil {
+		bundle.AddProblem("current block", err)
+	}
+
+	syncProgress, err := client.SyncProgress(ctx)
+	if err != nil {
+		bundle.AddProblem("sync state", err)
+	} else if syncProgress != nil {
+		chainState.Syncing = true
+		chainState.SyncProgress = syncProgress
+	}
+
+	if err := bundle.AddJSON("chain_state.json", chainState); err != nil {
+		bundle.AddProblem("chain state", err)
+	}
+
+	chainHandle, err := ethereum.ConnectReadOnly(ctx, cfg.Ethereum)
+	if err != nil {
+		bundle.AddProblem("chain config", err)
+		return
+	}
+
+	chainConfig := chainHandle.ThresholdRelay().GetConfig()
+	if err := bundle.AddJSON("chain_config.json", chainConfig); err != nil {
+		bundle.AddProblem("chain config", err)
+	}
+}
+
+func addSupportLog(bundle *support.Bundle, logFile string, lines int) {
+	if logFile == "" {
+		bundle.AddProblem("log", fmt.Errorf("--%v is not set", logFileFlag))
+		return
+	}
+
+	content, err := support.TailFile(logFile, lines)
+	if err != nil {
+		bundle.AddProblem("log", err)
+		return
+	}
+
+	if err := bundle.AddFile("client.log", content); err != nil {
+		bundle.AddProblem("log", err)
+	}
+}
+
+func addSupportGoroutines(
+	bundle *support.Bundle,
+	adminClient *admin.Client,
+	adminErr error,
+) {
+	if adminClient == nil {
+		bundle.AddProblem("goroutine dump", adminErr)
+		return
+	}
+
+	goroutines := &admin.Goroutines{}
+	if err := adminClient.Get(admin.GoroutinesPath, goroutines); err != nil {
+		bundle.AddProblem("goroutine dump", err)
+		return
+	}
+
+	if err := bundle.AddFile("goroutines.txt", []byte(goroutines.Dump)); err != nil {
+		bundle.AddProblem("goroutine dump", err)
+	}
+}
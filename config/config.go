@@ -6,7 +6,6 @@ import (
 	"strings"
 	"syscall"
 
-	"github.com/BurntSushi/toml"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"golang.org/x/crypto/ssh/terminal"
@@ -24,6 +23,18 @@ type Config struct {
 	Storage     Storage
 	Metrics     Metrics
 	Diagnostics Diagnostics
+	Admin       Admin
+	Logging     Logging
+	Secrets     Secrets
+	Signer      Signer
+	Operators   []Operator
+
+	// storagePassword is the password used to encrypt data stored on disk,
+	// resolved when the config is read.
+	storagePassword string
+	// operatorPasswords holds passwords of the operators from the Operators
+	// section, resolved when the config is read.
+	operatorPasswords []operatorPasswords
 }
 
 // Storage stores meta-info about keeping data on disk
@@ -43,6 +54,21 @@ type Diagnostics struct {
 	Port int
 }
 
+// Admin stores the configuration of the admin control API. The API is
+// exposed on the given localhost port or on the given unix socket.
+type Admin struct {
+	Port   int
+	Socket string
+}
+
+// Logging stores the logging configuration.
+type Logging struct {
+	// Level is a space-delimited set of log level directives, in the same
+	// format as the LOG_LEVEL environment variable which takes precedence
+	// over it.
+	Level string
+}
+
 var (
 	// KeepOpts contains global application settings
 	KeepOpts Config
@@ -50,45 +76,166 @@ var (
 
 // ReadConfig reads in the configuration file at `filePath` and returns the
 // valid config stored there, or an error if something fails while reading the
-// file or the config is invalid in a known way.
+// file or the config is invalid in a known way. Values from the file are
+// overridden with values of environment variables, as described in Load.
 func ReadConfig(filePath string) (*Config, error) {
+	config, err := Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := readAccountPassword(config); err != nil {
+		return nil, err
+	}
+
+	if err := readStoragePassword(config); err != nil {
+		return nil, err
+	}
+
+	if err := readOperatorPasswords(config); err != nil {
+		return nil, err
+	}
+
+	if err := validate(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ReadConfigWithoutPassword reads in the configuration file at `filePath`
+// the same way ReadConfig does but it neither reads nor requires the
+// account password. It is meant for commands which do not need access to
+// the operator key.
+func ReadConfigWithoutPassword(filePath string) (*Config, error) {
+	config, err := Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Load reads in the configuration file at `filePath` in TOML, JSON or YAML
+// format, depending on the file extension, and overrides its values with
+// values of environment variables named `KEEP_<SECTION>_<FIELD>`, such as
+// `KEEP_LIBP2P_PORT` or `KEEP_ETHEREUM_ACCOUNT_KEYFILE`. The config is
+// neither validated nor are any secrets read.
+func Load(filePath string) (*Config, error) {
 	config := &Config{}
-	if _, err := toml.DecodeFile(filePath, config); err != nil {
-		return nil, fmt.Errorf("unable to decode .toml file [%s] error [%s]", filePath, err)
+	if _, err := decodeFile(filePath, config); err != nil {
+		return nil, err
+	}
+
+	if err := applyEnvironment(config, os.Environ()); err != nil {
+		return nil, err
 	}
 
+	return config, nil
+}
+
+func readAccountPassword(config *Config) error {
 	envPassword := os.Getenv(passwordEnvVariable)
-	if envPassword == "prompt" {
-		var (
-			password string
-			err      error
-		)
-		if password, err = readPassword("Enter Account Password: "); err != nil {
-			return nil, err
+	switch {
+	case envPassword == "prompt":
+		password, err := readPassword("Enter Account Password: ")
+		if err != nil {
+			return err
 		}
 		config.Ethereum.Account.KeyFilePassword = password
-	} else {
+	case envPassword != "":
 		config.Ethereum.Account.KeyFilePassword = envPassword
+	case config.Secrets.KeyFilePassword.IsSet():
+		password, err := readSecret(
+			"Secrets.KeyFilePassword",
+			&config.Secrets.KeyFilePassword,
+		)
+		if err != nil {
+			return err
+		}
+		config.Ethereum.Account.KeyFilePassword = password
 	}
 
-	if config.Ethereum.Account.KeyFilePassword == "" {
-		return nil, fmt.Errorf(
-			"password is required; set in the config file, set environment "+
-				"variable %v to the password, or set the same environment "+
-				"variable to 'prompt' to be prompted for the password at startup",
+	if config.Ethereum.Account.KeyFilePassword == "" &&
+		requiresAccountPassword(config) {
+		return fmt.Errorf(
+			"password is required; set in the config file, configure its "+
+				"source in the Secrets.KeyFilePassword section of the config "+
+				"file, set environment variable %v to the password, or set "+
+				"the same environment variable to 'prompt' to be prompted "+
+				"for the password at startup",
 			passwordEnvVariable,
 		)
 	}
 
+	return nil
+}
+
+func readStoragePassword(config *Config) error {
+	if !config.Secrets.StoragePassword.IsSet() {
+		config.storagePassword = config.Ethereum.Account.KeyFilePassword
+
+		// With the external signer there may be no key file password the
+		// storage password could default to.
+		if config.storagePassword == "" && config.UsesExternalSigner() {
+			return fmt.Errorf(
+				"storage password is required with the external signer; "+
+					"configure its source in the Secrets.StoragePassword "+
+					"section of the config file or set environment "+
+					"variable %v",
+				passwordEnvVariable,
+			)
+		}
+
+		return nil
+	}
+
+	password, err := readSecret(
+		"Secrets.StoragePassword",
+		&config.Secrets.StoragePassword,
+	)
+	if err != nil {
+		return err
+	}
+
+	config.storagePassword = password
+	return nil
+}
+
+// StoragePassword returns the password used to encrypt data stored on disk.
+// It is the key file password unless a different source of the storage
+// password is configured. It is available only for configs read with
+// ReadConfig.
+func (c *Config) StoragePassword() string {
+	return c.storagePassword
+}
+
+func validate(config *Config) error {
+	if err := validateSignerRequired(config); err != nil {
+		return err
+	}
+
+	if config.IsMultiOperator() {
+		return validateOperatorsRequired(config)
+	}
+
 	if config.LibP2P.Port == 0 {
-		return nil, fmt.Errorf("missing value for port; see node section in config file or use --port flag")
+		return fmt.Errorf("missing value for port; see node section in config file or use --port flag")
 	}
 
 	if config.Storage.DataDir == "" {
-		return nil, fmt.Errorf("missing value for storage directory data")
+		return fmt.Errorf("missing value for storage directory data")
 	}
 
-	return config, nil
+	if config.Admin.Port != 0 && config.Admin.Socket != "" {
+		return fmt.Errorf("admin port and admin socket can not be set at the same time")
+	}
+
+	return nil
 }
 
 // ReadEthereumConfig reads in the configuration file at `filePath` and returns
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"io/ioutil"
 	"math/big"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -66,3 +68,186 @@ func TestReadConfig(t *testing.T) {
 	}
 
 }
+
+func TestReadConfigWithoutPassword(t *testing.T) {
+	err := os.Unsetenv("KEEP_ETHEREUM_PASSWORD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filepath := "../test/config.toml"
+	cfg, err := ReadConfigWithoutPassword(filepath)
+	if err != nil {
+		t.Fatalf(
+			"failed to read test config: [%v]",
+			err,
+		)
+	}
+
+	if cfg.Ethereum.Account.KeyFilePassword != "" {
+		t.Errorf("password should not be set")
+	}
+
+	if cfg.Storage.DataDir != "/my/secure/location" {
+		t.Errorf(
+			"\nexpected: %s\nactual:   %s",
+			"/my/secure/location",
+			cfg.Storage.DataDir,
+		)
+	}
+}
+
+func TestReadConfigSecrets(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config-secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	passwordFile := filepath.Join(directory, "password")
+	err = ioutil.WriteFile(passwordFile, []byte("key-file-password\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configContent := `
+[LibP2P]
+	Port = 3919
+
+[Storage]
+	DataDir = "` + directory + `"
+
+[Secrets.KeyFilePassword]
+	File = "` + passwordFile + `"
+`
+
+	var tests = map[string]struct {
+		envPassword             string
+		storagePasswordSecret   string
+		expectedKeyFilePassword string
+		expectedStoragePassword string
+	}{
+		"key file password from file": {
+			expectedKeyFilePassword: "key-file-password",
+			expectedStoragePassword: "key-file-password",
+		},
+		"storage password from command": {
+			storagePasswordSecret: `
+[Secrets.StoragePassword]
+	Command = ["echo", "storage-password"]
+`,
+			expectedKeyFilePassword: "key-file-password",
+			expectedStoragePassword: "storage-password",
+		},
+		"environment variable takes precedence": {
+			envPassword:             "env-password",
+			expectedKeyFilePassword: "env-password",
+			expectedStoragePassword: "env-password",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if err := os.Setenv(passwordEnvVariable, test.envPassword); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Unsetenv(passwordEnvVariable)
+
+			configFile := filepath.Join(directory, "config.toml")
+			err := ioutil.WriteFile(
+				configFile,
+				[]byte(configContent+test.storagePasswordSecret),
+				0600,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := ReadConfig(configFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cfg.Ethereum.Account.KeyFilePassword != test.expectedKeyFilePassword {
+				t.Errorf(
+					"unexpected key file password\nexpected: [%v]\nactual:   [%v]",
+					test.expectedKeyFilePassword,
+					cfg.Ethereum.Account.KeyFilePassword,
+				)
+			}
+
+			if cfg.StoragePassword() != test.expectedStoragePassword {
+				t.Errorf(
+					"unexpected storage password\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStoragePassword,
+					cfg.StoragePassword(),
+				)
+			}
+		})
+	}
+}
+
+func TestSecretProvider(t *testing.T) {
+	var tests = map[string]struct {
+		secret        Secret
+		expectedError string
+	}{
+		"file": {
+			secret: Secret{File: "/run/secrets/password"},
+		},
+		"command": {
+			secret: Secret{Command: []string{"pass", "show", "keep"}},
+		},
+		"secret store": {
+			secret: Secret{
+				URL:       "https://vault:8200/v1/secret/data/keep",
+				TokenFile: "/run/secrets/token",
+				Field:     "data.data.password",
+			},
+		},
+		"no source": {
+			secret:        Secret{Field: "password"},
+			expectedError: "exactly one of File, Command and URL must be set",
+		},
+		"multiple sources": {
+			secret: Secret{
+				File:    "/run/secrets/password",
+				Command: []string{"pass"},
+			},
+			expectedError: "exactly one of File, Command and URL must be set",
+		},
+		"token file without URL": {
+			secret: Secret{
+				File:      "/run/secrets/password",
+				TokenFile: "/run/secrets/token",
+			},
+			expectedError: "TokenFile and Field can be set only with URL",
+		},
+		"URL not HTTP": {
+			secret:        Secret{URL: "ftp://vault/keep"},
+			expectedError: "URL [ftp://vault/keep] must be an HTTP (http://, https://) URL",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			provider, err := test.secret.Provider()
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%v]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if provider == nil {
+				t.Fatal("expected provider")
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// environmentPrefix is the prefix of environment variables overriding values
+// of the config.
+const environmentPrefix = "KEEP"
+
+// knownContracts lists names of contracts which addresses can be set with
+// environment variables even if they are not present in the config file.
+var knownContracts = []string{
+	"KeepRandomBeaconOperator",
+	"KeepRandomBeaconService",
+	"TokenStaking",
+	"TokenGrant",
+}
+
+// configField is a value of the config which can be overridden with an
+// environment variable.
+type configField struct {
+	// path holds names of the field and of all the sections the field is
+	// nested in, for example `Ethereum`, `Account`, `KeyFile`.
+	path  []string
+	value reflect.Value
+}
+
+// environmentVariable returns the name of the environment variable
+// overriding the field, for example `KEEP_ETHEREUM_ACCOUNT_KEYFILE`.
+func (cf *configField) environmentVariable() string {
+	return environmentPrefix + "_" + strings.ToUpper(strings.Join(cf.path, "_"))
+}
+
+// applyEnvironment overrides values of the config with values of environment
+// variables named `KEEP_<SECTION>_<FIELD>`, for example `KEEP_LIBP2P_PORT` or
+// `KEEP_ETHEREUM_ACCOUNT_KEYFILE`. Names are case-insensitive and underscores
+// between words of a section or field name are optional, so both
+// `KEEP_STORAGE_DATADIR` and `KEEP_STORAGE_DATA_DIR` set `Storage.DataDir`.
+// Lists are given as JSON arrays or as comma-separated values. Environment
+// variables not matching any field are ignored.
+func applyEnvironment(config *Config, environment []string) error {
+	fields := make(map[string]*configField)
+	for _, field := range configFields(config) {
+		fields[normalizeVariable(field.environmentVariable())] = field
+	}
+
+	for _, variable := range environment {
+		separator := strings.Index(variable, "=")
+		if separator < 0 {
+			continue
+		}
+		name, value := variable[:separator], variable[separator+1:]
+
+		normalizedName := normalizeVariable(name)
+		if !strings.HasPrefix(normalizedName, environmentPrefix) {
+			continue
+		}
+
+		field, ok := fields[normalizedName]
+		if !ok {
+			if err := applyContractAddress(config, normalizedName, value); err != nil {
+				return fmt.Errorf(
+					"invalid value of environment variable [%v]: [%v]",
+					name,
+					err,
+				)
+			}
+			continue
+		}
+
+		if err := setFieldValue(field.value, value); err != nil {
+			return fmt.Errorf(
+				"invalid value of environment variable [%v]: [%v]",
+				name,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// applyContractAddress sets the contract address if the normalized name of
+// the environment variable denotes a contract address.
+func applyContractAddress(config *Config, normalizedName, value string) error {
+	prefix := normalizeVariable(environmentPrefix + "_ETHEREUM_CONTRACTADDRESSES")
+	if !strings.HasPrefix(normalizedName, prefix) {
+		return nil
+	}
+	normalizedContract := strings.TrimPrefix(normalizedName, prefix)
+
+	if config.Ethereum.ContractAddresses == nil {
+		config.Ethereum.ContractAddresses = make(map[string]string)
+	}
+
+	candidates := append([]string{}, knownContracts...)
+	for contract := range config.Ethereum.ContractAddresses {
+		candidates = append(candidates, contract)
+	}
+
+	for _, contract := range candidates {
+		if normalizeVariable(contract) == normalizedContract {
+			config.Ethereum.ContractAddresses[contract] = value
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"unknown contract; address of a contract other than %v can be "+
+			"overridden only if it is present in the config file",
+		knownContracts,
+	)
+}
+
+func normalizeVariable(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "_", ""))
+}
+
+// configFields returns all the fields of the config which can be overridden
+// with environment variables. Fields of embedded structs are treated as
+// fields of the embedding struct.
+func configFields(config *Config) []*configField {
+	var fields []*configField
+
+	var collect func(value reflect.Value, path []string)
+	collect = func(value reflect.Value, path []string) {
+		valueType := value.Type()
+		for i := 0; i < valueType.NumField(); i++ {
+			structField := valueType.Field(i)
+			if structField.PkgPath != "" {
+				// unexported field
+				continue
+			}
+
+			fieldValue := value.Field(i)
+			fieldPath := append(append([]string{}, path...), structField.Name)
+
+			switch {
+			case isTextUnmarshaler(fieldValue):
+				fields = append(fields, &configField{fieldPath, fieldValue})
+			case structField.Anonymous && fieldValue.Kind() == reflect.Struct:
+				collect(fieldValue, path)
+			case fieldValue.Kind() == reflect.Struct:
+				collect(fieldValue, fieldPath)
+			case fieldValue.Kind() == reflect.Map:
+				// maps are handled separately as their keys are not known
+				// upfront
+			default:
+				fields = append(fields, &configField{fieldPath, fieldValue})
+			}
+		}
+	}
+	collect(reflect.ValueOf(config).Elem(), nil)
+
+	return fields
+}
+
+func isTextUnmarshaler(value reflect.Value) bool {
+	textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	if value.Kind() == reflect.Ptr {
+		return value.Type().Implements(textUnmarshaler)
+	}
+	return reflect.PtrTo(value.Type()).Implements(textUnmarshaler)
+}
+
+func setFieldValue(field reflect.Value, value string) error {
+	if isTextUnmarshaler(field) {
+		if field.Kind() == reflect.Ptr {
+			newValue := reflect.New(field.Type().Elem())
+			if err := newValue.Interface().(encoding.TextUnmarshaler).UnmarshalText(
+				[]byte(value),
+			); err != nil {
+				return err
+			}
+			field.Set(newValue)
+			return nil
+		}
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(
+			[]byte(value),
+		)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type [%v]", field.Type())
+		}
+		values, err := parseList(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(values).Convert(field.Type()))
+	default:
+		return fmt.Errorf("unsupported type [%v]", field.Type())
+	}
+
+	return nil
+}
+
+// parseList parses a list given as a JSON array of strings or as
+// comma-separated values.
+func parseList(value string) ([]string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return []string{}, nil
+	}
+
+	if strings.HasPrefix(trimmed, "[") {
+		var values []string
+		if err := json.Unmarshal([]byte(trimmed), &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+
+	values := strings.Split(trimmed, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values, nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnvironment(t *testing.T) {
+	cfg := &Config{}
+	cfg.LibP2P.Port = 3919
+	cfg.Storage.DataDir = "/my/secure/location"
+	cfg.Ethereum.ContractAddresses = map[string]string{
+		"CustomContract": "0x1",
+	}
+
+	err := applyEnvironment(cfg, []string{
+		"KEEP_LIBP2P_PORT=27001",
+		"KEEP_LIBP2P_PEERS=/ip4/127.0.0.1/tcp/3919, /ip4/127.0.0.1/tcp/3920",
+		"KEEP_STORAGE_DATA_DIR=/data",
+		"KEEP_ETHEREUM_ACCOUNT_KEYFILE=/keys/operator",
+		"KEEP_ETHEREUM_MAXGASPRICE=140 Gwei",
+		"KEEP_ETHEREUM_CONTRACTADDRESSES_TOKENSTAKING=0x2",
+		"KEEP_ETHEREUM_CONTRACT_ADDRESSES_CUSTOM_CONTRACT=0x3",
+		"KEEP_SECRETS_KEYFILEPASSWORD_COMMAND=[\"pass\", \"keep/operator\"]",
+		"KEEP_ETHEREUM_PASSWORD=ignored",
+		"PATH=/usr/bin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		actual   interface{}
+		expected interface{}
+	}{
+		"LibP2P.Port": {
+			actual:   cfg.LibP2P.Port,
+			expected: 27001,
+		},
+		"LibP2P.Peers": {
+			actual: cfg.LibP2P.Peers,
+			expected: []string{
+				"/ip4/127.0.0.1/tcp/3919",
+				"/ip4/127.0.0.1/tcp/3920",
+			},
+		},
+		"Storage.DataDir": {
+			actual:   cfg.Storage.DataDir,
+			expected: "/data",
+		},
+		"Ethereum.Account.KeyFile": {
+			actual:   cfg.Ethereum.Account.KeyFile,
+			expected: "/keys/operator",
+		},
+		"Ethereum.MaxGasPrice": {
+			actual:   cfg.Ethereum.MaxGasPrice.Int,
+			expected: big.NewInt(140000000000),
+		},
+		"Ethereum.ContractAddresses": {
+			actual: cfg.Ethereum.ContractAddresses,
+			expected: map[string]string{
+				"CustomContract": "0x3",
+				"TokenStaking":   "0x2",
+			},
+		},
+		"Secrets.KeyFilePassword.Command": {
+			actual:   cfg.Secrets.KeyFilePassword.Command,
+			expected: []string{"pass", "keep/operator"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if !reflect.DeepEqual(test.expected, test.actual) {
+				t.Errorf("\nexpected: %v\nactual:   %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestApplyEnvironmentInvalidValue(t *testing.T) {
+	var tests = map[string]string{
+		"invalid number":   "KEEP_LIBP2P_PORT=port",
+		"invalid amount":   "KEEP_ETHEREUM_MAXGASPRICE=many",
+		"unknown contract": "KEEP_ETHEREUM_CONTRACTADDRESSES_UNKNOWN=0x1",
+	}
+
+	for testName, variable := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := applyEnvironment(&Config{}, []string{variable})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// redactedValue replaces secret values in the printed config.
+const redactedValue = "[redacted]"
+
+// Formats returns the names of supported config formats.
+func Formats() []string {
+	return []string{"toml", "json", "yaml"}
+}
+
+// decodeFile decodes the configuration file at `filePath` into the config.
+// The format of the file is determined by its extension: `.json` for JSON,
+// `.yaml` or `.yml` for YAML and TOML otherwise. JSON and YAML documents are
+// converted to TOML before decoding, so that values are interpreted the same
+// way regardless of the format.
+func decodeFile(filePath string, config *Config) (toml.MetaData, error) {
+	decodingError := func(err error) error {
+		return fmt.Errorf(
+			"unable to decode config file [%s] error [%s]",
+			filePath,
+			err,
+		)
+	}
+
+	var (
+		document interface{}
+		err      error
+	)
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		document, err = readJSON(filePath)
+	case ".yaml", ".yml":
+		document, err = readYAML(filePath)
+	default:
+		metadata, err := toml.DecodeFile(filePath, config)
+		if err != nil {
+			return metadata, decodingError(err)
+		}
+		return metadata, nil
+	}
+	if err != nil {
+		return toml.MetaData{}, decodingError(err)
+	}
+
+	table, ok := document.(map[string]interface{})
+	if !ok {
+		return toml.MetaData{}, decodingError(
+			fmt.Errorf("top-level value must be an object"),
+		)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := toml.NewEncoder(buffer).Encode(table); err != nil {
+		return toml.MetaData{}, decodingError(err)
+	}
+
+	metadata, err := toml.Decode(buffer.String(), config)
+	if err != nil {
+		return metadata, decodingError(err)
+	}
+
+	return metadata, nil
+}
+
+func readJSON(filePath string) (interface{}, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return normalizeDocument(document)
+}
+
+func readYAML(filePath string) (interface{}, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	return normalizeDocument(document)
+}
+
+// normalizeDocument converts values decoded from JSON or YAML to values the
+// TOML encoder accepts: maps are keyed by strings, numbers are either
+// integers or floats and null values are dropped.
+func normalizeDocument(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			if element == nil {
+				continue
+			}
+			normalized, err := normalizeDocument(element)
+			if err != nil {
+				return nil, err
+			}
+			table[key] = normalized
+		}
+		return table, nil
+	case map[interface{}]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			if element == nil {
+				continue
+			}
+			normalized, err := normalizeDocument(element)
+			if err != nil {
+				return nil, err
+			}
+			table[fmt.Sprintf("%v", key)] = normalized
+		}
+		return table, nil
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, element := range v {
+			normalized, err := normalizeDocument(element)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = normalized
+		}
+		return array, nil
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer, nil
+		}
+		return v.Float64()
+	default:
+		return value, nil
+	}
+}
+
+// Encode writes the config to the writer in the given format, one of
+// Formats, with secret values redacted. Values are written as the client
+// sees them, that is after applying environment variable overrides if the
+// config was read with Load.
+func Encode(writer io.Writer, config *Config, format string) error {
+	redacted := redact(config)
+
+	buffer := &bytes.Buffer{}
+	if err := toml.NewEncoder(buffer).Encode(redacted); err != nil {
+		return fmt.Errorf("could not encode config: [%v]", err)
+	}
+
+	if format == "toml" {
+		_, err := buffer.WriteTo(writer)
+		return err
+	}
+
+	// JSON and YAML documents are produced from the TOML one so values such
+	// as token amounts are printed the same way in all the formats.
+	var document map[string]interface{}
+	if _, err := toml.Decode(buffer.String(), &document); err != nil {
+		return fmt.Errorf("could not encode config: [%v]", err)
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(document)
+	case "yaml":
+		encoder := yaml.NewEncoder(writer)
+		if err := encoder.Encode(document); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf(
+			"unsupported format [%v]; supported formats are: %v",
+			format,
+			strings.Join(Formats(), ", "),
+		)
+	}
+}
+
+// redact returns a copy of the config with secret values replaced.
+func redact(config *Config) *Config {
+	redacted := *config
+
+	if redacted.Ethereum.Account.KeyFilePassword != "" {
+		redacted.Ethereum.Account.KeyFilePassword = redactedValue
+	}
+
+	redacted.Secrets.KeyFilePassword.URL = redactSecretURL(
+		redacted.Secrets.KeyFilePassword.URL,
+	)
+	redacted.Secrets.StoragePassword.URL = redactSecretURL(
+		redacted.Secrets.StoragePassword.URL,
+	)
+
+	redacted.Ethereum.URL = redactURL(redacted.Ethereum.URL)
+	redacted.Ethereum.URLRPC = redactURL(redacted.Ethereum.URLRPC)
+	redacted.Signer.Endpoint = redactURL(redacted.Signer.Endpoint)
+
+	if len(config.Operators) > 0 {
+		redacted.Operators = make([]Operator, len(config.Operators))
+		for i, operator := range config.Operators {
+			operator.KeyFilePassword.URL = redactSecretURL(
+				operator.KeyFilePassword.URL,
+			)
+			redacted.Operators[i] = operator
+		}
+	}
+
+	return &redacted
+}
+
+// redactSecretURL replaces the password in the user info part and values of
+// the query parameters of the URL of a secret, which may hold access tokens.
+func redactSecretURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return redactURL(rawURL)
+	}
+
+	query := parsed.Query()
+	parameters := make([]string, 0, len(query))
+	for key := range query {
+		parameters = append(parameters, url.QueryEscape(key)+"="+redactedValue)
+	}
+	sort.Strings(parameters)
+	parsed.RawQuery = strings.Join(parameters, "&")
+
+	return redactURL(parsed.String())
+}
+
+// redactURL replaces the password in the user info part of the URL, if any.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil {
+		return rawURL
+	}
+
+	if _, ok := parsed.User.Password(); !ok {
+		return rawURL
+	}
+
+	username := parsed.User.Username()
+	parsed.User = nil
+	return strings.Replace(
+		parsed.String(),
+		"://",
+		"://"+url.PathEscape(username)+":"+redactedValue+"@",
+		1,
+	)
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Operator stores the configuration of one of the operators run by the
+// client in the multi-operator mode. In this mode, the account from the
+// Ethereum section, the LibP2P port and announced addresses, and the storage
+// directory are not used; each operator has its own.
+type Operator struct {
+	// KeyFile is the path to the operator key file.
+	KeyFile string
+	// KeyFilePassword is the source of the operator key file password. The
+	// account password is used if not set.
+	KeyFilePassword Secret
+	// DataDir is the directory the operator data are stored in.
+	DataDir string
+	// Port is the port the operator network identity listens on.
+	Port int
+	// AnnouncedAddresses are the addresses announced to other peers instead
+	// of the addresses the operator listens on.
+	AnnouncedAddresses []string
+}
+
+// operatorPasswords holds the passwords of an operator, resolved when the
+// config is read.
+type operatorPasswords struct {
+	keyFile string
+	storage string
+}
+
+// IsMultiOperator tells whether the client runs multiple operators listed in
+// the Operators section of the config.
+func (c *Config) IsMultiOperator() bool {
+	return len(c.Operators) > 0
+}
+
+// OperatorPasswords returns the key file password and the storage password
+// of the operator with the given index in the Operators section. The storage
+// password is the operator key file password unless a different source of
+// the storage password is configured. The passwords are available only for
+// configs read with ReadConfig.
+func (c *Config) OperatorPasswords(index int) (
+	keyFilePassword string,
+	storagePassword string,
+) {
+	if index < 0 || index >= len(c.operatorPasswords) {
+		return "", ""
+	}
+
+	passwords := c.operatorPasswords[index]
+	return passwords.keyFile, passwords.storage
+}
+
+// requiresAccountPassword tells whether the account password has to be
+// provided: in the single-operator mode unless the operator key is held by
+// the external signer and, in the multi-operator mode, if any of the
+// operators has no own source of the key file password.
+func requiresAccountPassword(config *Config) bool {
+	if !config.IsMultiOperator() {
+		return !config.UsesExternalSigner()
+	}
+
+	for _, operator := range config.Operators {
+		if !operator.KeyFilePassword.IsSet() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readOperatorPasswords(config *Config) error {
+	config.operatorPasswords = make([]operatorPasswords, len(config.Operators))
+
+	for i := range config.Operators {
+		operator := &config.Operators[i]
+
+		keyFilePassword := config.Ethereum.Account.KeyFilePassword
+		if operator.KeyFilePassword.IsSet() {
+			password, err := readSecret(
+				fmt.Sprintf("Operators[%v].KeyFilePassword", i),
+				&operator.KeyFilePassword,
+			)
+			if err != nil {
+				return err
+			}
+			keyFilePassword = password
+		}
+
+		storagePassword := keyFilePassword
+		if config.Secrets.StoragePassword.IsSet() {
+			storagePassword = config.storagePassword
+		}
+
+		config.operatorPasswords[i] = operatorPasswords{
+			keyFile: keyFilePassword,
+			storage: storagePassword,
+		}
+	}
+
+	return nil
+}
+
+// validateOperatorsRequired checks the values of the Operators section
+// without which the client can not start.
+func validateOperatorsRequired(config *Config) error {
+	ports := make(map[int]int)
+	for i, operator := range config.Operators {
+		if operator.KeyFile == "" {
+			return fmt.Errorf("missing value for Operators[%v].KeyFile", i)
+		}
+
+		if operator.DataDir == "" {
+			return fmt.Errorf("missing value for Operators[%v].DataDir", i)
+		}
+
+		if operator.Port == 0 {
+			return fmt.Errorf("missing value for Operators[%v].Port", i)
+		}
+
+		if other, ok := ports[operator.Port]; ok {
+			return fmt.Errorf(
+				"Operators[%v] and Operators[%v] use the same port [%v]",
+				other,
+				i,
+				operator.Port,
+			)
+		}
+		ports[operator.Port] = i
+	}
+
+	return nil
+}
+
+func validateOperators(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	if !config.IsMultiOperator() {
+		return
+	}
+
+	for i := range config.Operators {
+		operator := &config.Operators[i]
+		name := fmt.Sprintf("Operators[%v]", i)
+
+		if operator.KeyFile == "" {
+			problemf("missing value for %v.KeyFile", name)
+		} else if _, err := os.Stat(operator.KeyFile); err != nil {
+			problemf("could not access key file of %v: [%v]", name, err)
+		}
+
+		if operator.Port == 0 {
+			problemf("missing value for %v.Port", name)
+		}
+
+		validateDataDir(name+".DataDir", operator.DataDir, problemf)
+
+		if operator.KeyFilePassword.IsSet() {
+			validateSecret(
+				name+".KeyFilePassword",
+				&operator.KeyFilePassword,
+				problemf,
+			)
+		}
+
+		for _, address := range operator.AnnouncedAddresses {
+			if _, err := multiaddr.NewMultiaddr(address); err != nil {
+				problemf(
+					"announced address [%v] of %v is not a valid "+
+						"multiaddr: [%v]",
+					address,
+					name,
+					err,
+				)
+			}
+		}
+	}
+}
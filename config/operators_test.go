@@ -0,0 +1,180 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigOperators(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config-operators")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	passwordFile := filepath.Join(directory, "password")
+	err = ioutil.WriteFile(passwordFile, []byte("operator-2-password\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configContent := `
+[[Operators]]
+	KeyFile = "/keys/operator-1"
+	DataDir = "` + directory + `/operator-1"
+	Port = 3919
+
+[[Operators]]
+	KeyFile = "/keys/operator-2"
+	DataDir = "` + directory + `/operator-2"
+	Port = 3920
+
+[Operators.KeyFilePassword]
+	File = "` + passwordFile + `"
+`
+
+	configFile := filepath.Join(directory, "config.toml")
+	err = ioutil.WriteFile(configFile, []byte(configContent), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Setenv(passwordEnvVariable, "account-password"); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(passwordEnvVariable)
+
+	cfg, err := ReadConfig(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cfg.IsMultiOperator() {
+		t.Fatal("expected multi-operator config")
+	}
+
+	expectedPasswords := []string{"account-password", "operator-2-password"}
+	for i, expectedPassword := range expectedPasswords {
+		keyFilePassword, storagePassword := cfg.OperatorPasswords(i)
+		if keyFilePassword != expectedPassword {
+			t.Errorf(
+				"unexpected key file password of operator [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedPassword,
+				keyFilePassword,
+			)
+		}
+		if storagePassword != expectedPassword {
+			t.Errorf(
+				"unexpected storage password of operator [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedPassword,
+				storagePassword,
+			)
+		}
+	}
+
+	// The first operator has no own password source and requires the
+	// account password.
+	os.Unsetenv(passwordEnvVariable)
+	if _, err := ReadConfig(configFile); err == nil {
+		t.Errorf("expected error for missing account password")
+	}
+
+	// The account password is not needed if all the operators have their
+	// own password sources.
+
+	err = ioutil.WriteFile(
+		configFile,
+		[]byte(strings.Replace(
+			configContent,
+			"\tPort = 3919\n",
+			"\tPort = 3919\n[Operators.KeyFilePassword]\n\tFile = \""+
+				passwordFile+"\"\n",
+			1,
+		)),
+		0600,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = ReadConfig(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keyFilePassword, _ := cfg.OperatorPasswords(0); keyFilePassword != "operator-2-password" {
+		t.Errorf("unexpected key file password [%v]", keyFilePassword)
+	}
+}
+
+func TestValidateOperators(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config-operators-validation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	configFile := filepath.Join(directory, "config.toml")
+	err = ioutil.WriteFile(configFile, []byte(`
+[ethereum]
+	URL = "ws://127.0.0.1:8546"
+
+[ethereum.ContractAddresses]
+	KeepRandomBeaconOperator = "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb"
+	TokenStaking = "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb"
+
+[Admin]
+	Port = 9701
+
+[[Operators]]
+	KeyFile = "/non/existing/key-file"
+	DataDir = "`+directory+`"
+	Port = 3919
+
+[[Operators]]
+	DataDir = "`+directory+`"
+	Port = 3919
+	AnnouncedAddresses = ["not-a-multiaddr"]
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, problems := Validate(configFile)
+
+	expectedProblems := []string{
+		"could not access key file of Operators[0]",
+		"missing value for Operators[1].KeyFile",
+		"announced address [not-a-multiaddr] of Operators[1] is not a valid multiaddr",
+		"Operators[0].Port and Operators[1].Port use the same port [3919]",
+	}
+
+	if len(problems) != len(expectedProblems) {
+		t.Errorf(
+			"unexpected number of problems\nexpected: [%v]\nactual:   [%v]",
+			len(expectedProblems),
+			problems,
+		)
+	}
+
+	for _, expectedProblem := range expectedProblems {
+		found := false
+		for _, problem := range problems {
+			if strings.Contains(problem.Error(), expectedProblem) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			t.Errorf("expected problem [%v] not reported", expectedProblem)
+		}
+	}
+}
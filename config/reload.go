@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// reloadableFields lists fields of the config which can be changed while the
+// client runs. Changes of any other field require a restart.
+var reloadableFields = map[string]bool{
+	"LibP2P.Peers":                    true,
+	"LibP2P.DisseminationTime":        true,
+	"Metrics.NetworkMetricsTick":      true,
+	"Metrics.EthereumMetricsTick":     true,
+	"Ethereum.RequestsPerSecondLimit": true,
+	"Ethereum.ConcurrencyLimit":       true,
+	"Logging.Level":                   true,
+}
+
+// Change is a change of a single config value.
+type Change struct {
+	// Field is the dot-separated path of the changed field, for example
+	// `LibP2P.Peers`.
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// IsReloadable tells whether the change can be applied without restarting
+// the client.
+func (c *Change) IsReloadable() bool {
+	return reloadableFields[c.Field]
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%v: [%v] -> [%v]", c.Field, c.OldValue, c.NewValue)
+}
+
+// Diff returns the changes between the current and the updated config,
+// sorted by field. Values of secrets are redacted.
+func Diff(current *Config, updated *Config) []*Change {
+	currentFields := configFields(redact(current))
+	updatedFields := configFields(redact(updated))
+
+	var changes []*Change
+	for i, currentField := range currentFields {
+		currentValue := currentField.value.Interface()
+		updatedValue := updatedFields[i].value.Interface()
+
+		if reflect.DeepEqual(currentValue, updatedValue) {
+			continue
+		}
+
+		changes = append(changes, &Change{
+			Field:    strings.Join(currentField.path, "."),
+			OldValue: formatValue(currentValue),
+			NewValue: formatValue(updatedValue),
+		})
+	}
+
+	contracts := make(map[string]bool)
+	for contract := range current.Ethereum.ContractAddresses {
+		contracts[contract] = true
+	}
+	for contract := range updated.Ethereum.ContractAddresses {
+		contracts[contract] = true
+	}
+	for contract := range contracts {
+		currentAddress := current.Ethereum.ContractAddresses[contract]
+		updatedAddress := updated.Ethereum.ContractAddresses[contract]
+
+		if currentAddress != updatedAddress {
+			changes = append(changes, &Change{
+				Field:    "Ethereum.ContractAddresses." + contract,
+				OldValue: currentAddress,
+				NewValue: updatedAddress,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case fmt.Stringer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return ""
+		}
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+)
+
+func TestDiff(t *testing.T) {
+	current := &Config{}
+	current.LibP2P.Port = 3919
+	current.LibP2P.Peers = []string{"/ip4/127.0.0.1/tcp/3919"}
+	current.Metrics.NetworkMetricsTick = 60
+	current.Ethereum.Account.KeyFile = "/keys/operator"
+	current.Ethereum.Account.KeyFilePassword = "p4ssw0rd"
+	current.Ethereum.ContractAddresses = map[string]string{
+		"TokenStaking": "0x1",
+	}
+
+	updated := &Config{}
+	updated.LibP2P.Port = 3920
+	updated.LibP2P.Peers = []string{
+		"/ip4/127.0.0.1/tcp/3919",
+		"/ip4/127.0.0.1/tcp/3920",
+	}
+	updated.Metrics.NetworkMetricsTick = 60
+	updated.Ethereum.Account.KeyFile = "/keys/operator"
+	updated.Ethereum.Account.KeyFilePassword = "s3cr3t"
+	updated.Ethereum.ContractAddresses = map[string]string{
+		"TokenStaking": "0x2",
+	}
+	updated.Ethereum.MaxGasPrice = &ethereum.Wei{}
+	if err := updated.Ethereum.MaxGasPrice.UnmarshalText(
+		[]byte("140 Gwei"),
+	); err != nil {
+		t.Fatal(err)
+	}
+	updated.Logging.Level = "debug"
+
+	changes := Diff(current, updated)
+
+	actual := make([]Change, len(changes))
+	for i, change := range changes {
+		actual[i] = *change
+	}
+
+	expected := []Change{
+		{"Ethereum.ContractAddresses.TokenStaking", "0x1", "0x2"},
+		{"Ethereum.MaxGasPrice", "", "140000000000"},
+		{
+			"LibP2P.Peers",
+			"/ip4/127.0.0.1/tcp/3919",
+			"/ip4/127.0.0.1/tcp/3919, /ip4/127.0.0.1/tcp/3920",
+		},
+		{"LibP2P.Port", "3919", "3920"},
+		{"Logging.Level", "", "debug"},
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("\nexpected: %v\nactual:   %v", expected, actual)
+	}
+
+	expectedReloadable := map[string]bool{
+		"Ethereum.ContractAddresses.TokenStaking": false,
+		"Ethereum.MaxGasPrice":                    false,
+		"LibP2P.Peers":                            true,
+		"LibP2P.Port":                             false,
+		"Logging.Level":                           true,
+	}
+	for _, change := range changes {
+		if change.IsReloadable() != expectedReloadable[change.Field] {
+			t.Errorf(
+				"unexpected reloadability of [%v]\nexpected: %v\nactual:   %v",
+				change.Field,
+				expectedReloadable[change.Field],
+				change.IsReloadable(),
+			)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/secret"
+)
+
+// secretTimeout is the maximum time to obtain a secret from its provider.
+const secretTimeout = 30 * time.Second
+
+// Secrets stores the sources of secrets read by the client.
+type Secrets struct {
+	// KeyFilePassword is the password of the operator key file. It is used
+	// if the KEEP_ETHEREUM_PASSWORD environment variable is not set.
+	KeyFilePassword Secret
+	// StoragePassword is the password used to encrypt data stored on disk.
+	// It defaults to the key file password.
+	StoragePassword Secret
+}
+
+// Secret stores the source of a secret. Exactly one of File, Command and URL
+// has to be set for the source to be used. TokenFile and Field can be set only
+// along with URL.
+type Secret struct {
+	// File is the path to the file holding the secret, such as a Docker or
+	// Kubernetes secret mount.
+	File string
+	// Command is the command, along with its arguments, printing the secret
+	// to the standard output.
+	Command []string
+	// URL is the endpoint of an HTTP secret store serving the secret.
+	URL string
+	// TokenFile is the path to the file holding the bearer token used to
+	// authenticate to the secret store.
+	TokenFile string
+	// Field is the dot-separated path to the secret in the JSON object
+	// returned by the secret store. The whole response is the secret if not
+	// set.
+	Field string
+}
+
+// IsSet tells whether any source of the secret is set.
+func (s *Secret) IsSet() bool {
+	return s.File != "" || len(s.Command) > 0 || s.URL != "" ||
+		s.TokenFile != "" || s.Field != ""
+}
+
+// Provider returns the provider of the secret from the configured source or
+// an error if the source is misconfigured.
+func (s *Secret) Provider() (secret.Provider, error) {
+	sources := 0
+	for _, isSet := range []bool{s.File != "", len(s.Command) > 0, s.URL != ""} {
+		if isSet {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("exactly one of File, Command and URL must be set")
+	}
+
+	if s.URL == "" && (s.TokenFile != "" || s.Field != "") {
+		return nil, fmt.Errorf("TokenFile and Field can be set only with URL")
+	}
+
+	switch {
+	case s.File != "":
+		return &secret.File{Path: s.File}, nil
+	case len(s.Command) > 0:
+		if s.Command[0] == "" {
+			return nil, fmt.Errorf("command name must not be empty")
+		}
+		return &secret.Command{Name: s.Command[0], Args: s.Command[1:]}, nil
+	default:
+		secretURL, err := url.Parse(s.URL)
+		if err != nil ||
+			(secretURL.Scheme != "http" && secretURL.Scheme != "https") {
+			return nil, fmt.Errorf(
+				"URL [%v] must be an HTTP (http://, https://) URL",
+				s.URL,
+			)
+		}
+
+		provider := &secret.HTTP{URL: s.URL, Field: s.Field}
+		if s.TokenFile != "" {
+			provider.Token = &secret.File{Path: s.TokenFile}
+		}
+		return provider, nil
+	}
+}
+
+// readSecret obtains the secret with the given name from its configured
+// source.
+func readSecret(name string, source *Secret) (string, error) {
+	provider, err := source.Provider()
+	if err != nil {
+		return "", fmt.Errorf("invalid source of secret [%v]: [%v]", name, err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), secretTimeout)
+	defer cancelCtx()
+
+	value, err := provider.Secret(ctx)
+	if err != nil {
+		return "", fmt.Errorf(
+			"could not read secret [%v] from %v: [%v]",
+			name,
+			provider,
+			err,
+		)
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("secret [%v] read from %v is empty", name, provider)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Signer stores the configuration of the external signer holding the
+// operator key. If the endpoint is set, the operator key file is not read;
+// transactions and messages, including network messages, are signed by the
+// signer instead.
+type Signer struct {
+	// Endpoint is the path to the unix socket or the HTTP URL of the signer
+	// JSON-RPC API.
+	Endpoint string
+	// Address is the address of the operator account. It can be omitted if
+	// the signer holds exactly one key.
+	Address string
+}
+
+// UsesExternalSigner tells whether the operator key is held by the external
+// signer configured in the Signer section.
+func (c *Config) UsesExternalSigner() bool {
+	return c.Signer.Endpoint != ""
+}
+
+// SignerAddress returns the address of the operator account held by the
+// external signer or an empty address if not set.
+func (c *Config) SignerAddress() common.Address {
+	return common.HexToAddress(c.Signer.Address)
+}
+
+// validateSignerRequired checks the values of the Signer section without
+// which the client can not start.
+func validateSignerRequired(config *Config) error {
+	if !config.UsesExternalSigner() {
+		return nil
+	}
+
+	if config.IsMultiOperator() {
+		return fmt.Errorf("external signer is not supported with multiple operators")
+	}
+
+	if config.Signer.Address != "" && !common.IsHexAddress(config.Signer.Address) {
+		return fmt.Errorf(
+			"Signer.Address [%v] is not a valid hex address",
+			config.Signer.Address,
+		)
+	}
+
+	return nil
+}
+
+func validateSigner(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	if err := validateSignerRequired(config); err != nil {
+		problemf("%v", err)
+	}
+}
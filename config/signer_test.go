@@ -0,0 +1,83 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadConfigSigner(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	passwordFile := filepath.Join(directory, "storage-password")
+	err = ioutil.WriteFile(passwordFile, []byte("storage-password\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configContent := `
+[LibP2P]
+	Port = 3919
+
+[Storage]
+	DataDir = "` + directory + `"
+
+[Signer]
+	Endpoint = "` + directory + `/signer.ipc"
+	Address = "0x440a9a3fcc8eb4a2d73fad7c3ea11d6f40e2f7e3"
+`
+
+	configFile := filepath.Join(directory, "config.toml")
+	err = ioutil.WriteFile(configFile, []byte(configContent), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Unsetenv(passwordEnvVariable)
+
+	// Without the key file there is no password the storage password could
+	// default to.
+	if _, err := ReadConfig(configFile); err == nil {
+		t.Fatal("expected error for missing storage password")
+	}
+
+	storagePasswordConfig := configContent + `
+[Secrets.StoragePassword]
+	File = "` + passwordFile + `"
+`
+	err = ioutil.WriteFile(configFile, []byte(storagePasswordConfig), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadConfig(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cfg.UsesExternalSigner() {
+		t.Fatal("expected external signer config")
+	}
+
+	if cfg.StoragePassword() != "storage-password" {
+		t.Errorf(
+			"unexpected storage password\nexpected: [%v]\nactual:   [%v]",
+			"storage-password",
+			cfg.StoragePassword(),
+		)
+	}
+
+	expectedAddress := "0x440a9A3FcC8eB4A2d73faD7C3EA11D6f40E2f7e3"
+	if cfg.SignerAddress().Hex() != expectedAddress {
+		t.Errorf(
+			"unexpected signer address\nexpected: [%v]\nactual:   [%v]",
+			expectedAddress,
+			cfg.SignerAddress().Hex(),
+		)
+	}
+}
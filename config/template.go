@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/template"
+)
+
+// network holds the network-specific values of the config file template.
+type network struct {
+	Description              string
+	URL                      string
+	URLRPC                   string
+	KeepRandomBeaconOperator string
+	TokenStaking             string
+	KeepRandomBeaconService  string
+	Peers                    []string
+}
+
+var networks = map[string]network{
+	"mainnet": {
+		Description:              "Ethereum mainnet",
+		URL:                      "ws://127.0.0.1:8546",
+		URLRPC:                   "http://127.0.0.1:8545",
+		KeepRandomBeaconOperator: "0xdf708431162ba247ddae362d2c919e0fbafcf9de",
+		TokenStaking:             "0x1293a54e160d1cd7075487898d65266081a15458",
+		KeepRandomBeaconService:  "0x50510e691c90ea098e3fdd23c311731bf394aafd",
+		Peers: []string{
+			"/dns4/bst-a01.core.keep.boar.network/tcp/3001/ipfs/16Uiu2HAkzYFHsqbwt64ZztWWK1hyeLntRNqWMYFiZjaKu1PZgikN",
+			"/dns4/bst-b01.core.keep.boar.network/tcp/3001/ipfs/16Uiu2HAkxLttmh3G8LYzAy1V1g1b3kdukzYskjpvv5DihY4wvx7D",
+			"/dns4/keep-boot-validator-0.prod-us-west-2.staked.cloud/tcp/3919/ipfs/16Uiu2HAmDnq9qZJH9zJJ3TR4pX1BkYHWtR2rVww24ttxQTiKhsaJ",
+			"/dns4/keep-boot-validator-1.prod-us-west-2.staked.cloud/tcp/3919/ipfs/16Uiu2HAmHbbMTDDsT2f6z8zMgDtJkTUDJQSYsQYUpaJjdMjiYNEf",
+			"/dns4/keep-boot-validator-2.prod-us-west-2.staked.cloud/tcp/3919/ipfs/16Uiu2HAmBXoNLLMYU9EcKYH6JN5tA498sXQHFWk4heK22RfXD7wC",
+			"/ip4/54.39.179.73/tcp/3919/ipfs/16Uiu2HAkyYtzNoWuF3ULaA7RMfVAxvfQQ9YRvRT3TK4tXmuZtaWi",
+			"/ip4/54.39.186.166/tcp/3919/ipfs/16Uiu2HAkzD5n4mtTSddzqVY3wPJZmtvWjARTSpr4JbDX9n9PDJRh",
+			"/ip4/54.39.179.134/tcp/3919/ipfs/16Uiu2HAkuxCuWA4zXnsj9R6A3b3a1TKUjQvBpAEaJ98KGdGue67p",
+		},
+	},
+	"testnet": {
+		Description:              "Ethereum Ropsten testnet",
+		URL:                      "ws://127.0.0.1:8546",
+		URLRPC:                   "http://127.0.0.1:8545",
+		KeepRandomBeaconOperator: "0x7728660fC0C8a48986f902Cb41C98931cd32C7B0",
+		TokenStaking:             "0xF63bF51797377Af850CBBdd51Ed8f3D238ea907D",
+		KeepRandomBeaconService:  "0x50BD3A8E3b1749E82A3C604db6559cab9C6232bD",
+		Peers: []string{
+			"/dns4/bootstrap-1.core.keep.test.boar.network/tcp/3001/ipfs/16Uiu2HAkuTUKNh6HkfvWBEkftZbqZHPHi3Kak5ZUygAxvsdQ2UgG",
+			"/dns4/bootstrap-3.test.keep.network/tcp/3919/ipfs/16Uiu2HAm8KJX32kr3eYUhDuzwTucSfAfspnjnXNf9veVhB12t6Vf",
+			"/dns4/bootstrap-2.test.keep.network/tcp/3919/ipfs/16Uiu2HAmNNuCp45z5bgB8KiTHv1vHTNAVbBgxxtTFGAndageo9Dp",
+		},
+	},
+	"local": {
+		Description: "local development chain; fill in addresses of " +
+			"contracts deployed with the migrations",
+		URL:                      "ws://127.0.0.1:8546",
+		URLRPC:                   "http://127.0.0.1:8545",
+		KeepRandomBeaconOperator: "0x0000000000000000000000000000000000000000",
+		TokenStaking:             "0x0000000000000000000000000000000000000000",
+		KeepRandomBeaconService:  "0x0000000000000000000000000000000000000000",
+	},
+}
+
+// Networks returns names of networks for which a config file template can be
+// written.
+func Networks() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// WriteTemplate writes an annotated config file template for the network
+// with the given name.
+func WriteTemplate(writer io.Writer, networkName string) error {
+	network, ok := networks[networkName]
+	if !ok {
+		return fmt.Errorf(
+			"unknown network [%v]; supported networks are %v",
+			networkName,
+			Networks(),
+		)
+	}
+
+	return configTemplate.Execute(writer, network)
+}
+
+var configTemplate = template.Must(template.New("config").Parse(
+	`# Keep client configuration for {{.Description}}.
+#
+# Fill in the values marked as required and run the "config validate" command
+# to check the configuration before starting the client.
+
+[ethereum]
+	# WebSocket or IPC endpoint of the Ethereum node. Required.
+	URL = "{{.URL}}"
+	# HTTP endpoint of the Ethereum node.
+	URLRPC = "{{.URLRPC}}"
+	#
+	# Uncomment to override the maximum gas price the client is willing to
+	# pay for a transaction to be mined.
+	# MaxGasPrice = "500 Gwei"
+	#
+	# Uncomment to override the minimum operator account balance below
+	# which the client reports errors in logs.
+	# BalanceAlertThreshold = "0.5 ether"
+
+[ethereum.account]
+	# Path to the encrypted operator key file. Required. The key file password
+	# is read from the KEEP_ETHEREUM_PASSWORD environment variable.
+	KeyFile = "/path/to/keystore/key-file"
+
+[ethereum.ContractAddresses]
+	# Hex-encoded address of KeepRandomBeaconOperator contract. Required.
+	KeepRandomBeaconOperator = "{{.KeepRandomBeaconOperator}}"
+	# Hex-encoded address of TokenStaking contract. Required.
+	TokenStaking = "{{.TokenStaking}}"
+	# Hex-encoded address of KeepRandomBeaconService contract. Only needed
+	# in cases where the client's utility functions will be used (e.g., the
+	# relay subcommand).
+	KeepRandomBeaconService = "{{.KeepRandomBeaconService}}"
+
+[LibP2P]
+	# Multiaddrs of bootstrap peers including their peer IDs.
+	Peers = [{{range $index, $peer := .Peers}}{{if $index}},{{end}}
+		"{{$peer}}"{{end}}
+	]
+	# Port on which the client accepts connections. Required.
+	Port = 3919
+	#
+	# Uncomment to override the node's default addresses announced in the
+	# network.
+	# AnnouncedAddresses = ["/dns4/example.com/tcp/3919", "/ip4/80.70.60.50/tcp/3919"]
+
+[Storage]
+	# Directory in which the encrypted group memberships are stored.
+	# Required. The directory must be persistent and writable.
+	DataDir = "/path/to/data/directory"
+
+# Uncomment to read the key file password from a file, such as a Docker or
+# Kubernetes secret mount, instead of the KEEP_ETHEREUM_PASSWORD environment
+# variable. Alternatively, set Command to a command printing the password, for
+# example ["pass", "show", "keep/operator"], or URL to an HTTP secret store
+# endpoint along with TokenFile holding the bearer token and Field with the
+# dot-separated path to the password in the JSON response. The password used
+# to encrypt the data stored on disk can be configured the same way in the
+# Secrets.StoragePassword section; it defaults to the key file password.
+# [Secrets.KeyFilePassword]
+	# File = "/run/secrets/keep-operator-password"
+
+# Uncomment to expose metrics on the /metrics endpoint.
+# [Metrics]
+	# Port = 8080
+	# NetworkMetricsTick = 60
+	# EthereumMetricsTick = 600
+
+# Uncomment to expose diagnostics on the /diagnostics endpoint.
+# [Diagnostics]
+	# Port = 8081
+
+# Uncomment to enable the admin API used by the "admin" command.
+# [Admin]
+	# Port = 9701
+
+# Uncomment to set log levels; the LOG_LEVEL environment variable takes
+# precedence. Can be changed without a restart by sending SIGHUP.
+# [Logging]
+	# Level = "info keep*=debug"
+
+# Uncomment to delegate signing of transactions and messages to an external
+# signer holding the operator key, such as Clef, instead of reading the key
+# file. Endpoint is the path to the signer unix socket or its HTTP URL. The
+# Ethereum account section is then not used and the storage password has to
+# be configured in the Secrets.StoragePassword section or set with the
+# KEEP_ETHEREUM_PASSWORD environment variable.
+# [Signer]
+	# Endpoint = "/path/to/signer.ipc"
+	# Address = "0x0000000000000000000000000000000000000000"
+
+# Uncomment to run multiple operators in one client process; add one section
+# per operator. In this mode, the Ethereum account, the LibP2P port and
+# announced addresses, and the storage directory above are not used. The
+# account password, or Secrets.KeyFilePassword, is used for operators without
+# their own KeyFilePassword source.
+# [[Operators]]
+	# KeyFile = "/path/to/first/operator/key/file"
+	# DataDir = "/path/to/first/operator/data/directory"
+	# Port = 3919
+# [[Operators]]
+	# KeyFile = "/path/to/second/operator/key/file"
+	# DataDir = "/path/to/second/operator/data/directory"
+	# Port = 3920
+	# [Operators.KeyFilePassword]
+		# File = "/run/secrets/second-operator-password"
+`))
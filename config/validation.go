@@ -0,0 +1,312 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// RequiredContracts lists names of contracts which addresses have to be
+// present in the Ethereum.ContractAddresses section of the config file.
+var RequiredContracts = []string{
+	"KeepRandomBeaconOperator",
+	"TokenStaking",
+}
+
+// Validate reads in the configuration file at `filePath` and checks it for
+// all the problems which can be detected without connecting to the chain.
+// Unlike ReadConfig, it does not stop on the first problem but returns all of
+// them. It does not require the account password. The returned config is nil
+// only if the file could not be decoded.
+func Validate(filePath string) (*Config, []error) {
+	config := &Config{}
+	metadata, err := decodeFile(filePath, config)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	if err := applyEnvironment(config, os.Environ()); err != nil {
+		return nil, []error{err}
+	}
+
+	var problems []error
+	problemf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	for _, key := range metadata.Undecoded() {
+		problemf("unknown configuration key [%v]", key)
+	}
+
+	validateEthereum(config, problemf)
+	validateLibP2P(config, problemf)
+	validatePorts(config, problemf)
+	validateStorage(config, problemf)
+	validateSecrets(config, problemf)
+	validateOperators(config, problemf)
+	validateSigner(config, problemf)
+
+	if config.Admin.Port != 0 && config.Admin.Socket != "" {
+		problemf("admin port and admin socket can not be set at the same time")
+	}
+
+	return config, problems
+}
+
+func validateEthereum(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	if config.Ethereum.URL == "" {
+		problemf("missing value for Ethereum.URL")
+	} else if !isWebSocketOrIPC(config.Ethereum.URL) {
+		problemf(
+			"Ethereum.URL [%v] must be a WebSocket (ws://, wss://) URL "+
+				"or an IPC endpoint path",
+			config.Ethereum.URL,
+		)
+	}
+
+	if config.Ethereum.URLRPC != "" {
+		rpcURL, err := url.Parse(config.Ethereum.URLRPC)
+		if err != nil || (rpcURL.Scheme != "http" && rpcURL.Scheme != "https") {
+			problemf(
+				"Ethereum.URLRPC [%v] must be an HTTP (http://, https://) URL",
+				config.Ethereum.URLRPC,
+			)
+		}
+	}
+
+	// In the multi-operator mode, key files are configured per operator and
+	// with the external signer there is no key file.
+	if !config.IsMultiOperator() && !config.UsesExternalSigner() {
+		if config.Ethereum.Account.KeyFile == "" {
+			problemf("missing value for Ethereum.Account.KeyFile")
+		} else if _, err := os.Stat(config.Ethereum.Account.KeyFile); err != nil {
+			problemf("could not access key file: [%v]", err)
+		}
+	}
+
+	for _, contractName := range RequiredContracts {
+		if _, ok := config.Ethereum.ContractAddresses[contractName]; !ok {
+			problemf(
+				"missing address of [%v] in Ethereum.ContractAddresses",
+				contractName,
+			)
+		}
+	}
+
+	contractNames := make([]string, 0, len(config.Ethereum.ContractAddresses))
+	for contractName := range config.Ethereum.ContractAddresses {
+		contractNames = append(contractNames, contractName)
+	}
+	sort.Strings(contractNames)
+
+	for _, contractName := range contractNames {
+		address := config.Ethereum.ContractAddresses[contractName]
+		if !common.IsHexAddress(address) {
+			problemf(
+				"address [%v] of [%v] is not a valid hex address",
+				address,
+				contractName,
+			)
+		}
+	}
+}
+
+func isWebSocketOrIPC(endpoint string) bool {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+
+	switch endpointURL.Scheme {
+	case "ws", "wss":
+		return true
+	case "":
+		return strings.HasSuffix(endpoint, ".ipc")
+	default:
+		return false
+	}
+}
+
+func validateLibP2P(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	for _, address := range config.LibP2P.Peers {
+		multiaddress, err := multiaddr.NewMultiaddr(address)
+		if err != nil {
+			problemf("peer [%v] is not a valid multiaddr: [%v]", address, err)
+			continue
+		}
+
+		if _, err := peer.AddrInfoFromP2pAddr(multiaddress); err != nil {
+			problemf(
+				"peer [%v] does not contain a valid peer ID: [%v]",
+				address,
+				err,
+			)
+		}
+	}
+
+	for _, address := range config.LibP2P.AnnouncedAddresses {
+		if _, err := multiaddr.NewMultiaddr(address); err != nil {
+			problemf(
+				"announced address [%v] is not a valid multiaddr: [%v]",
+				address,
+				err,
+			)
+		}
+	}
+}
+
+func validatePorts(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	type namedPort struct {
+		name  string
+		value int
+	}
+
+	ports := []namedPort{
+		{"Metrics.Port", config.Metrics.Port},
+		{"Diagnostics.Port", config.Diagnostics.Port},
+		{"Admin.Port", config.Admin.Port},
+	}
+
+	if config.IsMultiOperator() {
+		for i, operator := range config.Operators {
+			ports = append(ports, namedPort{
+				fmt.Sprintf("Operators[%v].Port", i),
+				operator.Port,
+			})
+		}
+	} else {
+		if config.LibP2P.Port == 0 {
+			problemf("missing value for LibP2P.Port")
+		}
+
+		ports = append([]namedPort{{"LibP2P.Port", config.LibP2P.Port}}, ports...)
+	}
+
+	usedPorts := make(map[int]string)
+	for _, port := range ports {
+		if port.value == 0 {
+			continue
+		}
+
+		if port.value < 0 || port.value > 65535 {
+			problemf("%v [%v] is out of range", port.name, port.value)
+			continue
+		}
+
+		if otherName, ok := usedPorts[port.value]; ok {
+			problemf(
+				"%v and %v use the same port [%v]",
+				otherName,
+				port.name,
+				port.value,
+			)
+			continue
+		}
+
+		usedPorts[port.value] = port.name
+	}
+}
+
+func validateStorage(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	// In the multi-operator mode, data directories are configured per
+	// operator.
+	if config.IsMultiOperator() {
+		return
+	}
+
+	validateDataDir("Storage.DataDir", config.Storage.DataDir, problemf)
+}
+
+func validateDataDir(
+	name string,
+	dataDir string,
+	problemf func(format string, args ...interface{}),
+) {
+	if dataDir == "" {
+		problemf("missing value for %v", name)
+		return
+	}
+
+	info, err := os.Stat(dataDir)
+	if err != nil {
+		problemf("could not access data directory: [%v]", err)
+		return
+	}
+
+	if !info.IsDir() {
+		problemf(
+			"data directory [%v] is not a directory",
+			dataDir,
+		)
+		return
+	}
+
+	file, err := ioutil.TempFile(dataDir, ".write-test")
+	if err != nil {
+		problemf("data directory is not writable: [%v]", err)
+		return
+	}
+
+	file.Close()
+	os.Remove(file.Name())
+}
+
+func validateSecrets(
+	config *Config,
+	problemf func(format string, args ...interface{}),
+) {
+	secrets := []struct {
+		name   string
+		source *Secret
+	}{
+		{"Secrets.KeyFilePassword", &config.Secrets.KeyFilePassword},
+		{"Secrets.StoragePassword", &config.Secrets.StoragePassword},
+	}
+
+	for _, secret := range secrets {
+		if !secret.source.IsSet() {
+			continue
+		}
+
+		validateSecret(secret.name, secret.source, problemf)
+	}
+}
+
+func validateSecret(
+	name string,
+	source *Secret,
+	problemf func(format string, args ...interface{}),
+) {
+	if _, err := source.Provider(); err != nil {
+		problemf("invalid source of %v: [%v]", name, err)
+		return
+	}
+
+	for _, path := range []string{source.File, source.TokenFile} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			problemf("could not access file of %v: [%v]", name, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config-validation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	configFile := filepath.Join(directory, "config.toml")
+	err = ioutil.WriteFile(configFile, []byte(`
+[ethereum]
+	URL = "http://127.0.0.1:8545"
+
+[ethereum.account]
+	KeyFile = "/non/existing/key-file"
+
+[ethereum.ContractAddresses]
+	KeepRandomBeaconOperator = "0xinvalid"
+
+[LibP2P]
+	Peers = ["/ip4/127.0.0.1/tcp/3919", "not-a-multiaddr"]
+	Port = 8080
+
+[Storage]
+	DataDir = "`+directory+`"
+
+[Metrics]
+	Port = 8080
+	Tick = 10
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, problems := Validate(configFile)
+	if config == nil {
+		t.Fatal("config should be decoded")
+	}
+
+	expectedProblems := []string{
+		"unknown configuration key [Metrics.Tick]",
+		"Ethereum.URL [http://127.0.0.1:8545] must be a WebSocket",
+		"could not access key file",
+		"missing address of [TokenStaking]",
+		"address [0xinvalid] of [KeepRandomBeaconOperator] is not a valid hex address",
+		"peer [/ip4/127.0.0.1/tcp/3919] does not contain a valid peer ID",
+		"peer [not-a-multiaddr] is not a valid multiaddr",
+		"LibP2P.Port and Metrics.Port use the same port [8080]",
+	}
+
+	if len(problems) != len(expectedProblems) {
+		t.Errorf(
+			"unexpected number of problems\nexpected: [%v]\nactual:   [%v]",
+			len(expectedProblems),
+			problems,
+		)
+	}
+
+	for _, expectedProblem := range expectedProblems {
+		found := false
+		for _, problem := range problems {
+			if strings.Contains(problem.Error(), expectedProblem) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			t.Errorf("expected problem [%v] not reported", expectedProblem)
+		}
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	for _, network := range Networks() {
+		t.Run(network, func(t *testing.T) {
+			template := &bytes.Buffer{}
+			if err := WriteTemplate(template, network); err != nil {
+				t.Fatal(err)
+			}
+
+			configFile := filepath.Join(directory, network+".toml")
+			err := ioutil.WriteFile(configFile, template.Bytes(), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config, problems := Validate(configFile)
+			if config == nil {
+				t.Fatalf("template could not be decoded: [%v]", problems)
+			}
+
+			// The template contains placeholders for the key file and the
+			// data directory; all the other values should be valid.
+			if len(problems) != 2 {
+				t.Errorf("unexpected problems: [%v]", problems)
+			}
+		})
+	}
+}
+
+func TestWriteTemplateUnknownNetwork(t *testing.T) {
+	err := WriteTemplate(&bytes.Buffer{}, "unknown")
+	if err == nil {
+		t.Fatal("expected error for unknown network")
+	}
+}
@@ -29,4 +29,6 @@ require (
 	github.com/multiformats/go-multiaddr v0.2.2
 	github.com/urfave/cli v1.22.1
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	golang.org/x/tools v0.0.0-20210106214847-113979e3529a
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -60,8 +60,18 @@ func main() {
 	app.Commands = []cli.Command{
 		cmd.StartCommand,
 		cmd.RelayCommand,
-		cmd.PingCommand,
 		cmd.EthereumCommand,
+		cmd.AdminCommand,
+		cmd.GroupsCommand,
+		cmd.BeaconCommand,
+		cmd.KeysCommand,
+		cmd.ConfigCommand,
+		cmd.NetCommand,
+		cmd.DKGCommand,
+		cmd.SignerCommand,
+		cmd.BootstrapCommand,
+		cmd.SupportBundleCommand,
+		cmd.LedgerCommand,
 	}
 
 	cli.AppHelpTemplate = fmt.Sprintf(`%s
@@ -0,0 +1,252 @@
+// Package admin provides the admin control API allowing the operator to
+// inspect and adjust the state of a running client without restarting it.
+//
+// The API is exposed over HTTP, either on a localhost port or on a unix
+// socket, and every request must be authenticated with a token. The token is
+// generated when the client starts and is written to a cookie file readable
+// only by the client's user. The admin CLI reads the token from that file.
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-admin")
+
+// CookieFileName is the name of the file, placed in the client's data
+// directory, holding the token authenticating admin API requests.
+const CookieFileName = "admin.cookie"
+
+const (
+	tokenLength           = 32
+	serverShutdownTimeout = 5 * time.Second
+)
+
+// Handler handles a single admin API request. The returned result is
+// serialized to JSON and written to the response.
+type Handler func(request *http.Request) (interface{}, error)
+
+// badRequestError is returned by handlers when the request is invalid.
+type badRequestError struct {
+	message string
+}
+
+func (bre *badRequestError) Error() string {
+	return bre.message
+}
+
+func newBadRequestError(format string, args ...interface{}) error {
+	return &badRequestError{fmt.Sprintf(format, args...)}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves the admin control API.
+type Server struct {
+	mux   *http.ServeMux
+	token string
+	// prefix is prepended to paths of handlers registered with the server.
+	prefix string
+}
+
+// Initialize sets up the admin control API server listening on the given
+// localhost port or on the given unix socket and writes the authentication
+// token to the cookie file at the given path. It returns false if neither
+// port nor socket is configured. The server is shut down and the cookie file
+// is removed when the provided context is done.
+func Initialize(
+	ctx context.Context,
+	port int,
+	socket string,
+	cookiePath string,
+) (*Server, bool, error) {
+	if port == 0 && socket == "" {
+		return nil, false, nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, false, fmt.Errorf("could not generate token: [%v]", err)
+	}
+
+	listener, err := listen(port, socket)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := ioutil.WriteFile(cookiePath, []byte(token), 0600); err != nil {
+		_ = listener.Close()
+		return nil, false, fmt.Errorf(
+			"could not write cookie file [%v]: [%v]",
+			cookiePath,
+			err,
+		)
+	}
+
+	server := &Server{
+		mux:   http.NewServeMux(),
+		token: token,
+	}
+
+	httpServer := &http.Server{Handler: server}
+
+	go func() {
+		if err := httpServer.Serve(listener); err != http.ErrServerClosed {
+			logger.Errorf("admin server error: [%v]", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancelShutdownCtx := context.WithTimeout(
+			context.Background(),
+			serverShutdownTimeout,
+		)
+		defer cancelShutdownCtx()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warningf("could not shut down admin server: [%v]", err)
+		}
+
+		if err := os.Remove(cookiePath); err != nil {
+			logger.Warningf("could not remove cookie file: [%v]", err)
+		}
+	}()
+
+	return server, true, nil
+}
+
+// ForOperator returns the server registering handlers of the operator with
+// the given address, when the client runs multiple operators. Handlers of
+// the operator are served under OperatorsPath followed by the lower-case
+// operator address.
+func (s *Server) ForOperator(address string) *Server {
+	return &Server{
+		mux:    s.mux,
+		token:  s.token,
+		prefix: OperatorPath(address),
+	}
+}
+
+// RegisterHandler registers the handler for requests with the given method
+// and path.
+func (s *Server) RegisterHandler(method string, path string, handler Handler) {
+	s.mux.HandleFunc(s.prefix+path, func(
+		response http.ResponseWriter,
+		request *http.Request,
+	) {
+		if request.Method != method {
+			writeResponse(
+				response,
+				http.StatusMethodNotAllowed,
+				&errorResponse{fmt.Sprintf("method %v not allowed", request.Method)},
+			)
+			return
+		}
+
+		result, err := handler(request)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if _, ok := err.(*badRequestError); ok {
+				status = http.StatusBadRequest
+			}
+
+			writeResponse(response, status, &errorResponse{err.Error()})
+			return
+		}
+
+		writeResponse(response, http.StatusOK, result)
+	})
+}
+
+// ServeHTTP authenticates the request and dispatches it to the registered
+// handler.
+func (s *Server) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		writeResponse(
+			response,
+			http.StatusUnauthorized,
+			&errorResponse{"invalid authentication token"},
+		)
+		return
+	}
+
+	s.mux.ServeHTTP(response, request)
+}
+
+func writeResponse(
+	response http.ResponseWriter,
+	status int,
+	result interface{},
+) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
+
+	if err := json.NewEncoder(response).Encode(result); err != nil {
+		logger.Errorf("could not write response: [%v]", err)
+	}
+}
+
+func listen(port int, socket string) (net.Listener, error) {
+	if socket == "" {
+		return net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	}
+
+	// Remove the socket file possibly left by the previous run. Refuse to
+	// remove anything that is not a socket.
+	if info, err := os.Stat(socket); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("[%v] exists and is not a socket", socket)
+		}
+		if err := os.Remove(socket); err != nil {
+			return nil, fmt.Errorf(
+				"could not remove stale socket [%v]: [%v]",
+				socket,
+				err,
+			)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(socket), 0700); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(socket, 0600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+func generateToken() (string, error) {
+	tokenBytes := make([]byte, tokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(tokenBytes), nil
+}
@@ -0,0 +1,300 @@
+package admin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/reliability"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func initializeTestServer(t *testing.T) (*Server, *Client, func()) {
+	directory, err := ioutil.TempDir("", "admin-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	socket := filepath.Join(directory, "admin.sock")
+	cookiePath := filepath.Join(directory, CookieFileName)
+
+	server, isConfigured, err := Initialize(ctx, 0, socket, cookiePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isConfigured {
+		t.Fatal("admin server should be configured")
+	}
+
+	client, err := NewClient(0, socket, cookiePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client, func() {
+		cancelCtx()
+		os.RemoveAll(directory)
+	}
+}
+
+func TestInitializeNotConfigured(t *testing.T) {
+	_, isConfigured, err := Initialize(context.Background(), 0, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isConfigured {
+		t.Fatal("admin server should not be configured")
+	}
+}
+
+func TestRejectsInvalidToken(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	RegisterLogLevelHandler(server)
+
+	client.token = "invalid"
+
+	err := client.Post(LogLevelPath, &LogLevelRequest{"info"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected unauthorized error; has: [%v]", err)
+	}
+}
+
+func TestRejectsInvalidMethod(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	RegisterLogLevelHandler(server)
+
+	err := client.Get(LogLevelPath, nil)
+	if err == nil || !strings.Contains(
+		err.Error(),
+		http.StatusText(http.StatusMethodNotAllowed),
+	) {
+		t.Fatalf("expected method not allowed error; has: [%v]", err)
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	RegisterLogLevelHandler(server)
+
+	result := &LogLevelRequest{}
+	err := client.Post(LogLevelPath, &LogLevelRequest{"keep*=debug"}, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Directives != "keep*=debug" {
+		t.Errorf("unexpected directives: [%v]", result.Directives)
+	}
+
+	err = client.Post(LogLevelPath, &LogLevelRequest{"keep*=loud"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("expected bad request error; has: [%v]", err)
+	}
+}
+
+func TestGoroutines(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	RegisterGoroutinesHandler(server)
+
+	result := &Goroutines{}
+	if err := client.Get(GoroutinesPath, result); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(result.Dump, "TestGoroutines") {
+		t.Errorf("expected test goroutine in dump:\n%v", result.Dump)
+	}
+}
+
+func TestDisconnectAndBanPeer(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	_, peerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerAddress := key.NetworkPubKeyToChainAddress(peerPublicKey)
+
+	provider := local.Connect()
+	provider.AddPeer("peer-1", peerPublicKey)
+
+	banList := firewall.NewBanList(firewall.Disabled)
+
+	RegisterPeersHandlers(server, provider.ConnectionManager(), banList)
+
+	peers := &Peers{}
+	if err := client.Get(PeersPath, peers); err != nil {
+		t.Fatal(err)
+	}
+	if len(peers.Connected) != 1 ||
+		peers.Connected[0].EthereumAddress != peerAddress {
+		t.Fatalf("unexpected connected peers: [%+v]", peers.Connected)
+	}
+
+	err = client.Post(
+		DisconnectPeerPath,
+		&DisconnectPeerRequest{NetworkID: "peer-1", Ban: true},
+		peers,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers.Connected) != 0 {
+		t.Errorf("peer should be disconnected: [%+v]", peers.Connected)
+	}
+	if !banList.IsBanned(peerAddress) {
+		t.Errorf("peer should be banned")
+	}
+
+	err = client.Post(
+		UnbanPeerPath,
+		&UnbanPeerRequest{EthereumAddress: peerAddress},
+		peers,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers.Banned) != 0 || banList.IsBanned(peerAddress) {
+		t.Errorf("peer should not be banned: [%+v]", peers.Banned)
+	}
+}
+
+func TestReliability(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	tracker := reliability.NewTracker([]byte{0x01})
+	tracker.ObserveSigning(
+		[]relaychain.StakerAddress{{0x01}, {0x02}, {0x03}},
+		map[group.MemberIndex]uint64{1: 1, 2: 1},
+	)
+
+	RegisterReliabilityHandler(server, tracker)
+
+	operators := make([]OperatorReliability, 0)
+	if err := client.Get(ReliabilityPath, &operators); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(operators) != 2 {
+		t.Fatalf("unexpected number of operators: [%v]", len(operators))
+	}
+	if operators[0].Address != "0x03" || !operators[0].Unreliable {
+		t.Errorf("unexpected least reliable operator: [%+v]", operators[0])
+	}
+	if operators[1].Address != "0x02" || operators[1].ShareDeliveryRate != 1 {
+		t.Errorf("unexpected most reliable operator: [%+v]", operators[1])
+	}
+}
+
+func TestOperatorHandlers(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	addresses := []string{"0xAa01", "0xBb02"}
+	RegisterOperatorsHandler(server, addresses)
+
+	for i, address := range addresses {
+		tracker := reliability.NewTracker([]byte{0x01})
+		tracker.ObserveSigning(
+			[]relaychain.StakerAddress{{0x01}, {byte(0x02 + i)}},
+			map[group.MemberIndex]uint64{1: 1, 2: 1},
+		)
+
+		RegisterReliabilityHandler(server.ForOperator(address), tracker)
+	}
+
+	operatorAddresses := make([]string, 0)
+	if err := client.Get(OperatorsPath, &operatorAddresses); err != nil {
+		t.Fatal(err)
+	}
+	if len(operatorAddresses) != 2 || operatorAddresses[1] != "0xBb02" {
+		t.Fatalf("unexpected operators: [%v]", operatorAddresses)
+	}
+
+	for i, address := range addresses {
+		operators := make([]OperatorReliability, 0)
+		err := client.ForOperator(address).Get(ReliabilityPath, &operators)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedAddress := fmt.Sprintf("0x%02x", 0x02+i)
+		if len(operators) != 1 || operators[0].Address != expectedAddress {
+			t.Errorf(
+				"unexpected operators of [%v]: [%+v]",
+				address,
+				operators,
+			)
+		}
+	}
+
+	err := client.Get(ReliabilityPath, nil)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected not found error; has: [%v]", err)
+	}
+}
+
+func TestPingPeerRejectsInvalidRequest(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	RegisterPingPeerHandler(server, local.Connect(), firewall.Disabled)
+
+	for _, request := range []*PingPeerRequest{
+		{NetworkID: "not-a-peer-id", Count: 1},
+		{NetworkID: "16Uiu2HAkzYFHsqbwt64ZztWWK1hyeLntRNqWMYFiZjaKu1PZgikN"},
+	} {
+		err := client.Post(PingPeerPath, request, nil)
+		if err == nil || !strings.Contains(err.Error(), "400") {
+			t.Errorf("expected bad request error; has: [%v]", err)
+		}
+	}
+}
+
+func TestPingPeerReportsMinimumStakePolicy(t *testing.T) {
+	server, client, cleanup := initializeTestServer(t)
+	defer cleanup()
+
+	RegisterPingPeerHandler(server, local.Connect(), &rejectingFirewall{})
+
+	err := client.Post(
+		PingPeerPath,
+		&PingPeerRequest{
+			NetworkID: "16Uiu2HAkzYFHsqbwt64ZztWWK1hyeLntRNqWMYFiZjaKu1PZgikN",
+			Count:     1,
+			Timeout:   time.Millisecond,
+		},
+		&PingResult{},
+	)
+	if err == nil || !strings.Contains(err.Error(), "no minimum stake") {
+		t.Errorf("expected minimum stake policy error; has: [%v]", err)
+	}
+}
+
+type rejectingFirewall struct{}
+
+func (rf *rejectingFirewall) Validate(*ecdsa.PublicKey) error {
+	return fmt.Errorf("no minimum stake")
+}
@@ -0,0 +1,150 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const clientTimeout = 30 * time.Second
+
+// Client talks to the admin control API of a running client.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	// pathPrefix is prepended to paths of requests sent by the client.
+	pathPrefix string
+}
+
+// NewClient creates a client of the admin control API exposed on the given
+// localhost port or unix socket. The authentication token is read from the
+// cookie file at the given path.
+func NewClient(port int, socket string, cookiePath string) (*Client, error) {
+	if port == 0 && socket == "" {
+		return nil, fmt.Errorf("admin API is not configured")
+	}
+
+	token, err := ioutil.ReadFile(cookiePath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read cookie file [%v]; is the client running?: [%v]",
+			cookiePath,
+			err,
+		)
+	}
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: clientTimeout},
+		baseURL:    fmt.Sprintf("http://127.0.0.1:%d", port),
+		token:      strings.TrimSpace(string(token)),
+	}
+
+	if socket != "" {
+		client.baseURL = "http://unix"
+		client.httpClient.Transport = &http.Transport{
+			DialContext: func(
+				ctx context.Context,
+				_, _ string,
+			) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		}
+	}
+
+	return client, nil
+}
+
+// ForOperator returns the client sending requests to handlers of the operator
+// with the given address, when the client runs multiple operators.
+func (c *Client) ForOperator(address string) *Client {
+	return &Client{
+		httpClient: c.httpClient,
+		baseURL:    c.baseURL,
+		token:      c.token,
+		pathPrefix: OperatorPath(address),
+	}
+}
+
+// WithRequestDuration returns the client for requests whose handlers take
+// the given time to complete, such as pinging a peer. The regular timeout of
+// the client applies on top of that time.
+func (c *Client) WithRequestDuration(duration time.Duration) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout:   duration + clientTimeout,
+			Transport: c.httpClient.Transport,
+		},
+		baseURL:    c.baseURL,
+		token:      c.token,
+		pathPrefix: c.pathPrefix,
+	}
+}
+
+// Get sends a GET request to the given path and decodes the response into
+// the result.
+func (c *Client) Get(path string, result interface{}) error {
+	return c.do(http.MethodGet, path, nil, result)
+}
+
+// Post sends a POST request with the given body to the given path and
+// decodes the response into the result.
+func (c *Client) Post(path string, body interface{}, result interface{}) error {
+	return c.do(http.MethodPost, path, body, result)
+}
+
+func (c *Client) do(
+	method string,
+	path string,
+	body interface{},
+	result interface{},
+) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequest(method, c.baseURL+c.pathPrefix+path, requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("admin API request failed: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		errorResponse := &errorResponse{}
+		if err := json.NewDecoder(response.Body).Decode(errorResponse); err != nil {
+			return fmt.Errorf("admin API responded with [%v]", response.Status)
+		}
+
+		return fmt.Errorf(
+			"admin API responded with [%v]: [%v]",
+			response.Status,
+			errorResponse.Error,
+		)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
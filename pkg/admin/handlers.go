@@ -0,0 +1,499 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/logging"
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/reliability"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/ping"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// Paths of the admin API endpoints.
+const (
+	GroupsPath           = "/groups"
+	SweepStaleGroupsPath = "/groups/sweep-stale"
+	SessionsPath         = "/sessions"
+	ReliabilityPath      = "/reliability"
+	LogLevelPath         = "/log-level"
+	PeersPath            = "/peers"
+	DisconnectPeerPath   = "/peers/disconnect"
+	UnbanPeerPath        = "/peers/unban"
+	PingPeerPath         = "/peers/ping"
+	GoroutinesPath       = "/debug/goroutines"
+	OperatorsPath        = "/operators"
+)
+
+// OperatorPath returns the path under which handlers of the operator with
+// the given address are served when the client runs multiple operators.
+func OperatorPath(address string) string {
+	return OperatorsPath + "/" + strings.ToLower(address)
+}
+
+// Group describes a group the client is a member of.
+type Group struct {
+	PublicKey     string  `json:"publicKey"`
+	ChannelName   string  `json:"channelName"`
+	MemberIndexes []uint8 `json:"memberIndexes"`
+}
+
+// Session describes a protocol session the client is currently executing.
+type Session struct {
+	Type           string    `json:"type"`
+	MemberIndex    uint8     `json:"memberIndex"`
+	Seed           string    `json:"seed,omitempty"`
+	GroupPublicKey string    `json:"groupPublicKey,omitempty"`
+	StartBlock     uint64    `json:"startBlock"`
+	StartedAt      time.Time `json:"startedAt"`
+}
+
+// OperatorReliability describes the behaviour of another operator observed
+// by the client in the most recent DKG and relay entry signing sessions.
+type OperatorReliability struct {
+	Address                   string  `json:"address"`
+	SigningSessions           int     `json:"signingSessions"`
+	SharesExpected            int     `json:"sharesExpected"`
+	SharesDelivered           int     `json:"sharesDelivered"`
+	ShareDeliveryRate         float64 `json:"shareDeliveryRate"`
+	AverageShareLatencyBlocks float64 `json:"averageShareLatencyBlocks"`
+	DKGSessions               int     `json:"dkgSessions"`
+	DKGInactiveMembers        int     `json:"dkgInactiveMembers"`
+	DKGDisqualifiedMembers    int     `json:"dkgDisqualifiedMembers"`
+	Unreliable                bool    `json:"unreliable"`
+}
+
+// LogLevelRequest sets the log levels using the same level directives as
+// the LOG_LEVEL environment variable.
+type LogLevelRequest struct {
+	Directives string `json:"directives"`
+}
+
+// Peers describes peers the client is connected to and chain addresses of
+// peers which have been banned.
+type Peers struct {
+	Connected []Peer   `json:"connected"`
+	Banned    []string `json:"banned"`
+}
+
+// Peer describes a single connected peer.
+type Peer struct {
+	NetworkID       string `json:"networkId"`
+	EthereumAddress string `json:"ethereumAddress"`
+}
+
+// DisconnectPeerRequest disconnects the peer with the given network ID and,
+// optionally, bans its chain address.
+type DisconnectPeerRequest struct {
+	NetworkID string `json:"networkId"`
+	Ban       bool   `json:"ban"`
+}
+
+// UnbanPeerRequest removes the given chain address from the ban list.
+type UnbanPeerRequest struct {
+	EthereumAddress string `json:"ethereumAddress"`
+}
+
+// PingPeerRequest sends count probes to the peer with the given network ID,
+// one every interval. A probe not answered within the timeout is lost.
+type PingPeerRequest struct {
+	NetworkID string        `json:"networkId"`
+	Count     int           `json:"count"`
+	Interval  time.Duration `json:"interval"`
+	Timeout   time.Duration `json:"timeout"`
+}
+
+// Duration returns the maximum time the peer is probed for.
+func (ppr *PingPeerRequest) Duration() time.Duration {
+	return time.Duration(ppr.Count-1)*ppr.Interval + ppr.Timeout
+}
+
+// PingResult holds the outcome of probing the peer. Round-trip times are
+// listed only for the answered probes. The minimum stake policy error is
+// empty if the peer passes the policy of the running client.
+type PingResult struct {
+	Sent                    int             `json:"sent"`
+	Received                int             `json:"received"`
+	RoundTripTimes          []time.Duration `json:"roundTripTimes"`
+	MinimumStakePolicyError string          `json:"minimumStakePolicyError,omitempty"`
+}
+
+// Goroutines holds the stack traces of all the goroutines of the client.
+type Goroutines struct {
+	Dump string `json:"dump"`
+}
+
+// RegisterOperatorsHandler registers the handler listing addresses of the
+// operators run by the client. Handlers of each of the operators are served
+// under OperatorPath of the operator address.
+func RegisterOperatorsHandler(server *Server, addresses []string) {
+	server.RegisterHandler(
+		http.MethodGet,
+		OperatorsPath,
+		func(_ *http.Request) (interface{}, error) {
+			return addresses, nil
+		},
+	)
+}
+
+// RegisterGroupsHandlers registers handlers listing groups from the registry
+// and sweeping stale groups out of it.
+func RegisterGroupsHandlers(server *Server, groupRegistry *registry.Groups) {
+	server.RegisterHandler(
+		http.MethodGet,
+		GroupsPath,
+		func(_ *http.Request) (interface{}, error) {
+			return listGroups(groupRegistry), nil
+		},
+	)
+
+	server.RegisterHandler(
+		http.MethodPost,
+		SweepStaleGroupsPath,
+		func(_ *http.Request) (interface{}, error) {
+			logger.Infof("sweeping stale groups on admin request")
+			groupRegistry.UnregisterStaleGroups(nil)
+			return listGroups(groupRegistry), nil
+		},
+	)
+}
+
+func listGroups(groupRegistry *registry.Groups) []Group {
+	groups := make([]Group, 0)
+	for _, memberships := range groupRegistry.GetGroups() {
+		if len(memberships) == 0 {
+			continue
+		}
+
+		memberIndexes := make([]uint8, 0, len(memberships))
+		for _, membership := range memberships {
+			memberIndexes = append(
+				memberIndexes,
+				uint8(membership.Signer.MemberID()),
+			)
+		}
+		sort.Slice(memberIndexes, func(i, j int) bool {
+			return memberIndexes[i] < memberIndexes[j]
+		})
+
+		groups = append(groups, Group{
+			PublicKey: hex.EncodeToString(
+				memberships[0].Signer.GroupPublicKeyBytesCompressed(),
+			),
+			ChannelName:   memberships[0].ChannelName,
+			MemberIndexes: memberIndexes,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].PublicKey < groups[j].PublicKey
+	})
+
+	return groups
+}
+
+// RegisterSessionsHandler registers the handler listing DKG and relay entry
+// signing sessions the node is currently executing.
+func RegisterSessionsHandler(server *Server, node *relay.Node) {
+	server.RegisterHandler(
+		http.MethodGet,
+		SessionsPath,
+		func(_ *http.Request) (interface{}, error) {
+			inFlightSessions := node.InFlightSessions()
+
+			sessions := make([]Session, len(inFlightSessions))
+			for i, inFlightSession := range inFlightSessions {
+				sessions[i] = Session{
+					Type:        inFlightSession.Type,
+					MemberIndex: uint8(inFlightSession.MemberIndex),
+					GroupPublicKey: hex.EncodeToString(
+						inFlightSession.GroupPublicKey,
+					),
+					StartBlock: inFlightSession.StartBlock,
+					StartedAt:  inFlightSession.StartedAt,
+				}
+				if inFlightSession.Seed != nil {
+					sessions[i].Seed = inFlightSession.Seed.Text(16)
+				}
+			}
+
+			return sessions, nil
+		},
+	)
+}
+
+// RegisterReliabilityHandler registers the handler listing reliability
+// statistics of other operators observed by the node, the least reliable
+// first.
+func RegisterReliabilityHandler(server *Server, tracker *reliability.Tracker) {
+	server.RegisterHandler(
+		http.MethodGet,
+		ReliabilityPath,
+		func(_ *http.Request) (interface{}, error) {
+			statistics := tracker.Statistics()
+
+			operators := make([]OperatorReliability, len(statistics))
+			for i, operator := range statistics {
+				operators[i] = OperatorReliability{
+					Address:                   operator.Address,
+					SigningSessions:           operator.SigningSessions,
+					SharesExpected:            operator.SharesExpected,
+					SharesDelivered:           operator.SharesDelivered,
+					ShareDeliveryRate:         operator.ShareDeliveryRate,
+					AverageShareLatencyBlocks: operator.AverageShareLatencyBlocks,
+					DKGSessions:               operator.DKGSessions,
+					DKGInactiveMembers:        operator.DKGInactiveMembers,
+					DKGDisqualifiedMembers:    operator.DKGDisqualifiedMembers,
+					Unreliable:                operator.Unreliable,
+				}
+			}
+
+			return operators, nil
+		},
+	)
+}
+
+// RegisterLogLevelHandler registers the handler changing log levels at
+// runtime.
+func RegisterLogLevelHandler(server *Server) {
+	server.RegisterHandler(
+		http.MethodPost,
+		LogLevelPath,
+		func(request *http.Request) (interface{}, error) {
+			logLevelRequest := &LogLevelRequest{}
+			if err := json.NewDecoder(request.Body).Decode(
+				logLevelRequest,
+			); err != nil {
+				return nil, newBadRequestError("invalid request: [%v]", err)
+			}
+
+			if err := logging.Configure(logLevelRequest.Directives); err != nil {
+				return nil, newBadRequestError("%v", err)
+			}
+
+			logger.Infof(
+				"log levels changed on admin request to [%v]",
+				logLevelRequest.Directives,
+			)
+
+			return logLevelRequest, nil
+		},
+	)
+}
+
+// RegisterPeersHandlers registers handlers listing connected peers,
+// disconnecting and banning peers and lifting bans.
+func RegisterPeersHandlers(
+	server *Server,
+	connectionManager net.ConnectionManager,
+	banList *firewall.BanList,
+) {
+	server.RegisterHandler(
+		http.MethodGet,
+		PeersPath,
+		func(_ *http.Request) (interface{}, error) {
+			return listPeers(connectionManager, banList), nil
+		},
+	)
+
+	server.RegisterHandler(
+		http.MethodPost,
+		DisconnectPeerPath,
+		func(request *http.Request) (interface{}, error) {
+			disconnectRequest := &DisconnectPeerRequest{}
+			if err := json.NewDecoder(request.Body).Decode(
+				disconnectRequest,
+			); err != nil {
+				return nil, newBadRequestError("invalid request: [%v]", err)
+			}
+
+			if disconnectRequest.Ban {
+				peerPublicKey, err := connectionManager.GetPeerPublicKey(
+					disconnectRequest.NetworkID,
+				)
+				if err != nil {
+					return nil, newBadRequestError("%v", err)
+				}
+
+				address := key.NetworkPubKeyToChainAddress(peerPublicKey)
+				banList.Ban(address)
+
+				logger.Infof(
+					"banned peer [%v] with address [%v] on admin request",
+					disconnectRequest.NetworkID,
+					address,
+				)
+			}
+
+			connectionManager.DisconnectPeer(disconnectRequest.NetworkID)
+
+			logger.Infof(
+				"disconnected peer [%v] on admin request",
+				disconnectRequest.NetworkID,
+			)
+
+			return listPeers(connectionManager, banList), nil
+		},
+	)
+
+	server.RegisterHandler(
+		http.MethodPost,
+		UnbanPeerPath,
+		func(request *http.Request) (interface{}, error) {
+			unbanRequest := &UnbanPeerRequest{}
+			if err := json.NewDecoder(request.Body).Decode(
+				unbanRequest,
+			); err != nil {
+				return nil, newBadRequestError("invalid request: [%v]", err)
+			}
+
+			banList.Unban(unbanRequest.EthereumAddress)
+
+			logger.Infof(
+				"unbanned address [%v] on admin request",
+				unbanRequest.EthereumAddress,
+			)
+
+			return listPeers(connectionManager, banList), nil
+		},
+	)
+}
+
+// RegisterPingPeerHandler registers the handler probing the round-trip time
+// to the given peer from the running client, with its network identity, and
+// checking whether the peer passes the given minimum stake policy. Clients
+// should expect the request to take the duration of the ping request.
+func RegisterPingPeerHandler(
+	server *Server,
+	provider net.Provider,
+	minimumStakePolicy net.Firewall,
+) {
+	server.RegisterHandler(
+		http.MethodPost,
+		PingPeerPath,
+		func(request *http.Request) (interface{}, error) {
+			pingRequest := &PingPeerRequest{}
+			if err := json.NewDecoder(request.Body).Decode(
+				pingRequest,
+			); err != nil {
+				return nil, newBadRequestError("invalid request: [%v]", err)
+			}
+
+			if pingRequest.Count < 1 {
+				return nil, newBadRequestError(
+					"number of probes must be positive",
+				)
+			}
+
+			peerID, err := peer.IDB58Decode(pingRequest.NetworkID)
+			if err != nil {
+				return nil, newBadRequestError(
+					"invalid peer ID [%v]: [%v]",
+					pingRequest.NetworkID,
+					err,
+				)
+			}
+
+			publicKey, err := peerID.ExtractPublicKey()
+			if err != nil {
+				return nil, newBadRequestError(
+					"could not extract public key from peer ID [%v]: [%v]",
+					pingRequest.NetworkID,
+					err,
+				)
+			}
+			policyErr := minimumStakePolicy.Validate(
+				key.NetworkKeyToECDSAKey(key.Libp2pKeyToNetworkKey(publicKey)),
+			)
+
+			result, err := ping.Probe(
+				request.Context(),
+				provider,
+				peerID,
+				pingRequest.Count,
+				pingRequest.Interval,
+				pingRequest.Timeout,
+				nil,
+			)
+			if err != nil {
+				if policyErr != nil {
+					return nil, fmt.Errorf(
+						"%v; remote peer does not pass the minimum stake "+
+							"policy: [%v]",
+						err,
+						policyErr,
+					)
+				}
+				return nil, err
+			}
+
+			pingResult := &PingResult{
+				Sent:           result.Sent,
+				Received:       result.Received,
+				RoundTripTimes: result.RoundTripTimes,
+			}
+			if policyErr != nil {
+				pingResult.MinimumStakePolicyError = policyErr.Error()
+			}
+
+			return pingResult, nil
+		},
+	)
+}
+
+func listPeers(
+	connectionManager net.ConnectionManager,
+	banList *firewall.BanList,
+) *Peers {
+	connectedPeers := connectionManager.ConnectedPeers()
+
+	peers := &Peers{
+		Connected: make([]Peer, 0, len(connectedPeers)),
+		Banned:    banList.Banned(),
+	}
+
+	for _, connectedPeer := range connectedPeers {
+		peer := Peer{NetworkID: connectedPeer}
+
+		peerPublicKey, err := connectionManager.GetPeerPublicKey(connectedPeer)
+		if err != nil {
+			logger.Warningf("could not get peer public key: [%v]", err)
+		} else {
+			peer.EthereumAddress = key.NetworkPubKeyToChainAddress(peerPublicKey)
+		}
+
+		peers.Connected = append(peers.Connected, peer)
+	}
+
+	return peers
+}
+
+// RegisterGoroutinesHandler registers the handler dumping stack traces of
+// all the goroutines of the client, in the same format as an unrecovered
+// panic does.
+func RegisterGoroutinesHandler(server *Server) {
+	server.RegisterHandler(
+		http.MethodGet,
+		GoroutinesPath,
+		func(_ *http.Request) (interface{}, error) {
+			buffer := &bytes.Buffer{}
+			if err := pprof.Lookup("goroutine").WriteTo(buffer, 2); err != nil {
+				return nil, err
+			}
+
+			return &Goroutines{Dump: buffer.String()}, nil
+		},
+	)
+}
@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
@@ -24,30 +26,39 @@ var logger = log.Logger("keep-beacon")
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
 // internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// otherwise returns the relay node handling the beacon work.
+//
+// When the provided context is done, the beacon unsubscribes from all chain
+// events and refuses to start new group selections and relay entry signing.
+// Work which is already in progress is not interrupted; the returned node
+// can be used to wait for its completion.
+//
+// Ticket submissions, DKG executions and relay entry signings are recorded in
+// the provided participation ledger unless it is nil.
 func Initialize(
 	ctx context.Context,
 	stakingID string,
 	chainHandle chain.Handle,
 	netProvider net.Provider,
 	persistence persistence.Handle,
-) error {
+	participationLedger *ledger.Ledger,
+) (*relay.Node, error) {
 	relayChain := chainHandle.ThresholdRelay()
 	chainConfig := relayChain.GetConfig()
 
 	stakeMonitor, err := chainHandle.StakeMonitor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	staker, err := stakeMonitor.StakerFor(stakingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockCounter, err := chainHandle.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signing := chainHandle.Signing()
@@ -61,6 +72,7 @@ func Initialize(
 		blockCounter,
 		chainConfig,
 		groupRegistry,
+		participationLedger,
 	)
 
 	// We need to calculate group selection duration here as we can't do it
@@ -79,7 +91,18 @@ func Initialize(
 
 	node.ResumeSigningIfEligible(relayChain, signing)
 
-	_ = relayChain.OnRelayEntryRequested(func(request *event.Request) {
+	relayEntryRequestedSubscription := relayChain.OnRelayEntryRequested(func(
+		request *event.Request,
+	) {
+		if ctx.Err() != nil {
+			logger.Warningf(
+				"refusing relay entry requested at block [%v]; "+
+					"client is shutting down",
+				request.BlockNumber,
+			)
+			return
+		}
+
 		onConfirmed := func() {
 			if node.IsInGroup(request.GroupPublicKey) {
 				go func() {
@@ -149,8 +172,39 @@ func Initialize(
 		)
 	})
 
-	_ = relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
+	groupSelectionStartedSubscription := relayChain.OnGroupSelectionStarted(func(
+		event *event.GroupSelectionStart,
+	) {
+		if ctx.Err() != nil {
+			logger.Warningf(
+				"refusing group selection started at block [%v]; "+
+					"client is shutting down",
+				event.BlockNumber,
+			)
+			return
+		}
+
+		recorder := participationLedger.NewRecorder(
+			&ledger.Record{
+				Type:       ledger.TicketSubmissionSession,
+				Seed:       event.NewEntry.Text(16),
+				StartBlock: event.BlockNumber,
+			},
+			blockCounter,
+		)
+
 		onGroupSelected := func(group *groupselection.Result) {
+			recordGroupSelection(recorder, group, staker.Address())
+
+			if ctx.Err() != nil {
+				logger.Warningf(
+					"not joining group selected with seed [0x%x]; "+
+						"client is shutting down",
+					event.NewEntry,
+				)
+				return
+			}
+
 			for index, staker := range group.SelectedStakers {
 				logger.Infof(
 					"new candidate group member [0x%v] with index [%v]",
@@ -186,7 +240,7 @@ func Initialize(
 			)
 
 			err = groupselection.CandidateToNewGroup(
-				relayChain,
+				recorder.Chain(relayChain),
 				blockCounter,
 				chainConfig,
 				staker,
@@ -196,11 +250,14 @@ func Initialize(
 			)
 			if err != nil {
 				logger.Errorf("tickets submission failed: [%v]", err)
+				recorder.Fail(err)
 			}
 		}()
 	})
 
-	_ = relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
+	groupRegisteredSubscription := relayChain.OnGroupRegistered(func(
+		registration *event.GroupRegistration,
+	) {
 		logger.Infof(
 			"new group with public key [0x%x] registered on-chain at block [%v]",
 			registration.GroupPublicKey,
@@ -209,7 +266,69 @@ func Initialize(
 		go groupRegistry.UnregisterStaleGroups(registration.GroupPublicKey)
 	})
 
-	return nil
+	go func() {
+		<-ctx.Done()
+
+		logger.Infof("unsubscribing from chain events")
+
+		relayEntryRequestedSubscription.Unsubscribe()
+		groupSelectionStartedSubscription.Unsubscribe()
+		groupRegisteredSubscription.Unsubscribe()
+	}()
+
+	return &node, nil
+}
+
+// recordGroupSelection completes the ticket submission session recording
+// with the indexes the staker has been selected to the candidate group at.
+func recordGroupSelection(
+	recorder *ledger.Recorder,
+	group *groupselection.Result,
+	stakerAddress []byte,
+) {
+	memberIndexes := make([]int, 0)
+	for index, selectedStaker := range group.SelectedStakers {
+		if bytes.Equal(selectedStaker, stakerAddress) {
+			memberIndexes = append(memberIndexes, index+1)
+		}
+	}
+
+	recorder.SetMemberIndexes(memberIndexes)
+
+	if len(memberIndexes) > 0 {
+		recorder.Complete(ledger.OutcomeSelected)
+	} else {
+		recorder.Complete(ledger.OutcomeNotSelected)
+	}
+}
+
+// ForwardSignatureShares starts forwarding signature shares messages of
+// every group requested to produce a relay entry, for nodes which are not
+// members of any group, such as bootstrap nodes. Messages are forwarded only
+// if the dissemination time of the network provider is set. Forwarding stops
+// being started for new requests when the provided context is done.
+func ForwardSignatureShares(
+	ctx context.Context,
+	relayChain relaychain.RelayEntryInterface,
+	netProvider net.Provider,
+) {
+	subscription := relayChain.OnRelayEntryRequested(func(
+		request *event.Request,
+	) {
+		logger.Infof(
+			"forwarding signature shares of group [0x%x] for relay entry "+
+				"requested at block [%v]",
+			request.GroupPublicKey,
+			request.BlockNumber,
+		)
+
+		relay.ForwardSignatureShares(netProvider, request.GroupPublicKey)
+	})
+
+	go func() {
+		<-ctx.Done()
+		subscription.Unsubscribe()
+	}()
 }
 
 // Before we start relay entry signing process we need to confirm the current
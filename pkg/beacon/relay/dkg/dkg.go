@@ -18,7 +18,11 @@ import (
 
 var logger = log.Logger("keep-dkg")
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// ExecuteDKG runs the full distributed key generation lifecycle. Along with
+// the signer, it returns the group as observed by the member at the end of
+// GJKR, with members marked as inactive and disqualified. The group is
+// returned also when the result publication failed and is nil only if GJKR
+// did not complete.
 func ExecuteDKG(
 	seed *big.Int,
 	index uint8, // starts with 0
@@ -30,7 +34,7 @@ func ExecuteDKG(
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 	channel net.BroadcastChannel,
-) (*ThresholdSigner, error) {
+) (*ThresholdSigner, *group.Group, error) {
 	// The staker index should begin with 1
 	playerIndex := group.MemberIndex(index + 1)
 
@@ -48,7 +52,7 @@ func ExecuteDKG(
 		startBlockHeight,
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, nil, fmt.Errorf(
 			"[member:%v] GJKR execution failed [%v]",
 			playerIndex,
 			err,
@@ -97,7 +101,7 @@ func ExecuteDKG(
 			relayChain,
 			blockCounter,
 		); err != nil {
-			return nil, err
+			return nil, gjkrResult.Group, err
 		}
 	}
 
@@ -106,7 +110,7 @@ func ExecuteDKG(
 		groupPublicKey:       gjkrResult.GroupPublicKey,
 		groupPrivateKeyShare: gjkrResult.GroupPrivateKeyShare,
 		groupPublicKeyShares: gjkrResult.GroupPublicKeyShares(),
-	}, nil
+	}, gjkrResult.Group, nil
 }
 
 // decideMemberFate decides what the member will do in case it failed
@@ -41,6 +41,11 @@ func (ts *ThresholdSigner) MemberID() group.MemberIndex {
 	return ts.memberIndex
 }
 
+// GroupPublicKey returns the public key of the group.
+func (ts *ThresholdSigner) GroupPublicKey() *bn256.G2 {
+	return ts.groupPublicKey
+}
+
 // GroupPublicKeyBytes returns group public key bytes in an uncompressed form.
 func (ts *ThresholdSigner) GroupPublicKeyBytes() []byte {
 	return ts.groupPublicKey.Marshal()
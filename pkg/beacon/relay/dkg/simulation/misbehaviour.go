@@ -0,0 +1,266 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Behaviour is a way in which a misbehaving member deviates from the
+// protocol.
+type Behaviour string
+
+const (
+	// DropMessages makes the member's messages get lost with the given
+	// probability.
+	DropMessages Behaviour = "drop"
+	// CorruptShares makes the member send corrupted encrypted shares to all
+	// other group members.
+	CorruptShares Behaviour = "corrupt-shares"
+	// GoInactive makes the member stop sending messages starting from the
+	// given protocol phase.
+	GoInactive Behaviour = "inactive"
+)
+
+// Misbehaviour describes how the given group member deviates from the
+// protocol.
+type Misbehaviour struct {
+	Member    group.MemberIndex
+	Behaviour Behaviour
+	// Percentage of the member's messages which are dropped; used only with
+	// DropMessages.
+	Percentage int
+	// Protocol phase, from 1 to 13, starting from which the member does not
+	// send any messages; used only with GoInactive.
+	Phase int
+}
+
+// ParseMisbehaviour parses misbehaviour given in one of the forms:
+//
+//   <member>:drop[:<percentage>]
+//   <member>:corrupt-shares
+//   <member>:inactive:<phase>
+//
+// Percentage of dropped messages defaults to 100.
+func ParseMisbehaviour(value string) (*Misbehaviour, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf(
+			"misbehaviour [%v] must have the form <member>:<behaviour>",
+			value,
+		)
+	}
+
+	member, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil || member == 0 {
+		return nil, fmt.Errorf(
+			"invalid member index [%v] in misbehaviour [%v]",
+			parts[0],
+			value,
+		)
+	}
+
+	misbehaviour := &Misbehaviour{
+		Member:    group.MemberIndex(member),
+		Behaviour: Behaviour(parts[1]),
+	}
+
+	switch misbehaviour.Behaviour {
+	case DropMessages:
+		misbehaviour.Percentage = 100
+		if len(parts) == 3 {
+			percentage, err := strconv.Atoi(parts[2])
+			if err != nil || percentage < 1 || percentage > 100 {
+				return nil, fmt.Errorf(
+					"percentage [%v] in misbehaviour [%v] must be "+
+						"between 1 and 100",
+					parts[2],
+					value,
+				)
+			}
+			misbehaviour.Percentage = percentage
+		} else if len(parts) > 3 {
+			return nil, fmt.Errorf("too many arguments in misbehaviour [%v]", value)
+		}
+	case CorruptShares:
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("too many arguments in misbehaviour [%v]", value)
+		}
+	case GoInactive:
+		if len(parts) != 3 {
+			return nil, fmt.Errorf(
+				"misbehaviour [%v] must have the form <member>:inactive:<phase>",
+				value,
+			)
+		}
+		phase, err := strconv.Atoi(parts[2])
+		if err != nil || phase < 1 || phase > resultPublicationPhase {
+			return nil, fmt.Errorf(
+				"phase [%v] in misbehaviour [%v] must be between 1 and %v",
+				parts[2],
+				value,
+				resultPublicationPhase,
+			)
+		}
+		misbehaviour.Phase = phase
+	default:
+		return nil, fmt.Errorf(
+			"unknown behaviour [%v] in misbehaviour [%v]; "+
+				"supported behaviours are [%v], [%v] and [%v]",
+			parts[1],
+			value,
+			DropMessages,
+			CorruptShares,
+			GoInactive,
+		)
+	}
+
+	return misbehaviour, nil
+}
+
+func (m *Misbehaviour) String() string {
+	switch m.Behaviour {
+	case DropMessages:
+		return fmt.Sprintf("%v:%v:%v", m.Member, m.Behaviour, m.Percentage)
+	case GoInactive:
+		return fmt.Sprintf("%v:%v:%v", m.Member, m.Behaviour, m.Phase)
+	default:
+		return fmt.Sprintf("%v:%v", m.Member, m.Behaviour)
+	}
+}
+
+// senderMessage is a protocol message carrying the index of its sender.
+type senderMessage interface {
+	net.TaggedMarshaler
+	SenderID() group.MemberIndex
+}
+
+// messagePhase returns the protocol phase in which the given message is sent
+// or zero if the message is not a DKG protocol message.
+func messagePhase(message net.TaggedMarshaler) int {
+	switch message.(type) {
+	case *gjkr.EphemeralPublicKeyMessage:
+		return 1
+	case *gjkr.MemberCommitmentsMessage, *gjkr.PeerSharesMessage:
+		return 3
+	case *gjkr.SecretSharesAccusationsMessage:
+		return 4
+	case *gjkr.MemberPublicKeySharePointsMessage:
+		return 7
+	case *gjkr.PointsAccusationsMessage:
+		return 8
+	case *gjkr.MisbehavedEphemeralKeysMessage:
+		return 10
+	case *result.DKGResultHashSignatureMessage:
+		return resultPublicationPhase
+	default:
+		return 0
+	}
+}
+
+// interceptionRules builds rules applying the misbehaviours to messages sent
+// by misbehaving members. Every sent message is also reported to the
+// observer along with its protocol phase. Random message drops are driven
+// by the given seed so that simulations can be repeated.
+func interceptionRules(
+	misbehaviours []*Misbehaviour,
+	randomSeed int64,
+	observer func(phase int),
+) interception.Rules {
+	byMember := make(map[group.MemberIndex][]*Misbehaviour)
+	for _, misbehaviour := range misbehaviours {
+		byMember[misbehaviour.Member] = append(
+			byMember[misbehaviour.Member],
+			misbehaviour,
+		)
+	}
+
+	var randomMutex sync.Mutex
+	random := rand.New(rand.NewSource(randomSeed)) // #nosec G404
+
+	return func(message net.TaggedMarshaler) net.TaggedMarshaler {
+		phase := messagePhase(message)
+		observer(phase)
+
+		sender, ok := message.(senderMessage)
+		if !ok {
+			return message
+		}
+
+		for _, misbehaviour := range byMember[sender.SenderID()] {
+			switch misbehaviour.Behaviour {
+			case DropMessages:
+				randomMutex.Lock()
+				roll := random.Intn(100)
+				randomMutex.Unlock()
+
+				if roll < misbehaviour.Percentage {
+					return nil
+				}
+			case GoInactive:
+				if phase >= misbehaviour.Phase {
+					return nil
+				}
+			case CorruptShares:
+				if sharesMessage, ok := message.(*gjkr.PeerSharesMessage); ok {
+					corrupted, err := corruptShares(sharesMessage)
+					if err != nil {
+						logger.Errorf("could not corrupt shares: [%v]", err)
+						continue
+					}
+					message = corrupted
+				}
+			}
+		}
+
+		return message
+	}
+}
+
+// corruptShares returns a copy of the message with every encrypted share
+// altered so that receivers can not decrypt it.
+func corruptShares(
+	message *gjkr.PeerSharesMessage,
+) (*gjkr.PeerSharesMessage, error) {
+	bytes, err := message.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	pbMessage := &pb.PeerShares{}
+	if err := pbMessage.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	for _, shares := range pbMessage.Shares {
+		corruptBytes(shares.EncryptedShareS)
+		corruptBytes(shares.EncryptedShareT)
+	}
+
+	bytes, err = pbMessage.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	corrupted := &gjkr.PeerSharesMessage{}
+	if err := corrupted.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	return corrupted, nil
+}
+
+func corruptBytes(bytes []byte) {
+	if len(bytes) > 0 {
+		bytes[len(bytes)-1] ^= 0xff
+	}
+}
@@ -0,0 +1,93 @@
+package simulation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMisbehaviour(t *testing.T) {
+	var tests = map[string]struct {
+		value                string
+		expectedMisbehaviour *Misbehaviour
+		expectedError        bool
+	}{
+		"drop all messages": {
+			value: "3:drop",
+			expectedMisbehaviour: &Misbehaviour{
+				Member:     3,
+				Behaviour:  DropMessages,
+				Percentage: 100,
+			},
+		},
+		"drop part of messages": {
+			value: "3:drop:25",
+			expectedMisbehaviour: &Misbehaviour{
+				Member:     3,
+				Behaviour:  DropMessages,
+				Percentage: 25,
+			},
+		},
+		"corrupt shares": {
+			value: "1:corrupt-shares",
+			expectedMisbehaviour: &Misbehaviour{
+				Member:    1,
+				Behaviour: CorruptShares,
+			},
+		},
+		"go inactive": {
+			value: "5:inactive:7",
+			expectedMisbehaviour: &Misbehaviour{
+				Member:    5,
+				Behaviour: GoInactive,
+				Phase:     7,
+			},
+		},
+		"missing behaviour": {
+			value:         "5",
+			expectedError: true,
+		},
+		"zero member index": {
+			value:         "0:drop",
+			expectedError: true,
+		},
+		"unknown behaviour": {
+			value:         "2:sleep",
+			expectedError: true,
+		},
+		"percentage out of range": {
+			value:         "2:drop:101",
+			expectedError: true,
+		},
+		"missing inactivity phase": {
+			value:         "2:inactive",
+			expectedError: true,
+		},
+		"inactivity phase out of range": {
+			value:         "2:inactive:14",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			misbehaviour, err := ParseMisbehaviour(test.value)
+			if test.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got misbehaviour [%v]", misbehaviour)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedMisbehaviour, misbehaviour) {
+				t.Errorf(
+					"unexpected misbehaviour\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedMisbehaviour,
+					misbehaviour,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+// Package simulation runs the full distributed key generation, including the
+// result publication, between group members connected with the local network
+// and the local chain. Selected members can be made to misbehave so that the
+// outcome of the protocol in adverse conditions can be explored offline.
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+var logger = log.Logger("keep-dkg-simulation")
+
+var minimumStake = big.NewInt(20)
+
+// resultPublicationPhase is the last protocol phase in which the result is
+// signed by group members and published to the chain.
+const resultPublicationPhase = 13
+
+// resultSubmissionTimeout is the time the simulation waits for the
+// asynchronously delivered result submission event after all members
+// completed the protocol.
+const resultSubmissionTimeout = 5 * time.Second
+
+// phases lists groups of consecutive protocol phases whose execution time can
+// be measured by observing messages sent by members. Phases which do not
+// exchange any messages are grouped with the adjacent ones.
+var phases = []struct {
+	first int
+	last  int
+	name  string
+}{
+	{1, 1, "ephemeral key pair generation"},
+	{2, 3, "symmetric keys, commitments and shares"},
+	{4, 6, "shares accusations, justification and qualification"},
+	{7, 7, "public key share points"},
+	{8, 9, "points accusations and justification"},
+	{10, 12, "key reveal, reconstruction and combination"},
+	{13, 13, "result publication"},
+}
+
+// PhaseTiming holds the wall-clock time it took to execute the given
+// protocol phases.
+type PhaseTiming struct {
+	FirstPhase int
+	LastPhase  int
+	Name       string
+	Duration   time.Duration
+}
+
+// Result of a simulation.
+type Result struct {
+	// Published tells whether the result was published to the chain.
+	Published bool
+	// GroupPublicKey is the published group public key.
+	GroupPublicKey []byte
+	// SignaturesCount is the number of member signatures supporting the
+	// published result.
+	SignaturesCount int
+	// Inactive and Disqualified are members marked as such by the protocol,
+	// as seen by an honest member.
+	Inactive     []group.MemberIndex
+	Disqualified []group.MemberIndex
+	// MemberFailures holds errors of members who failed to complete the
+	// protocol.
+	MemberFailures map[group.MemberIndex]error
+	// Phases holds the wall-clock time of protocol phases.
+	Phases []*PhaseTiming
+	// Duration is the wall-clock time of the entire simulation.
+	Duration time.Duration
+}
+
+// Run executes the distributed key generation for a group of the given size
+// and honest threshold, with the given members misbehaving. The seed is used
+// as the DKG seed and drives random message drops so that the simulation can
+// be repeated.
+func Run(
+	groupSize int,
+	honestThreshold int,
+	misbehaviours []*Misbehaviour,
+	seed *big.Int,
+) (*Result, error) {
+	if groupSize < 1 || groupSize > 255 {
+		return nil, fmt.Errorf("group size must be between 1 and 255")
+	}
+	if honestThreshold < 1 || honestThreshold > groupSize {
+		return nil, fmt.Errorf(
+			"honest threshold must be between 1 and group size [%v]",
+			groupSize,
+		)
+	}
+	for _, misbehaviour := range misbehaviours {
+		if int(misbehaviour.Member) > groupSize {
+			return nil, fmt.Errorf(
+				"misbehaving member [%v] is not in the group of size [%v]",
+				misbehaviour.Member,
+				groupSize,
+			)
+		}
+	}
+
+	simulationStart := time.Now()
+
+	timer := newPhaseTimer()
+	rules := interceptionRules(misbehaviours, seed.Int64(), timer.observe)
+
+	privateKey, publicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
+
+	network := interception.NewNetwork(
+		netLocal.ConnectWithKey(networkPublicKey),
+		rules,
+	)
+
+	chain := chainLocal.ConnectWithKey(
+		groupSize,
+		honestThreshold,
+		minimumStake,
+		privateKey,
+	)
+
+	address := chain.Signing().PublicKeyBytesToAddress(
+		key.Marshal(networkPublicKey),
+	)
+
+	selectedStakers := make([]relaychain.StakerAddress, groupSize)
+	for i := range selectedStakers {
+		selectedStakers[i] = address
+	}
+
+	relayConfig := chain.ThresholdRelay().GetConfig()
+
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := network.BroadcastChannelFor(
+		fmt.Sprintf("dkg-simulation-%v", seed),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gjkr.RegisterUnmarshallers(channel)
+	result.RegisterUnmarshallers(channel)
+
+	submissions := make(chan *event.DKGResultSubmission, 1)
+	subscription := chain.ThresholdRelay().OnDKGResultSubmitted(
+		func(submission *event.DKGResultSubmission) {
+			select {
+			case submissions <- submission:
+			default:
+			}
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		selectedStakers,
+		chain.Signing(),
+	)
+
+	currentBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for 3 blocks before starting DKG to make sure all members are up.
+	startBlockHeight := currentBlockHeight + 3
+
+	var (
+		mutex          sync.Mutex
+		gjkrResults    = make(map[group.MemberIndex]*gjkr.Result)
+		memberFailures = make(map[group.MemberIndex]error)
+		wg             sync.WaitGroup
+	)
+
+	wg.Add(groupSize)
+	for i := 1; i <= groupSize; i++ {
+		memberIndex := group.MemberIndex(i)
+		go func() {
+			defer wg.Done()
+
+			gjkrResult, gjkrEndBlockHeight, err := gjkr.Execute(
+				memberIndex,
+				groupSize,
+				blockCounter,
+				channel,
+				relayConfig.DishonestThreshold(),
+				seed,
+				membershipValidator,
+				startBlockHeight,
+			)
+			timer.observe(resultPublicationPhase)
+			if err != nil {
+				mutex.Lock()
+				memberFailures[memberIndex] = fmt.Errorf(
+					"GJKR execution failed [%v]",
+					err,
+				)
+				mutex.Unlock()
+				return
+			}
+
+			mutex.Lock()
+			gjkrResults[memberIndex] = gjkrResult
+			mutex.Unlock()
+
+			err = result.Publish(
+				memberIndex,
+				gjkrResult.Group,
+				membershipValidator,
+				gjkrResult,
+				channel,
+				chain.ThresholdRelay(),
+				chain.Signing(),
+				blockCounter,
+				gjkrEndBlockHeight,
+			)
+			if err != nil {
+				mutex.Lock()
+				memberFailures[memberIndex] = fmt.Errorf(
+					"DKG result publication failed [%v]",
+					err,
+				)
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	timer.finish()
+
+	simulationResult := &Result{MemberFailures: memberFailures}
+
+	select {
+	case <-submissions:
+		dkgResult, signatures := chain.GetLastDKGResult()
+		simulationResult.Published = true
+		simulationResult.GroupPublicKey = dkgResult.GroupPublicKey
+		simulationResult.SignaturesCount = len(signatures)
+	case <-time.After(resultSubmissionTimeout):
+		logger.Warningf("no DKG result was published")
+	}
+
+	if view := honestView(
+		gjkrResults,
+		misbehaviours,
+		simulationResult.GroupPublicKey,
+	); view != nil {
+		simulationResult.Inactive = sortedIndexes(view.Group.InactiveMemberIDs())
+		simulationResult.Disqualified = sortedIndexes(view.Group.DisqualifiedMemberIDs())
+	}
+
+	simulationResult.Phases = timer.timings()
+	simulationResult.Duration = time.Since(simulationStart)
+
+	return simulationResult, nil
+}
+
+// honestView returns the GJKR result of a member which supports the published
+// group public key, if any, preferring members which were not told to
+// misbehave.
+func honestView(
+	gjkrResults map[group.MemberIndex]*gjkr.Result,
+	misbehaviours []*Misbehaviour,
+	publishedGroupPublicKey []byte,
+) *gjkr.Result {
+	misbehaving := make(map[group.MemberIndex]bool)
+	for _, misbehaviour := range misbehaviours {
+		misbehaving[misbehaviour.Member] = true
+	}
+
+	memberIndexes := make([]group.MemberIndex, 0, len(gjkrResults))
+	for memberIndex := range gjkrResults {
+		memberIndexes = append(memberIndexes, memberIndex)
+	}
+	memberIndexes = sortedIndexes(memberIndexes)
+
+	supportsPublished := func(gjkrResult *gjkr.Result) bool {
+		if publishedGroupPublicKey == nil {
+			return true
+		}
+		groupPublicKey, err := gjkrResult.GroupPublicKeyBytes()
+		return err == nil && bytes.Equal(groupPublicKey, publishedGroupPublicKey)
+	}
+
+	var fallback *gjkr.Result
+	for _, memberIndex := range memberIndexes {
+		gjkrResult := gjkrResults[memberIndex]
+		if !supportsPublished(gjkrResult) {
+			continue
+		}
+		if !misbehaving[memberIndex] {
+			return gjkrResult
+		}
+		if fallback == nil {
+			fallback = gjkrResult
+		}
+	}
+
+	return fallback
+}
+
+func sortedIndexes(indexes []group.MemberIndex) []group.MemberIndex {
+	sorted := make([]group.MemberIndex, len(indexes))
+	copy(sorted, indexes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// phaseTimer records the moment each protocol phase was first observed.
+type phaseTimer struct {
+	mutex    sync.Mutex
+	started  map[int]time.Time
+	finished time.Time
+}
+
+func newPhaseTimer() *phaseTimer {
+	return &phaseTimer{started: make(map[int]time.Time)}
+}
+
+func (pt *phaseTimer) observe(phase int) {
+	if phase == 0 {
+		return
+	}
+
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if _, ok := pt.started[phase]; !ok {
+		pt.started[phase] = time.Now()
+	}
+}
+
+func (pt *phaseTimer) finish() {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.finished = time.Now()
+}
+
+// timings returns the wall-clock time of every group of phases, measured
+// from the moment it was first observed to the moment the next observed
+// group started. Groups which were not observed are skipped.
+func (pt *phaseTimer) timings() []*PhaseTiming {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	phaseStart := func(first, last int) (time.Time, bool) {
+		for phase := first; phase <= last; phase++ {
+			if start, ok := pt.started[phase]; ok {
+				return start, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	var timings []*PhaseTiming
+	for i, phase := range phases {
+		start, ok := phaseStart(phase.first, phase.last)
+		if !ok {
+			continue
+		}
+
+		end := pt.finished
+		for _, next := range phases[i+1:] {
+			if nextStart, ok := phaseStart(next.first, next.last); ok {
+				end = nextStart
+				break
+			}
+		}
+
+		timings = append(timings, &PhaseTiming{
+			FirstPhase: phase.first,
+			LastPhase:  phase.last,
+			Name:       phase.name,
+			Duration:   end.Sub(start),
+		})
+	}
+
+	return timings
+}
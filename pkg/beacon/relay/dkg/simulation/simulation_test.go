@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+func TestRun(t *testing.T) {
+	groupSize := 6
+	honestThreshold := 3
+
+	misbehaviours := []*Misbehaviour{
+		{Member: 2, Behaviour: GoInactive, Phase: 1},
+		{Member: 4, Behaviour: CorruptShares},
+	}
+
+	result, err := Run(groupSize, honestThreshold, misbehaviours, big.NewInt(7331))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Published {
+		t.Fatalf(
+			"expected DKG result to be published; member failures: [%v]",
+			result.MemberFailures,
+		)
+	}
+
+	if len(result.GroupPublicKey) == 0 {
+		t.Error("expected group public key to be published")
+	}
+
+	if result.SignaturesCount != 4 {
+		t.Errorf(
+			"unexpected number of result signatures\nexpected: [4]\nactual:   [%v]",
+			result.SignaturesCount,
+		)
+	}
+
+	expectedInactive := []group.MemberIndex{2}
+	if !reflect.DeepEqual(expectedInactive, result.Inactive) {
+		t.Errorf(
+			"unexpected inactive members\nexpected: [%v]\nactual:   [%v]",
+			expectedInactive,
+			result.Inactive,
+		)
+	}
+
+	expectedDisqualified := []group.MemberIndex{4}
+	if !reflect.DeepEqual(expectedDisqualified, result.Disqualified) {
+		t.Errorf(
+			"unexpected disqualified members\nexpected: [%v]\nactual:   [%v]",
+			expectedDisqualified,
+			result.Disqualified,
+		)
+	}
+
+	if len(result.Phases) != len(phases) {
+		t.Errorf(
+			"unexpected number of timed phases\nexpected: [%v]\nactual:   [%v]",
+			len(phases),
+			len(result.Phases),
+		)
+	}
+}
+
+func TestRunInvalidParameters(t *testing.T) {
+	var tests = map[string]struct {
+		groupSize       int
+		honestThreshold int
+		misbehaviours   []*Misbehaviour
+	}{
+		"honest threshold above group size": {
+			groupSize:       3,
+			honestThreshold: 4,
+		},
+		"misbehaving member outside of group": {
+			groupSize:       3,
+			honestThreshold: 2,
+			misbehaviours: []*Misbehaviour{
+				{Member: 4, Behaviour: CorruptShares},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := Run(
+				test.groupSize,
+				test.honestThreshold,
+				test.misbehaviours,
+				big.NewInt(1),
+			)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package entry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// ShareDeliveryObserver records valid signature shares broadcast by the
+// group members for a relay entry along with the blocks at which they were
+// received. Unlike the signing protocol, which stops collecting shares as
+// soon as the signature can be restored or is received from another member,
+// the observer keeps recording them until its context is done. The context
+// should last until the relay entry timeout block, so that members which are
+// slower than the honest threshold are not considered as not delivering
+// their shares.
+type ShareDeliveryObserver struct {
+	blockCounter         chain.BlockCounter
+	groupPublicKeyShares map[group.MemberIndex]*bn256.G2
+	previousEntry        *bn256.G1
+
+	mutex  sync.Mutex
+	shares map[group.MemberIndex]*bn256.G1
+	blocks map[group.MemberIndex]uint64
+}
+
+// ObserveShareDelivery starts recording signature shares of the given
+// previous entry received on the given broadcast channel for the lifetime of
+// the given context. Shares are recorded only for members whose group public
+// key shares are known. The channel must have unmarshallers registered with
+// RegisterUnmarshallers.
+func ObserveShareDelivery(
+	ctx context.Context,
+	channel net.BroadcastChannel,
+	blockCounter chain.BlockCounter,
+	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
+	previousEntryBytes []byte,
+) (*ShareDeliveryObserver, error) {
+	previousEntry := new(bn256.G1)
+	if _, err := previousEntry.Unmarshal(previousEntryBytes); err != nil {
+		return nil, fmt.Errorf("could not unmarshal previous entry: [%v]", err)
+	}
+
+	observer := &ShareDeliveryObserver{
+		blockCounter:         blockCounter,
+		groupPublicKeyShares: groupPublicKeyShares,
+		previousEntry:        previousEntry,
+		shares:               make(map[group.MemberIndex]*bn256.G1),
+		blocks:               make(map[group.MemberIndex]uint64),
+	}
+
+	channel.Recv(ctx, func(netMessage net.Message) {
+		message, ok := netMessage.Payload().(*SignatureShareMessage)
+		if !ok {
+			return
+		}
+
+		observer.record(message)
+	})
+
+	return observer, nil
+}
+
+func (sdo *ShareDeliveryObserver) record(message *SignatureShareMessage) {
+	if sdo.hasDelivered(message.senderID) {
+		return
+	}
+
+	share, err := extractShare(message, sdo.groupPublicKeyShares)
+	if err != nil {
+		return
+	}
+
+	// The sender ID of the message is not tied to the network identity of
+	// its sender so the share is verified before being recorded. Otherwise,
+	// anyone could record an invalid share on behalf of the member before
+	// the member delivers its own.
+	if !bls.VerifyG1(
+		sdo.groupPublicKeyShares[message.senderID],
+		sdo.previousEntry,
+		share,
+	) {
+		return
+	}
+
+	blockNumber, err := sdo.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf(
+			"could not get the block of the signature share delivery: [%v]",
+			err,
+		)
+		return
+	}
+
+	sdo.mutex.Lock()
+	defer sdo.mutex.Unlock()
+
+	// Only the first valid share of the member is recorded.
+	if _, ok := sdo.shares[message.senderID]; ok {
+		return
+	}
+
+	sdo.shares[message.senderID] = share
+	sdo.blocks[message.senderID] = blockNumber
+}
+
+func (sdo *ShareDeliveryObserver) hasDelivered(memberID group.MemberIndex) bool {
+	sdo.mutex.Lock()
+	defer sdo.mutex.Unlock()
+
+	_, ok := sdo.shares[memberID]
+	return ok
+}
+
+// Deliveries returns the blocks at which valid shares were received from
+// their senders so far. Members which have not delivered a valid share are
+// not included.
+func (sdo *ShareDeliveryObserver) Deliveries() map[group.MemberIndex]uint64 {
+	sdo.mutex.Lock()
+	defer sdo.mutex.Unlock()
+
+	deliveries := make(map[group.MemberIndex]uint64, len(sdo.blocks))
+	for senderID, blockNumber := range sdo.blocks {
+		deliveries[senderID] = blockNumber
+	}
+
+	return deliveries
+}
@@ -0,0 +1,73 @@
+package entry
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestShareDeliveryObserverDeliveries(t *testing.T) {
+	localChain := local.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	shares := make(map[group.MemberIndex]*bn256.G1)
+	for memberIndex := group.MemberIndex(1); memberIndex <= 5; memberIndex++ {
+		secretKeyShare := big.NewInt(int64(100 + memberIndex))
+		groupPublicKeyShares[memberIndex] = new(bn256.G2).ScalarBaseMult(
+			secretKeyShare,
+		)
+		shares[memberIndex] = bls.SignG1(secretKeyShare, previousEntry)
+	}
+
+	observer := &ShareDeliveryObserver{
+		blockCounter:         blockCounter,
+		groupPublicKeyShares: groupPublicKeyShares,
+		previousEntry:        previousEntry,
+		shares:               make(map[group.MemberIndex]*bn256.G1),
+		blocks:               make(map[group.MemberIndex]uint64),
+	}
+
+	// Member 3 does not deliver its share and member 4 sends the share of
+	// member 2. Someone impersonating member 5 sends an invalid share before
+	// member 5 delivers its valid one, which must still be recorded.
+	for _, delivery := range []struct {
+		senderID group.MemberIndex
+		share    *bn256.G1
+	}{
+		{1, shares[1]},
+		{2, shares[2]},
+		{4, shares[2]},
+		{5, shares[1]},
+		{5, shares[5]},
+	} {
+		observer.record(&SignatureShareMessage{
+			senderID:   delivery.senderID,
+			shareBytes: delivery.share.Marshal(),
+		})
+	}
+
+	deliveries := observer.Deliveries()
+
+	if len(deliveries) != 3 {
+		t.Errorf(
+			"unexpected number of deliveries\nexpected: [%v]\nactual:   [%v]",
+			3,
+			len(deliveries),
+		)
+	}
+	for _, memberIndex := range []group.MemberIndex{1, 2, 5} {
+		if _, ok := deliveries[memberIndex]; !ok {
+			t.Errorf("expected delivery from member [%v]", memberIndex)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package entry
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
@@ -26,11 +27,21 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &SignatureShareMessage{}
 	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &GroupSignatureMessage{}
+	})
 }
 
 // SignAndSubmit triggers the threshold signature process for the
 // previous relay entry and publishes the signature to the chain as
 // a new relay entry.
+//
+// The member which restores the group signature from the shares broadcasts
+// it to the group once it becomes eligible to submit the entry, unless the
+// signature has been broadcast by another member before. Members which
+// receive a valid group signature before collecting enough shares stop
+// collecting them and proceed straight to the submission using the received
+// signature.
 func SignAndSubmit(
 	blockCounter chain.BlockCounter,
 	channel net.BroadcastChannel,
@@ -43,10 +54,16 @@ func SignAndSubmit(
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
-	relayEntrySubmittedChannel := make(chan uint64)
+	// The handler must never block: once this member returns, nobody reads
+	// from the channel anymore and a blocked handler would hold up the
+	// subscription until it is closed.
+	relayEntrySubmittedChannel := make(chan uint64, 1)
 	subscription := relayChain.OnRelayEntrySubmitted(
 		func(event *event.EntrySubmitted) {
-			relayEntrySubmittedChannel <- event.BlockNumber
+			select {
+			case relayEntrySubmittedChannel <- event.BlockNumber:
+			default:
+			}
 		},
 	)
 	defer subscription.Unsubscribe()
@@ -79,23 +96,71 @@ func SignAndSubmit(
 		signer.MemberID(): selfShare,
 	}
 
+	// Received signature shares are not verified one by one. They are
+	// buffered until, together with the already verified ones, there are
+	// enough of them to restore the signature and then verified in a batch.
+	pendingShares := make(map[group.MemberIndex]*bn256.G1)
+	// Members whose shares failed the batch verification are not given
+	// another chance, so that their later shares do not make the following
+	// batches fail again.
+	rejectedSenders := make(map[group.MemberIndex]bool)
+
+	var receivedSignature *bn256.G1
+
 	// Run the message loop until the number of received and valid signature
-	// shares is equal to the honest threshold. Message loop will be also
-	// terminated if an other member submits the result or the relay entry
-	// timeout block is reached.
-	for len(receivedValidShares) < honestThreshold {
+	// shares is equal to the honest threshold or until a valid group signature
+	// is received from another member. Message loop will be also terminated
+	// if an other member submits the result or the relay entry timeout block
+	// is reached.
+	for len(receivedValidShares) < honestThreshold && receivedSignature == nil {
 		select {
 		case netMessage := <-receiveChannel:
+			if message, ok := netMessage.Payload().(*GroupSignatureMessage); ok {
+				if group.IsMessageFromSelf(signer.MemberID(), message) {
+					continue
+				}
+
+				signature, err := extractAndValidateSignature(
+					message,
+					signer.GroupPublicKey(),
+					previousEntry,
+				)
+				if err != nil {
+					logger.Warningf(
+						"[member:%v] rejecting group signature from "+
+							"member [%v]: [%v]",
+						signer.MemberID(),
+						message.senderID,
+						err,
+					)
+					continue
+				}
+
+				logger.Infof(
+					"[member:%v] accepting group signature from member [%v]; "+
+						"stopping collecting signature shares",
+					signer.MemberID(),
+					message.senderID,
+				)
+
+				receivedSignature = signature
+				continue
+			}
+
 			message, ok := netMessage.Payload().(*SignatureShareMessage)
 			if !ok || group.IsMessageFromSelf(signer.MemberID(), message) {
 				continue
 			}
 
-			share, err := extractAndValidateShare(
-				message,
-				signer.GroupPublicKeyShares(),
-				previousEntry,
-			)
+			if _, ok := receivedValidShares[message.senderID]; ok {
+				continue
+			}
+
+			if rejectedSenders[message.senderID] {
+				continue
+			}
+
+			share, err := extractShare(message, signer.GroupPublicKeyShares())
 			if err != nil {
 				logger.Warningf(
 					"[member:%v] rejecting signature share from "+
@@ -107,13 +172,32 @@ func SignAndSubmit(
 				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
+			pendingShares[message.senderID] = share
+
+			if len(receivedValidShares)+len(pendingShares) < honestThreshold {
+				continue
+			}
+
+			validShares, err := validateShares(
 				signer.MemberID(),
-				message.senderID,
+				pendingShares,
+				signer.GroupPublicKeyShares(),
+				previousEntry,
 			)
+			if err != nil {
+				return err
+			}
+
+			for senderID := range pendingShares {
+				share, ok := validShares[senderID]
+				if !ok {
+					rejectedSenders[senderID] = true
+					continue
+				}
 
-			receivedValidShares[message.senderID] = share
+				receivedValidShares[senderID] = share
+			}
+			pendingShares = make(map[group.MemberIndex]*bn256.G1)
 		case blockNumber := <-relayEntrySubmittedChannel:
 			logger.Infof(
 				"[member:%v] leaving message loop; "+
@@ -131,9 +215,38 @@ func SignAndSubmit(
 		}
 	}
 
-	signature, err := completeSignature(signer, receivedValidShares, honestThreshold)
-	if err != nil {
-		return err
+	signature := receivedSignature
+	if signature == nil {
+		signature, err = completeSignature(
+			signer,
+			receivedValidShares,
+			honestThreshold,
+		)
+		if err != nil {
+			return err
+		}
+
+		eligibilityWaiter, err := blockCounter.BlockHeightWaiter(
+			SubmissionEligibilityBlock(
+				startBlockHeight,
+				signer.MemberID(),
+				chainConfig.ResultPublicationBlockStep,
+			),
+		)
+		if err != nil {
+			return err
+		}
+
+		go broadcastSignatureWhenEligible(
+			ctx,
+			signer.MemberID(),
+			signature,
+			signer.GroupPublicKey(),
+			previousEntry,
+			channel,
+			receiveChannel,
+			eligibilityWaiter,
+		)
 	}
 
 	submitter := &relayEntrySubmitter{
@@ -176,10 +289,79 @@ func broadcastShare(
 	}
 }
 
-func extractAndValidateShare(
+// broadcastSignatureWhenEligible broadcasts the group signature once the
+// member becomes eligible to submit the relay entry, unless a valid group
+// signature is received from another member before. This way, members which
+// restored the signature at the same time do not all broadcast it and the
+// signature is broadcast by at most one member eligible to submit at a time.
+func broadcastSignatureWhenEligible(
+	ctx context.Context,
+	memberID group.MemberIndex,
+	signature *bn256.G1,
+	groupPublicKey *bn256.G2,
+	previousEntry *bn256.G1,
+	channel net.BroadcastChannel,
+	receiveChannel <-chan net.Message,
+	eligibilityWaiter <-chan uint64,
+) {
+	for {
+		select {
+		case netMessage := <-receiveChannel:
+			message, ok := netMessage.Payload().(*GroupSignatureMessage)
+			if !ok || group.IsMessageFromSelf(memberID, message) {
+				continue
+			}
+
+			_, err := extractAndValidateSignature(
+				message,
+				groupPublicKey,
+				previousEntry,
+			)
+			if err != nil {
+				continue
+			}
+
+			logger.Infof(
+				"[member:%v] group signature already broadcast by member [%v]",
+				memberID,
+				message.senderID,
+			)
+			return
+		case <-eligibilityWaiter:
+			broadcastSignature(ctx, memberID, signature, channel)
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func broadcastSignature(
+	ctx context.Context,
+	memberID group.MemberIndex,
+	signature *bn256.G1,
+	channel net.BroadcastChannel,
+) {
+	message := &GroupSignatureMessage{
+		memberID,
+		signature.Marshal(),
+	}
+
+	if err := channel.Send(ctx, message); err != nil {
+		logger.Errorf(
+			"[member:%v] could not send group signature: [%v]",
+			memberID,
+			err,
+		)
+	}
+}
+
+// extractShare unmarshals the signature share from the message and checks
+// that the group public key share of the sender is known, so that the share
+// can be verified.
+func extractShare(
 	message *SignatureShareMessage,
 	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
-	previousEntry *bn256.G1,
 ) (*bn256.G1, error) {
 	share := new(bn256.G1)
 	_, err := share.Unmarshal(message.shareBytes)
@@ -190,19 +372,97 @@ func extractAndValidateShare(
 		)
 	}
 
-	publicKeyShare, ok := groupPublicKeyShares[message.senderID]
-	if !ok {
+	if _, ok := groupPublicKeyShares[message.senderID]; !ok {
 		return nil, fmt.Errorf(
 			"could not validate signature share; " +
 				"group public key share for sender not found",
 		)
 	}
 
-	if !bls.VerifyG1(publicKeyShare, previousEntry, share) {
-		return nil, fmt.Errorf("invalid signature share")
+	return share, nil
+}
+
+// validateShares verifies the given signature shares in a single batch
+// against group public key shares of their senders and returns the valid
+// ones. Invalid shares are attributed to their senders and rejected.
+func validateShares(
+	memberID group.MemberIndex,
+	shares map[group.MemberIndex]*bn256.G1,
+	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
+	previousEntry *bn256.G1,
+) (map[group.MemberIndex]*bn256.G1, error) {
+	senderIDs := make([]group.MemberIndex, 0, len(shares))
+	for senderID := range shares {
+		senderIDs = append(senderIDs, senderID)
 	}
+	sort.Slice(senderIDs, func(i, j int) bool {
+		return senderIDs[i] < senderIDs[j]
+	})
 
-	return share, nil
+	publicKeyShares := make([]*bn256.G2, len(senderIDs))
+	signatureShares := make([]*bn256.G1, len(senderIDs))
+	for i, senderID := range senderIDs {
+		publicKeyShares[i] = groupPublicKeyShares[senderID]
+		signatureShares[i] = shares[senderID]
+	}
+
+	invalidPositions, err := bls.BatchVerifyG1(
+		publicKeyShares,
+		previousEntry,
+		signatureShares,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify signature shares: [%v]", err)
+	}
+
+	invalidSenders := make(map[group.MemberIndex]bool)
+	for _, position := range invalidPositions {
+		invalidSenders[senderIDs[position]] = true
+	}
+
+	validShares := make(map[group.MemberIndex]*bn256.G1)
+	for _, senderID := range senderIDs {
+		if invalidSenders[senderID] {
+			logger.Warningf(
+				"[member:%v] rejecting signature share from "+
+					"member [%v]: [invalid signature share]",
+				memberID,
+				senderID,
+			)
+			continue
+		}
+
+		logger.Debugf(
+			"[member:%v] accepting signature share from member [%v]",
+			memberID,
+			senderID,
+		)
+
+		validShares[senderID] = shares[senderID]
+	}
+
+	return validShares, nil
+}
+
+func extractAndValidateSignature(
+	message *GroupSignatureMessage,
+	groupPublicKey *bn256.G2,
+	previousEntry *bn256.G1,
+) (*bn256.G1, error) {
+	signature := new(bn256.G1)
+	_, err := signature.Unmarshal(message.signatureBytes)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal group signature: [%v]",
+			err,
+		)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntry, signature) {
+		return nil, fmt.Errorf("invalid group signature")
+	}
+
+	return signature, nil
 }
 
 func completeSignature(
@@ -232,3 +492,31 @@ func completeSignature(
 
 	return signature, nil
 }
+
+// Verify checks whether the given relay entry is a valid group signature of
+// the previous entry for the group with the given public key. All parameters
+// are expected in the same serialized form in which they are stored on-chain.
+func Verify(entry, previousEntry, groupPublicKey []byte) (bool, error) {
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(entry); err != nil {
+		return false, fmt.Errorf("could not unmarshal entry: [%v]", err)
+	}
+
+	message := new(bn256.G1)
+	if _, err := message.Unmarshal(previousEntry); err != nil {
+		return false, fmt.Errorf(
+			"could not unmarshal previous entry: [%v]",
+			err,
+		)
+	}
+
+	publicKey := new(bn256.G2)
+	if _, err := publicKey.Unmarshal(groupPublicKey); err != nil {
+		return false, fmt.Errorf(
+			"could not unmarshal group public key: [%v]",
+			err,
+		)
+	}
+
+	return bls.VerifyG1(publicKey, message, signature), nil
+}
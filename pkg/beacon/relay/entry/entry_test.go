@@ -0,0 +1,283 @@
+package entry
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestVerify(t *testing.T) {
+	secretKey := big.NewInt(123)
+	publicKey := new(bn256.G2).ScalarBaseMult(secretKey).Marshal()
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	entry := bls.SignG1(secretKey, previousEntry).Marshal()
+
+	otherEntry := bls.SignG1(big.NewInt(789), previousEntry).Marshal()
+
+	var tests = map[string]struct {
+		entry          []byte
+		expectedResult bool
+		expectedError  bool
+	}{
+		"valid entry": {
+			entry:          entry,
+			expectedResult: true,
+		},
+		"entry signed with other key": {
+			entry:          otherEntry,
+			expectedResult: false,
+		},
+		"malformed entry": {
+			entry:         entry[:10],
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			result, err := Verify(
+				test.entry,
+				previousEntry.Marshal(),
+				publicKey,
+			)
+
+			if test.expectedError != (err != nil) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			if result != test.expectedResult {
+				t.Errorf(
+					"unexpected verification result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
+
+func TestExtractAndValidateSignature(t *testing.T) {
+	secretKey := big.NewInt(123)
+	publicKey := new(bn256.G2).ScalarBaseMult(secretKey)
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	signature := bls.SignG1(secretKey, previousEntry).Marshal()
+
+	otherSignature := bls.SignG1(big.NewInt(789), previousEntry).Marshal()
+
+	var tests = map[string]struct {
+		signature     []byte
+		expectedError bool
+	}{
+		"valid signature": {
+			signature: signature,
+		},
+		"signature made with other key": {
+			signature:     otherSignature,
+			expectedError: true,
+		},
+		"malformed signature": {
+			signature:     signature[:10],
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			result, err := extractAndValidateSignature(
+				NewGroupSignatureMessage(2, test.signature),
+				publicKey,
+				previousEntry,
+			)
+
+			if test.expectedError != (err != nil) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			if !test.expectedError && string(result.Marshal()) != string(signature) {
+				t.Errorf(
+					"unexpected signature\nexpected: [%x]\nactual:   [%x]",
+					signature,
+					result.Marshal(),
+				)
+			}
+		})
+	}
+}
+
+func TestValidateShares(t *testing.T) {
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	shares := make(map[group.MemberIndex]*bn256.G1)
+	for memberIndex := group.MemberIndex(1); memberIndex <= 5; memberIndex++ {
+		secretKeyShare := big.NewInt(int64(100 + memberIndex))
+		groupPublicKeyShares[memberIndex] = new(bn256.G2).ScalarBaseMult(
+			secretKeyShare,
+		)
+		shares[memberIndex] = bls.SignG1(secretKeyShare, previousEntry)
+	}
+
+	// Member 4 sends the share of member 2.
+	shares[4] = shares[2]
+
+	validShares, err := validateShares(
+		1,
+		shares,
+		groupPublicKeyShares,
+		previousEntry,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, memberIndex := range []group.MemberIndex{1, 2, 3, 5} {
+		if _, ok := validShares[memberIndex]; !ok {
+			t.Errorf("expected share from member [%v] to be valid", memberIndex)
+		}
+	}
+
+	if _, ok := validShares[4]; ok {
+		t.Errorf("expected share from member [4] to be rejected")
+	}
+}
+
+func TestBroadcastSignatureWhenEligible(t *testing.T) {
+	secretKey := big.NewInt(123)
+	publicKey := new(bn256.G2).ScalarBaseMult(secretKey)
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	signature := bls.SignG1(secretKey, previousEntry)
+
+	otherSignature := bls.SignG1(big.NewInt(789), previousEntry)
+
+	var tests = map[string]struct {
+		received          []net.Message
+		expectedBroadcast bool
+	}{
+		"no valid signature received from other members": {
+			received: []net.Message{
+				&mockGroupSignatureMessage{
+					NewGroupSignatureMessage(2, otherSignature.Marshal()),
+				},
+				&mockGroupSignatureMessage{
+					NewGroupSignatureMessage(1, signature.Marshal()),
+				},
+			},
+			expectedBroadcast: true,
+		},
+		"valid signature received": {
+			received: []net.Message{
+				&mockGroupSignatureMessage{
+					NewGroupSignatureMessage(2, signature.Marshal()),
+				},
+			},
+			expectedBroadcast: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			channel := &mockBroadcastChannel{}
+
+			receiveChannel := make(chan net.Message, len(test.received))
+			for _, message := range test.received {
+				receiveChannel <- message
+			}
+
+			eligibilityWaiter := make(chan uint64, 1)
+			done := make(chan struct{})
+			go func() {
+				broadcastSignatureWhenEligible(
+					context.Background(),
+					1,
+					signature,
+					publicKey,
+					previousEntry,
+					channel,
+					receiveChannel,
+					eligibilityWaiter,
+				)
+				close(done)
+			}()
+
+			// Let all the received messages be processed before the member
+			// becomes eligible.
+			for len(receiveChannel) > 0 {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(10 * time.Millisecond)
+			eligibilityWaiter <- 10
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("signature broadcast did not complete")
+			}
+
+			if test.expectedBroadcast != (len(channel.sent) == 1) {
+				t.Errorf(
+					"unexpected broadcast\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBroadcast,
+					len(channel.sent) == 1,
+				)
+			}
+		})
+	}
+}
+
+type mockGroupSignatureMessage struct {
+	payload *GroupSignatureMessage
+}
+
+func (mgsm *mockGroupSignatureMessage) TransportSenderID() net.TransportIdentifier {
+	panic("not implemented")
+}
+func (mgsm *mockGroupSignatureMessage) Payload() interface{} {
+	return mgsm.payload
+}
+func (mgsm *mockGroupSignatureMessage) Type() string {
+	panic("not implemented")
+}
+func (mgsm *mockGroupSignatureMessage) SenderPublicKey() []byte {
+	panic("not implemented")
+}
+func (mgsm *mockGroupSignatureMessage) Seqno() uint64 {
+	panic("not implemented")
+}
+
+type mockBroadcastChannel struct {
+	mutex sync.Mutex
+	sent  []net.TaggedMarshaler
+}
+
+func (mbc *mockBroadcastChannel) Name() string {
+	return "mock"
+}
+func (mbc *mockBroadcastChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	mbc.mutex.Lock()
+	defer mbc.mutex.Unlock()
+
+	mbc.sent = append(mbc.sent, message)
+	return nil
+}
+func (mbc *mockBroadcastChannel) Recv(context.Context, func(net.Message)) {
+	panic("not implemented")
+}
+func (mbc *mockBroadcastChannel) SetUnmarshaler(func() net.TaggedUnmarshaler) {
+	panic("not implemented")
+}
+func (mbc *mockBroadcastChannel) SetFilter(net.BroadcastChannelFilter) error {
+	panic("not implemented")
+}
@@ -52,3 +52,37 @@ func (ssm *SignatureShareMessage) Unmarshal(bytes []byte) error {
 
 	return nil
 }
+
+// Type returns a string describing a GroupSignatureMessage's type.
+func (*GroupSignatureMessage) Type() string {
+	return "relay/signature/group"
+}
+
+// Marshal converts this GroupSignatureMessage to a byte array suitable for
+// network communication.
+func (gsm *GroupSignatureMessage) Marshal() ([]byte, error) {
+	pbGroupSignature := pb.GroupSignature{
+		SenderID:  uint32(gsm.senderID),
+		Signature: gsm.signatureBytes,
+	}
+
+	return pbGroupSignature.Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a
+// GroupSignatureMessage.
+func (gsm *GroupSignatureMessage) Unmarshal(bytes []byte) error {
+	pbGroupSignature := pb.GroupSignature{}
+	err := pbGroupSignature.Unmarshal(bytes)
+	if err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbGroupSignature.SenderID); err != nil {
+		return err
+	}
+	gsm.senderID = group.MemberIndex(pbGroupSignature.SenderID)
+	gsm.signatureBytes = pbGroupSignature.Signature
+
+	return nil
+}
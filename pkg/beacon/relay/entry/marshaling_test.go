@@ -54,3 +54,48 @@ func TestFuzzSignatureShareMessageRoundtrip(t *testing.T) {
 func TestFuzzSignatureShareMessageUnmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&SignatureShareMessage{})
 }
+
+func TestGroupSignatureMessageRoundTrip(t *testing.T) {
+	msg := &GroupSignatureMessage{123, []byte{1, 2, 3}}
+	unmarshaled := &GroupSignatureMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.senderID != unmarshaled.senderID {
+		t.Errorf(
+			"unexpected sender ID\nexpected: [%v]\nactual:   [%v]",
+			msg.senderID,
+			unmarshaled.senderID,
+		)
+	}
+
+	testutils.AssertBytesEqual(t, msg.signatureBytes, unmarshaled.signatureBytes)
+}
+
+func TestFuzzGroupSignatureMessageRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID       group.MemberIndex
+			signatureBytes []byte
+		)
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&signatureBytes)
+
+		message := &GroupSignatureMessage{
+			senderID:       senderID,
+			signatureBytes: signatureBytes,
+		}
+
+		_ = pbutils.RoundTrip(message, &GroupSignatureMessage{})
+	}
+}
+
+func TestFuzzGroupSignatureMessageUnmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&GroupSignatureMessage{})
+}
@@ -23,3 +23,24 @@ func NewSignatureShareMessage(
 func (ssm *SignatureShareMessage) SenderID() group.MemberIndex {
 	return ssm.senderID
 }
+
+// GroupSignatureMessage is a message payload that carries the group signature
+// restored by the sender from the signature shares, so that other members can
+// stop collecting the shares.
+type GroupSignatureMessage struct {
+	senderID       group.MemberIndex
+	signatureBytes []byte
+}
+
+// NewGroupSignatureMessage creates new GroupSignatureMessage.
+func NewGroupSignatureMessage(
+	senderID group.MemberIndex,
+	signatureBytes []byte,
+) *GroupSignatureMessage {
+	return &GroupSignatureMessage{senderID, signatureBytes}
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (gsm *GroupSignatureMessage) SenderID() group.MemberIndex {
+	return gsm.senderID
+}
@@ -124,10 +124,11 @@ func (res *relayEntrySubmitter) waitForSubmissionEligibility(
 	startBlockHeight uint64,
 	blockStep uint64,
 ) (<-chan uint64, error) {
-	// (member_index - 1) * T_step
-	blockWaitTime := (uint64(res.index) - 1) * blockStep
-
-	eligibleBlockHeight := startBlockHeight + blockWaitTime
+	eligibleBlockHeight := SubmissionEligibilityBlock(
+		startBlockHeight,
+		res.index,
+		blockStep,
+	)
 	logger.Infof(
 		"[member:%v] waiting for block [%v] to submit",
 		res.index,
@@ -141,3 +142,17 @@ func (res *relayEntrySubmitter) waitForSubmissionEligibility(
 
 	return waiter, err
 }
+
+// SubmissionEligibilityBlock returns the block at which the group member with
+// the given index becomes eligible to submit the relay entry for the request
+// started at the given block.
+func SubmissionEligibilityBlock(
+	startBlockHeight uint64,
+	memberIndex group.MemberIndex,
+	blockStep uint64,
+) uint64 {
+	// (member_index - 1) * T_step
+	blockWaitTime := (uint64(memberIndex) - 1) * blockStep
+
+	return startBlockHeight + blockWaitTime
+}
@@ -0,0 +1,40 @@
+package entry
+
+import (
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+func TestSubmissionEligibilityBlock(t *testing.T) {
+	var tests = map[string]struct {
+		memberIndex   group.MemberIndex
+		expectedBlock uint64
+	}{
+		"first member": {
+			memberIndex:   1,
+			expectedBlock: 100,
+		},
+		"second member": {
+			memberIndex:   2,
+			expectedBlock: 103,
+		},
+		"fifth member": {
+			memberIndex:   5,
+			expectedBlock: 112,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			block := SubmissionEligibilityBlock(100, test.memberIndex, 3)
+			if block != test.expectedBlock {
+				t.Errorf(
+					"unexpected eligibility block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBlock,
+					block,
+				)
+			}
+		})
+	}
+}
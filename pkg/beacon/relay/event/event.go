@@ -17,8 +17,33 @@ type EntrySubmitted struct {
 // EntryGenerated indicates that new relay entry has ben generated by threshold
 // relay. This event is intended to be used by threshold relay consumers.
 type EntryGenerated struct {
-	Value       *big.Int
+	RequestID *big.Int
+	Value     *big.Int
+	// Entry is the group signature submitted to the chain. Value is the
+	// number derived from it.
+	Entry       []byte
 	BlockNumber uint64
+
+	// PreviousEntry and GroupPublicKey describe the signing request of the
+	// group which generated the entry. If the group selected for the relay
+	// request did not submit the entry on time, the request is repeated for
+	// another group and the group public key differs from the one in the
+	// relay request.
+	PreviousEntry  []byte
+	GroupPublicKey []byte
+
+	// Request describes the relay request the entry has been generated for.
+	Request *EntryRequest
+}
+
+// EntryRequest describes a relay request submitted by a threshold relay
+// consumer.
+type EntryRequest struct {
+	TransactionHash string
+	Fee             *big.Int
+	PreviousEntry   []byte
+	GroupPublicKey  []byte
+	BlockNumber     uint64
 }
 
 // Request represents a request for an entry in the threshold relay.
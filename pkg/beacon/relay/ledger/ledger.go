@@ -0,0 +1,234 @@
+// Package ledger contains the participation ledger: an append-only local
+// record of every protocol session the node took part in - ticket submissions
+// for group selection, DKG executions and relay entry signings - along with
+// their outcome and the transactions sent. The ledger survives client
+// restarts and lets the operator prove the participation and investigate
+// missed work.
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+var logger = log.Logger("keep-ledger")
+
+// DirectoryName is the name of the directory, relative to the client data
+// directory, in which the ledger is stored. The ledger is kept apart from the
+// group registry data so that it never gets read as a group membership.
+const DirectoryName = "ledger"
+
+// Types of recorded protocol sessions.
+const (
+	TicketSubmissionSession = "ticket-submission"
+	DKGSession              = "dkg"
+	SigningSession          = "signing"
+)
+
+// Outcomes of recorded protocol sessions.
+const (
+	// OutcomeSelected means at least one of the node's virtual stakers has
+	// been selected to the candidate group.
+	OutcomeSelected = "selected"
+	// OutcomeNotSelected means none of the node's virtual stakers has been
+	// selected to the candidate group.
+	OutcomeNotSelected = "not-selected"
+	// OutcomeJoined means the member completed DKG and joined the group.
+	OutcomeJoined = "joined"
+	// OutcomeSigned means the relay entry has been produced and published
+	// on-chain, either by the member or by another member of the group.
+	OutcomeSigned = "signed"
+	// OutcomeFailed means the session ended with an error.
+	OutcomeFailed = "failed"
+)
+
+// Kinds of recorded transactions.
+const (
+	TicketTransaction     = "ticket"
+	DKGResultTransaction  = "dkg-result"
+	RelayEntryTransaction = "relay-entry"
+)
+
+// Statuses of recorded transactions.
+const (
+	TransactionPending = "pending"
+	TransactionMined   = "mined"
+	TransactionFailed  = "failed"
+)
+
+// Record describes a single protocol session.
+type Record struct {
+	// Type is the type of the session, one of TicketSubmissionSession,
+	// DKGSession and SigningSession.
+	Type string `json:"type"`
+	// MemberIndexes are the indexes of the node's members taking part in the
+	// session. For ticket submissions, these are the indexes of the node's
+	// virtual stakers selected to the candidate group.
+	MemberIndexes []int `json:"memberIndexes"`
+	// Seed is the hex-encoded group selection seed. Set for ticket
+	// submission and DKG sessions.
+	Seed string `json:"seed,omitempty"`
+	// GroupPublicKey is the hex-encoded public key of the group. Set for
+	// signing sessions and for DKG sessions which generated the key.
+	GroupPublicKey string `json:"groupPublicKey,omitempty"`
+
+	StartBlock uint64    `json:"startBlock"`
+	EndBlock   uint64    `json:"endBlock"`
+	StartedAt  time.Time `json:"startedAt"`
+	EndedAt    time.Time `json:"endedAt"`
+
+	// Outcome is the outcome of the session and Error describes why the
+	// session failed, if it did.
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+
+	// InactiveMembers and DisqualifiedMembers are the members of the group
+	// this node observed as inactive and disqualified during DKG.
+	InactiveMembers     []int `json:"inactiveMembers,omitempty"`
+	DisqualifiedMembers []int `json:"disqualifiedMembers,omitempty"`
+
+	// Transactions are the transactions the node sent during the session.
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// Transaction describes a single transaction sent during a session.
+type Transaction struct {
+	Kind string `json:"kind"`
+	// SubmittedAtBlock is the block at which the transaction has been sent.
+	SubmittedAtBlock uint64 `json:"submittedAtBlock"`
+	// MinedAtBlock is the block of the event emitted by the transaction.
+	// Set only for mined transactions emitting an event.
+	MinedAtBlock uint64 `json:"minedAtBlock,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Ledger is the append-only store of session records. Records are never
+// modified nor removed once appended.
+type Ledger struct {
+	mutex  sync.Mutex
+	handle persistence.Handle
+}
+
+// NewLedger creates a new ledger storing records in the given persistence
+// handle. The handle should not be shared with the group registry.
+func NewLedger(handle persistence.Handle) *Ledger {
+	return &Ledger{handle: handle}
+}
+
+// Append stores the given record in the ledger.
+func (l *Ledger) Append(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("could not marshal ledger record: [%v]", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Records are stored in separate files to never rewrite the data
+	// already stored. The start block makes file names sorted in the order
+	// of sessions and the time makes them unique.
+	memberIndexes := make([]string, len(record.MemberIndexes))
+	for i, memberIndex := range record.MemberIndexes {
+		memberIndexes[i] = fmt.Sprint(memberIndex)
+	}
+	name := fmt.Sprintf(
+		"%020d_%v_%d",
+		record.StartBlock,
+		strings.Join(memberIndexes, "-"),
+		time.Now().UnixNano(),
+	)
+
+	if err := l.handle.Save(data, record.Type, name); err != nil {
+		return fmt.Errorf("could not save ledger record: [%v]", err)
+	}
+
+	return nil
+}
+
+// Records reads all the records stored in the ledger, ordered by the start
+// block of the session. It returns the records which have been read
+// successfully and errors for those which could not be read.
+func (l *Ledger) Records() ([]*Record, []error) {
+	return ReadRecords(l.handle)
+}
+
+// ReadRecords reads all the ledger records stored in the given persistence
+// handle, ordered by the start block of the session. It returns the records
+// which have been read successfully and errors for those which could not be
+// read.
+func ReadRecords(handle persistence.Handle) ([]*Record, []error) {
+	dataChannel, errorChannel := handle.ReadAll()
+
+	var (
+		records = make([]*Record, 0)
+		errors  []error
+		wg      sync.WaitGroup
+	)
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for descriptor := range dataChannel {
+			record, err := unmarshalRecord(descriptor)
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+
+			records = append(records, record)
+		}
+	}()
+
+	var readErrors []error
+	go func() {
+		defer wg.Done()
+		for err := range errorChannel {
+			readErrors = append(readErrors, err)
+		}
+	}()
+
+	wg.Wait()
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].StartBlock != records[j].StartBlock {
+			return records[i].StartBlock < records[j].StartBlock
+		}
+		return records[i].StartedAt.Before(records[j].StartedAt)
+	})
+
+	return records, append(errors, readErrors...)
+}
+
+func unmarshalRecord(descriptor persistence.DataDescriptor) (*Record, error) {
+	content, err := descriptor.Content()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read ledger record from file [%v] in directory [%v]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	record := &Record{}
+	if err := json.Unmarshal(content, record); err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal ledger record from file [%v] in directory [%v]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	return record, nil
+}
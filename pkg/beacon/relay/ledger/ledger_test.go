@@ -0,0 +1,220 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+)
+
+func TestAppendAndReadRecords(t *testing.T) {
+	participationLedger := newTestLedger(t)
+
+	records := []*Record{
+		{
+			Type:          SigningSession,
+			MemberIndexes: []int{3},
+			StartBlock:    200,
+			EndBlock:      210,
+			Outcome:       OutcomeSigned,
+			Transactions:  []*Transaction{},
+		},
+		{
+			Type:                DKGSession,
+			MemberIndexes:       []int{3},
+			Seed:                "ff",
+			StartBlock:          100,
+			EndBlock:            150,
+			Outcome:             OutcomeJoined,
+			InactiveMembers:     []int{1},
+			DisqualifiedMembers: []int{2, 4},
+			Transactions: []*Transaction{
+				{
+					Kind:             DKGResultTransaction,
+					SubmittedAtBlock: 140,
+					MinedAtBlock:     141,
+					Status:           TransactionMined,
+				},
+			},
+		},
+	}
+
+	for _, record := range records {
+		if err := participationLedger.Append(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readRecords, errors := participationLedger.Records()
+	if len(errors) > 0 {
+		t.Fatal(errors)
+	}
+
+	expectedRecords := []*Record{records[1], records[0]}
+	if !reflect.DeepEqual(expectedRecords, readRecords) {
+		t.Errorf(
+			"unexpected records\nexpected: [%+v]\nactual:   [%+v]",
+			expectedRecords,
+			readRecords,
+		)
+	}
+}
+
+func TestRecorderRecordsSession(t *testing.T) {
+	participationLedger := newTestLedger(t)
+
+	localChain := local.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := participationLedger.NewRecorder(
+		&Record{
+			Type:          DKGSession,
+			MemberIndexes: []int{2},
+			StartBlock:    1,
+		},
+		blockCounter,
+	)
+
+	relayChain := recorder.Chain(localChain.ThresholdRelay())
+
+	err = waitForEntrySubmission(relayChain.SubmitRelayEntry([]byte{0x01}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failingChain := recorder.Chain(&failingRelayChain{
+		Interface: localChain.ThresholdRelay(),
+	})
+	err = waitForEntrySubmission(failingChain.SubmitRelayEntry([]byte{0x02}))
+	if err == nil {
+		t.Fatal("expected relay entry submission error")
+	}
+
+	dkgGroup := group.NewDkgGroup(2, 5)
+	dkgGroup.MarkMemberAsInactive(4)
+	dkgGroup.MarkMemberAsDisqualified(5)
+	recorder.ObserveGroup(dkgGroup)
+	recorder.SetGroupPublicKey("abcd")
+
+	recorder.Complete(OutcomeJoined)
+	// The session ends only once.
+	recorder.Fail(fmt.Errorf("too late"))
+
+	records, errors := participationLedger.Records()
+	if len(errors) > 0 {
+		t.Fatal(errors)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf(
+			"unexpected number of records\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(records),
+		)
+	}
+
+	record := records[0]
+
+	if record.Outcome != OutcomeJoined || record.Error != "" {
+		t.Errorf(
+			"unexpected outcome\nexpected: [%v]\nactual:   [%v] [%v]",
+			OutcomeJoined,
+			record.Outcome,
+			record.Error,
+		)
+	}
+	if record.GroupPublicKey != "abcd" {
+		t.Errorf("unexpected group public key [%v]", record.GroupPublicKey)
+	}
+	if !reflect.DeepEqual([]int{4}, record.InactiveMembers) {
+		t.Errorf("unexpected inactive members [%v]", record.InactiveMembers)
+	}
+	if !reflect.DeepEqual([]int{5}, record.DisqualifiedMembers) {
+		t.Errorf(
+			"unexpected disqualified members [%v]",
+			record.DisqualifiedMembers,
+		)
+	}
+
+	if len(record.Transactions) != 2 {
+		t.Fatalf(
+			"unexpected number of transactions\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(record.Transactions),
+		)
+	}
+	if record.Transactions[0].Kind != RelayEntryTransaction ||
+		record.Transactions[0].Status != TransactionMined {
+		t.Errorf("unexpected first transaction [%+v]", record.Transactions[0])
+	}
+	if record.Transactions[1].Status != TransactionFailed ||
+		record.Transactions[1].Error != "submission failed" {
+		t.Errorf("unexpected second transaction [%+v]", record.Transactions[1])
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var participationLedger *Ledger
+
+	localChain := local.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := participationLedger.NewRecorder(
+		&Record{Type: SigningSession},
+		blockCounter,
+	)
+	if recorder != nil {
+		t.Fatal("expected nil recorder for nil ledger")
+	}
+
+	relayChain := localChain.ThresholdRelay()
+	if recorder.Chain(relayChain) != relayChain {
+		t.Errorf("expected relay chain not to be wrapped")
+	}
+
+	recorder.ObserveGroup(group.NewDkgGroup(2, 5))
+	recorder.Complete(OutcomeSigned)
+}
+
+func newTestLedger(t *testing.T) *Ledger {
+	handle, err := persistence.NewDiskHandle(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewLedger(handle)
+}
+
+func waitForEntrySubmission(promise *async.EventEntrySubmittedPromise) error {
+	errorChannel := make(chan error, 1)
+	promise.OnComplete(func(_ *event.EntrySubmitted, err error) {
+		errorChannel <- err
+	})
+
+	return <-errorChannel
+}
+
+type failingRelayChain struct {
+	relaychain.Interface
+}
+
+func (frc *failingRelayChain) SubmitRelayEntry(
+	entry []byte,
+) *async.EventEntrySubmittedPromise {
+	promise := &async.EventEntrySubmittedPromise{}
+	promise.Fail(fmt.Errorf("submission failed"))
+	return promise
+}
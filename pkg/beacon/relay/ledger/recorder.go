@@ -0,0 +1,308 @@
+package ledger
+
+import (
+	"sync"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+)
+
+// Recorder collects the information about a single session while it is
+// executed and appends the session record to the ledger once the session
+// ends. All Recorder methods are safe for concurrent use. A nil Recorder
+// records nothing, so that the node can operate without a ledger.
+type Recorder struct {
+	mutex        sync.Mutex
+	ledger       *Ledger
+	blockCounter chain.BlockCounter
+	record       *Record
+	ended        bool
+}
+
+// NewRecorder starts recording the session described by the given record.
+// Type, member indexes, start block and seed or group public key should be
+// already set in the record. If the ledger is nil, the returned recorder is
+// nil as well.
+func (l *Ledger) NewRecorder(
+	record *Record,
+	blockCounter chain.BlockCounter,
+) *Recorder {
+	if l == nil {
+		return nil
+	}
+
+	if record.StartedAt.IsZero() {
+		record.StartedAt = time.Now()
+	}
+	if record.MemberIndexes == nil {
+		record.MemberIndexes = []int{}
+	}
+	record.Transactions = []*Transaction{}
+
+	return &Recorder{
+		ledger:       l,
+		blockCounter: blockCounter,
+		record:       record,
+	}
+}
+
+// MemberIndexes converts member indexes to the form kept in the record.
+func MemberIndexes(memberIndexes []group.MemberIndex) []int {
+	converted := make([]int, len(memberIndexes))
+	for i, memberIndex := range memberIndexes {
+		converted[i] = int(memberIndex)
+	}
+	return converted
+}
+
+// SetMemberIndexes sets the member indexes of the recorded session.
+func (r *Recorder) SetMemberIndexes(memberIndexes []int) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.record.MemberIndexes = memberIndexes
+}
+
+// SetGroupPublicKey sets the hex-encoded public key of the group the session
+// has been executed for.
+func (r *Recorder) SetGroupPublicKey(groupPublicKey string) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.record.GroupPublicKey = groupPublicKey
+}
+
+// ObserveGroup records members of the given group which have been marked as
+// inactive or disqualified.
+func (r *Recorder) ObserveGroup(dkgGroup *group.Group) {
+	if r == nil || dkgGroup == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.record.InactiveMembers = MemberIndexes(dkgGroup.InactiveMemberIDs())
+	r.record.DisqualifiedMembers = MemberIndexes(
+		dkgGroup.DisqualifiedMemberIDs(),
+	)
+}
+
+// Chain returns the relay chain which records all the transactions sent
+// through it as transactions of the session. If the recorder is nil, the
+// given relay chain is returned.
+func (r *Recorder) Chain(relayChain relaychain.Interface) relaychain.Interface {
+	if r == nil {
+		return relayChain
+	}
+
+	return &recordingChain{Interface: relayChain, recorder: r}
+}
+
+// Complete ends the session with the given outcome and appends its record to
+// the ledger.
+func (r *Recorder) Complete(outcome string) {
+	r.end(outcome, nil)
+}
+
+// Fail ends the session with the given error and appends its record to the
+// ledger.
+func (r *Recorder) Fail(err error) {
+	r.end(OutcomeFailed, err)
+}
+
+func (r *Recorder) end(outcome string, err error) {
+	if r == nil {
+		return
+	}
+
+	endBlock, blockErr := r.blockCounter.CurrentBlock()
+	if blockErr != nil {
+		logger.Warningf("could not get the session end block: [%v]", blockErr)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.ended {
+		return
+	}
+	r.ended = true
+
+	r.record.EndBlock = endBlock
+	r.record.EndedAt = time.Now()
+	r.record.Outcome = outcome
+	if err != nil {
+		r.record.Error = err.Error()
+	}
+
+	if appendErr := r.ledger.Append(r.record); appendErr != nil {
+		logger.Errorf(
+			"could not append [%v] session started at block [%v] "+
+				"to the ledger: [%v]",
+			r.record.Type,
+			r.record.StartBlock,
+			appendErr,
+		)
+	}
+}
+
+// beginTransaction records a new pending transaction of the given kind.
+// Transactions completing after the session ended stay pending in the
+// ledger.
+func (r *Recorder) beginTransaction(kind string) *Transaction {
+	submittedAtBlock, err := r.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf(
+			"could not get the [%v] transaction submission block: [%v]",
+			kind,
+			err,
+		)
+	}
+
+	transaction := &Transaction{
+		Kind:             kind,
+		SubmittedAtBlock: submittedAtBlock,
+		Status:           TransactionPending,
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.record.Transactions = append(r.record.Transactions, transaction)
+
+	return transaction
+}
+
+func (r *Recorder) completeTransaction(
+	transaction *Transaction,
+	minedAtBlock uint64,
+	err error,
+) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.ended {
+		return
+	}
+
+	if err != nil {
+		transaction.Status = TransactionFailed
+		transaction.Error = err.Error()
+		return
+	}
+
+	transaction.Status = TransactionMined
+	transaction.MinedAtBlock = minedAtBlock
+}
+
+// recordingChain is the relay chain recording transactions sent during the
+// session. The returned promises are completed only after the transaction
+// has been recorded, so that the session never ends before the transaction
+// it awaits is recorded.
+type recordingChain struct {
+	relaychain.Interface
+
+	recorder *Recorder
+}
+
+func (rc *recordingChain) SubmitTicket(
+	ticket *relaychain.Ticket,
+) *async.EventGroupTicketSubmissionPromise {
+	transaction := rc.recorder.beginTransaction(TicketTransaction)
+	promise := &async.EventGroupTicketSubmissionPromise{}
+
+	rc.Interface.SubmitTicket(ticket).OnComplete(
+		func(submission *event.GroupTicketSubmission, err error) {
+			var minedAtBlock uint64
+			if submission != nil {
+				minedAtBlock = submission.BlockNumber
+			}
+			rc.recorder.completeTransaction(transaction, minedAtBlock, err)
+
+			if err != nil {
+				if failErr := promise.Fail(err); failErr != nil {
+					logger.Errorf("could not fail promise: [%v]", failErr)
+				}
+				return
+			}
+			if err := promise.Fulfill(submission); err != nil {
+				logger.Errorf("could not fulfill promise: [%v]", err)
+			}
+		},
+	)
+
+	return promise
+}
+
+func (rc *recordingChain) SubmitDKGResult(
+	participantIndex relaychain.GroupMemberIndex,
+	dkgResult *relaychain.DKGResult,
+	signatures map[relaychain.GroupMemberIndex][]byte,
+) *async.EventDKGResultSubmissionPromise {
+	transaction := rc.recorder.beginTransaction(DKGResultTransaction)
+	promise := &async.EventDKGResultSubmissionPromise{}
+
+	rc.Interface.SubmitDKGResult(participantIndex, dkgResult, signatures).OnComplete(
+		func(submission *event.DKGResultSubmission, err error) {
+			var minedAtBlock uint64
+			if submission != nil {
+				minedAtBlock = submission.BlockNumber
+			}
+			rc.recorder.completeTransaction(transaction, minedAtBlock, err)
+
+			if err != nil {
+				if failErr := promise.Fail(err); failErr != nil {
+					logger.Errorf("could not fail promise: [%v]", failErr)
+				}
+				return
+			}
+			if err := promise.Fulfill(submission); err != nil {
+				logger.Errorf("could not fulfill promise: [%v]", err)
+			}
+		},
+	)
+
+	return promise
+}
+
+func (rc *recordingChain) SubmitRelayEntry(
+	entry []byte,
+) *async.EventEntrySubmittedPromise {
+	transaction := rc.recorder.beginTransaction(RelayEntryTransaction)
+	promise := &async.EventEntrySubmittedPromise{}
+
+	rc.Interface.SubmitRelayEntry(entry).OnComplete(
+		func(submission *event.EntrySubmitted, err error) {
+			var minedAtBlock uint64
+			if submission != nil {
+				minedAtBlock = submission.BlockNumber
+			}
+			rc.recorder.completeTransaction(transaction, minedAtBlock, err)
+
+			if err != nil {
+				if failErr := promise.Fail(err); failErr != nil {
+					logger.Errorf("could not fail promise: [%v]", failErr)
+				}
+				return
+			}
+			if err := promise.Fulfill(submission); err != nil {
+				logger.Errorf("could not fulfill promise: [%v]", err)
+			}
+		},
+	)
+
+	return promise
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -16,7 +17,9 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/reliability"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
@@ -35,6 +38,34 @@ type Node struct {
 	chainConfig  *relaychain.Config
 
 	groupRegistry *registry.Groups
+
+	// ledger records DKG and relay entry signing sessions executed by this
+	// node. It is nil if the sessions should not be recorded.
+	ledger *ledger.Ledger
+
+	// reliability keeps statistics of the behaviour of other operators
+	// observed in DKG and relay entry signing executed by this node.
+	reliability *reliability.Tracker
+
+	// sessions tracks DKG and relay entry signing executions started by
+	// this node and inFlightWork allows to await their completion when the
+	// client shuts down. Once shuttingDown is set, no new executions are
+	// started.
+	sessionsMutex sync.Mutex
+	sessions      map[*Session]bool
+	inFlightWork  sync.WaitGroup
+	shuttingDown  bool
+}
+
+// GroupRegistry returns the registry of groups this node is a member of.
+func (n *Node) GroupRegistry() *registry.Groups {
+	return n.groupRegistry
+}
+
+// Reliability returns the statistics of the behaviour of other operators
+// observed by this node.
+func (n *Node) Reliability() *reliability.Tracker {
+	return n.reliability
 }
 
 // IsInGroup checks if this node is a member of the group which was selected to
@@ -100,12 +131,45 @@ func (n *Node) JoinGroupIfEligible(
 			)
 		}
 
+		// All our members observe the same group, so inactive and
+		// disqualified members are counted in reliability statistics only
+		// once.
+		var observeDKGOnce sync.Once
+
 		for _, index := range indexes {
 			// capture player index for goroutine
 			playerIndex := index
 
+			endSession, err := n.beginSession(&Session{
+				Type:        DKGSession,
+				MemberIndex: group.MemberIndex(playerIndex + 1),
+				Seed:        newEntry,
+				StartBlock:  dkgStartBlockHeight,
+				StartedAt:   time.Now(),
+			})
+			if err != nil {
+				logger.Warningf(
+					"not starting DKG of member [%v]: [%v]",
+					playerIndex+1,
+					err,
+				)
+				continue
+			}
+
+			recorder := n.ledger.NewRecorder(
+				&ledger.Record{
+					Type:          ledger.DKGSession,
+					MemberIndexes: []int{int(playerIndex) + 1},
+					Seed:          newEntry.Text(16),
+					StartBlock:    dkgStartBlockHeight,
+				},
+				n.blockCounter,
+			)
+
 			go func() {
-				signer, err := dkg.ExecuteDKG(
+				defer endSession()
+
+				signer, dkgGroup, err := dkg.ExecuteDKG(
 					newEntry,
 					playerIndex,
 					n.chainConfig.GroupSize,
@@ -113,15 +177,29 @@ func (n *Node) JoinGroupIfEligible(
 					membershipValidator,
 					dkgStartBlockHeight,
 					n.blockCounter,
-					relayChain,
+					recorder.Chain(relayChain),
 					signing,
 					broadcastChannel,
 				)
+				recorder.ObserveGroup(dkgGroup)
+				if dkgGroup != nil {
+					observeDKGOnce.Do(func() {
+						n.reliability.ObserveDKG(
+							groupSelectionResult.SelectedStakers,
+							dkgGroup,
+						)
+					})
+				}
 				if err != nil {
 					logger.Errorf("failed to execute dkg: [%v]", err)
+					recorder.Fail(err)
 					return
 				}
 
+				recorder.SetGroupPublicKey(
+					hex.EncodeToString(signer.GroupPublicKeyBytesCompressed()),
+				)
+
 				// final broadcast channel name for group is the compressed
 				// public key of the group
 				channelName := hex.EncodeToString(
@@ -131,8 +209,12 @@ func (n *Node) JoinGroupIfEligible(
 				err = n.groupRegistry.RegisterGroup(signer, channelName)
 				if err != nil {
 					logger.Errorf("failed to register a group: [%v]", err)
+					recorder.Fail(err)
+					return
 				}
 
+				recorder.Complete(ledger.OutcomeJoined)
+
 				logger.Infof(
 					"[member:%v] ready to operate in the group",
 					signer.MemberID(),
@@ -144,17 +226,54 @@ func (n *Node) JoinGroupIfEligible(
 	return
 }
 
+// WaitForInFlightWork blocks until all DKG and relay entry signing
+// executions started by this node complete or until the given timeout
+// elapses. No new executions are started once the wait begins. It returns
+// an error if the timeout elapsed before all the work has been completed.
+func (n *Node) WaitForInFlightWork(timeout time.Duration) error {
+	n.sessionsMutex.Lock()
+	n.shuttingDown = true
+	n.sessionsMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.inFlightWork.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf(
+			"in-flight work did not complete within [%v]",
+			timeout,
+		)
+	}
+}
+
 // ForwardSignatureShares enables the ability to forward signature shares
 // messages to other nodes even if this node is not a part of the group which
 // signs the relay entry.
 func (n *Node) ForwardSignatureShares(groupPublicKeyBytes []byte) {
+	ForwardSignatureShares(n.netProvider, groupPublicKeyBytes)
+}
+
+// ForwardSignatureShares enables the ability to forward signature shares
+// messages of the group with the given public key through the provider. It
+// is meant for nodes which are not a part of any group, such as bootstrap
+// nodes.
+func ForwardSignatureShares(
+	netProvider net.Provider,
+	groupPublicKeyBytes []byte,
+) {
 	name, err := channelNameForPublicKeyBytes(groupPublicKeyBytes)
 	if err != nil {
 		logger.Warningf("could not forward signature shares: [%v]", err)
 		return
 	}
 
-	n.netProvider.BroadcastChannelForwarderFor(name)
+	netProvider.BroadcastChannelForwarderFor(name)
 }
 
 // ResumeSigningIfEligible enables a client to rejoin the ongoing signing process
@@ -0,0 +1,98 @@
+package relay
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitForInFlightWork_WorkCompleted(t *testing.T) {
+	node := &Node{}
+
+	endSession, err := node.beginSession(&Session{Type: SigningSession})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		endSession()
+	}()
+
+	err = node.WaitForInFlightWork(1 * time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestWaitForInFlightWork_Timeout(t *testing.T) {
+	node := &Node{}
+
+	endSession, err := node.beginSession(&Session{Type: DKGSession})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer endSession()
+
+	err = node.WaitForInFlightWork(50 * time.Millisecond)
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+}
+
+func TestWaitForInFlightWork_RefusesNewSessions(t *testing.T) {
+	node := &Node{}
+
+	err := node.WaitForInFlightWork(1 * time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	if _, err := node.beginSession(&Session{Type: DKGSession}); err == nil {
+		t.Fatal("expected session to be refused while shutting down")
+	}
+
+	if sessions := node.InFlightSessions(); len(sessions) != 0 {
+		t.Errorf("refused session should not be in-flight")
+	}
+}
+
+func TestInFlightSessions(t *testing.T) {
+	node := &Node{}
+
+	now := time.Now()
+	dkgSession := &Session{Type: DKGSession, StartedAt: now}
+	signingSession := &Session{Type: SigningSession, StartedAt: now.Add(-time.Minute)}
+
+	endDkgSession, err := node.beginSession(dkgSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endSigningSession, err := node.beginSession(signingSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := node.InFlightSessions()
+	if len(sessions) != 2 {
+		t.Fatalf(
+			"unexpected number of sessions\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(sessions),
+		)
+	}
+	if sessions[0] != signingSession || sessions[1] != dkgSession {
+		t.Errorf("sessions are not ordered by their start time")
+	}
+
+	endSigningSession()
+
+	sessions = node.InFlightSessions()
+	if len(sessions) != 1 || sessions[0] != dkgSession {
+		t.Errorf("completed session should not be in-flight")
+	}
+
+	endDkgSession()
+
+	if sessions := node.InFlightSessions(); len(sessions) != 0 {
+		t.Errorf("no session should be in-flight")
+	}
+}
@@ -78,6 +78,20 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	return g.myGroups[groupKeyToString(groupPublicKey)]
 }
 
+// GetGroups gets all the groups the client is a member of. The returned map
+// is keyed by the hex-encoded, uncompressed group public key.
+func (g *Groups) GetGroups() map[string][]*Membership {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	groups := make(map[string][]*Membership, len(g.myGroups))
+	for groupPublicKey, memberships := range g.myGroups {
+		groups[groupPublicKey] = append([]*Membership{}, memberships...)
+	}
+
+	return groups
+}
+
 // UnregisterStaleGroups lookup for groups that have been marked as stale
 // on-chain. A stale group is a group that has expired and a certain time passed
 // after the group expiration. This guarantees the group will not be selected to
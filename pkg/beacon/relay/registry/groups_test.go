@@ -75,6 +75,47 @@ func TestRegisterGroup(t *testing.T) {
 	}
 }
 
+func TestGetGroups(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock)
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName1)
+	gr.RegisterGroup(signer3, channelName2)
+
+	groups := gr.GetGroups()
+
+	if len(groups) != 3 {
+		t.Fatalf(
+			"Unexpected number of groups \nExpected: [%+v]\nActual:   [%+v]",
+			3,
+			len(groups),
+		)
+	}
+
+	memberships := groups[hex.EncodeToString(signer3.GroupPublicKeyBytes())]
+	if len(memberships) != 1 || memberships[0].ChannelName != channelName2 {
+		t.Errorf("Unexpected memberships for group: [%+v]", memberships)
+	}
+}
+
+func TestReadMemberships(t *testing.T) {
+	memberships, errors := ReadMemberships(persistenceMock)
+
+	if len(errors) != 0 {
+		t.Fatalf("unexpected errors: [%v]", errors)
+	}
+
+	if len(memberships) != 3 {
+		t.Fatalf(
+			"Unexpected number of memberships \nExpected: [%+v]\nActual:   [%+v]",
+			3,
+			len(memberships),
+		)
+	}
+}
+
 func TestLoadGroup(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
 	gr := NewGroupRegistry(chain, persistenceMock)
@@ -25,6 +25,39 @@ func newStorage(persistence persistence.Handle) storage {
 	}
 }
 
+// ReadMemberships reads all the memberships stored in the given persistence
+// handle. It returns the memberships which have been read successfully and
+// errors for those which could not be read.
+func ReadMemberships(persistence persistence.Handle) ([]*Membership, []error) {
+	membershipsChannel, errorsChannel := newStorage(persistence).readAll()
+
+	var (
+		memberships []*Membership
+		errors      []error
+		wg          sync.WaitGroup
+	)
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for membership := range membershipsChannel {
+			memberships = append(memberships, membership)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for err := range errorsChannel {
+			errors = append(errors, err)
+		}
+	}()
+
+	wg.Wait()
+
+	return memberships, errors
+}
+
 func (ps *persistentStorage) save(membership *Membership) error {
 	membershipBytes, err := membership.Marshal()
 	if err != nil {
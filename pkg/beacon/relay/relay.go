@@ -1,12 +1,22 @@
 package relay
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"time"
+
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/reliability"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
@@ -18,13 +28,16 @@ var logger = log.Logger("keep-relay")
 const maxGroupSize = 255
 
 // NewNode returns an empty Node with no group, zero group count, and a nil last
-// seen entry, tied to the given net.Provider.
+// seen entry, tied to the given net.Provider. DKG and relay entry signing
+// sessions executed by the node are recorded in the given ledger unless it
+// is nil.
 func NewNode(
 	staker chain.Staker,
 	netProvider net.Provider,
 	blockCounter chain.BlockCounter,
 	chainConfig *relayChain.Config,
 	groupRegistry *registry.Groups,
+	participationLedger *ledger.Ledger,
 ) Node {
 	return Node{
 		Staker:        staker,
@@ -32,6 +45,8 @@ func NewNode(
 		blockCounter:  blockCounter,
 		chainConfig:   chainConfig,
 		groupRegistry: groupRegistry,
+		ledger:        participationLedger,
+		reliability:   reliability.NewTracker(staker.Address()),
 	}
 }
 
@@ -39,6 +54,11 @@ func NewNode(
 // When a processing group which is supposed to deliver a relay entry does not
 // fulfill its work, then this Node notifies the chain about it. In the case of
 // delivering a relay entry by a processing group, this Node does nothing.
+//
+// Nodes do not report the timeout all at once. Each node becomes eligible to
+// report it at a block determined by the hash of its address and the request,
+// see TimeoutReportingEligibilityBlock, and reports only if no other node has
+// done it before.
 func (n *Node) MonitorRelayEntry(
 	relayChain relayChain.Interface,
 	relayRequestBlockNumber uint64,
@@ -46,32 +66,42 @@ func (n *Node) MonitorRelayEntry(
 ) {
 	logger.Infof("monitoring chain for a new relay entry")
 
-	timeoutWaiterChannel, err := n.blockCounter.BlockHeightWaiter(relayRequestBlockNumber + chainConfig.RelayEntryTimeout)
+	timeoutBlock := relayRequestBlockNumber + chainConfig.RelayEntryTimeout
+
+	timeoutWaiterChannel, err := n.blockCounter.BlockHeightWaiter(timeoutBlock)
 	if err != nil {
 		logger.Errorf("waiter for a relay entry timeout block failed: [%v]", err)
 	}
 
-	onEntrySubmittedChannel := make(chan *event.EntrySubmitted)
+	// Only the first submitted entry matters; the handler never blocks so
+	// that it does not hold up the event subscription once the monitoring
+	// is over.
+	onEntrySubmittedChannel := make(chan *event.EntrySubmitted, 1)
 
 	subscription := relayChain.OnRelayEntrySubmitted(
 		func(event *event.EntrySubmitted) {
-			onEntrySubmittedChannel <- event
+			select {
+			case onEntrySubmittedChannel <- event:
+			default:
+			}
 		},
 	)
+	defer subscription.Unsubscribe()
 
 	for {
 		select {
 		case blockNumber := <-timeoutWaiterChannel:
-			subscription.Unsubscribe()
-			close(onEntrySubmittedChannel)
 			logger.Warningf(
-				"relay entry was not submitted on time, reporting timeout at block [%v]",
+				"relay entry was not submitted on time; timeout reached at "+
+					"block [%v]",
 				blockNumber,
 			)
-			err = relayChain.ReportRelayEntryTimeout()
-			if err != nil {
-				logger.Errorf("could not report a relay entry timeout: [%v]", err)
-			}
+			n.reportRelayEntryTimeout(
+				relayChain,
+				relayRequestBlockNumber,
+				timeoutBlock,
+				chainConfig,
+			)
 			return
 		case entry := <-onEntrySubmittedChannel:
 			logger.Infof(
@@ -83,6 +113,144 @@ func (n *Node) MonitorRelayEntry(
 	}
 }
 
+// reportRelayEntryTimeout reports the relay entry timeout once this node
+// becomes eligible to do it. The node backs off if a new relay request has
+// been seen in the meantime, or if the chain shows that the timed out request
+// is no longer in progress, both meaning the timeout has been already
+// reported by another node.
+func (n *Node) reportRelayEntryTimeout(
+	relayChain relayChain.Interface,
+	relayRequestBlockNumber uint64,
+	timeoutBlock uint64,
+	chainConfig *relayChain.Config,
+) {
+	eligibleBlock := TimeoutReportingEligibilityBlock(
+		timeoutBlock,
+		relayRequestBlockNumber,
+		n.Staker.Address(),
+		chainConfig.GroupSize,
+		chainConfig.ResultPublicationBlockStep,
+	)
+
+	logger.Infof(
+		"waiting for block [%v] to report relay entry timeout",
+		eligibleBlock,
+	)
+
+	eligibleWaiterChannel, err := n.blockCounter.BlockHeightWaiter(eligibleBlock)
+	if err != nil {
+		logger.Errorf(
+			"waiter for a timeout reporting eligibility block failed: [%v]",
+			err,
+		)
+		return
+	}
+
+	onRequestChannel := make(chan *event.Request, 1)
+	subscription := relayChain.OnRelayEntryRequested(
+		func(request *event.Request) {
+			if request.BlockNumber == relayRequestBlockNumber {
+				return
+			}
+
+			select {
+			case onRequestChannel <- request:
+			default:
+			}
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	select {
+	case blockNumber := <-eligibleWaiterChannel:
+		if !isRelayRequestInProgress(relayChain, relayRequestBlockNumber) {
+			logger.Infof(
+				"relay entry timeout already reported; not reporting at "+
+					"block [%v]",
+				blockNumber,
+			)
+			return
+		}
+
+		logger.Infof("reporting relay entry timeout at block [%v]", blockNumber)
+
+		err := relayChain.ReportRelayEntryTimeout()
+		if err != nil {
+			// Check if we failed because someone else reported in the
+			// meantime or because something wrong happened with our
+			// transaction.
+			if !isRelayRequestInProgress(relayChain, relayRequestBlockNumber) {
+				logger.Infof("relay entry timeout already reported")
+				return
+			}
+
+			logger.Errorf("could not report a relay entry timeout: [%v]", err)
+		}
+	case request := <-onRequestChannel:
+		logger.Infof(
+			"relay entry timeout already reported; new relay entry "+
+				"requested at block [%v]",
+			request.BlockNumber,
+		)
+	}
+}
+
+// isRelayRequestInProgress checks on chain if the relay request started at
+// the given block is still in progress and therefore its timeout has not been
+// reported yet. If the chain state could not be read, the request is assumed
+// to be in progress.
+func isRelayRequestInProgress(
+	relayChain relayChain.Interface,
+	relayRequestBlockNumber uint64,
+) bool {
+	isEntryInProgress, err := relayChain.IsEntryInProgress()
+	if err != nil {
+		logger.Warningf("could not check if entry is in progress: [%v]", err)
+		return true
+	}
+	if !isEntryInProgress {
+		return false
+	}
+
+	startBlock, err := relayChain.CurrentRequestStartBlock()
+	if err != nil {
+		logger.Warningf("could not check current request start block: [%v]", err)
+		return true
+	}
+
+	return startBlock.Uint64() == relayRequestBlockNumber
+}
+
+// TimeoutReportingEligibilityBlock returns the block at which the node with
+// the given address becomes eligible to report the timeout of the relay
+// request started at the given block. Nodes are spread over groupSize slots,
+// each blockStep blocks long, starting from the timeout block. The slot of the
+// node is determined by the hash of its address and the request start block,
+// so the order of nodes changes with every request.
+func TimeoutReportingEligibilityBlock(
+	timeoutBlock uint64,
+	relayRequestBlockNumber uint64,
+	address relayChain.StakerAddress,
+	groupSize int,
+	blockStep uint64,
+) uint64 {
+	if groupSize < 1 {
+		return timeoutBlock
+	}
+
+	requestBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(requestBytes, relayRequestBlockNumber)
+
+	hash := sha256.Sum256(append(append([]byte{}, address...), requestBytes...))
+
+	slot := new(big.Int).Mod(
+		new(big.Int).SetBytes(hash[:]),
+		big.NewInt(int64(groupSize)),
+	).Uint64()
+
+	return timeoutBlock + slot*blockStep
+}
+
 // GenerateRelayEntry is triggered for a new relay request and checks if this
 // client is one of the group members selected to create a new relay entry.
 // If it is, this client enters the threshold signature creation process and,
@@ -131,12 +299,90 @@ func (n *Node) GenerateRelayEntry(
 		)
 	}
 
+	// Signature shares delivered by the group members are observed until the
+	// relay entry timeout block, even though our members stop collecting them
+	// as soon as the group signature is restored, so that members slower than
+	// the honest threshold are not considered as not delivering their shares.
+	// Reliability statistics of their operators are updated once both the
+	// signing is over and the timeout block is reached.
+	observerCtx, cancelObserverCtx := context.WithCancel(context.Background())
+	deliveryObserver, err := entry.ObserveShareDelivery(
+		observerCtx,
+		channel,
+		n.blockCounter,
+		memberships[0].Signer.GroupPublicKeyShares(),
+		previousEntry,
+	)
+	if err != nil {
+		cancelObserverCtx()
+		logger.Errorf("could not observe signature shares delivery: [%v]", err)
+		return
+	}
+
+	signingWait := &sync.WaitGroup{}
+	signingWait.Add(len(memberships))
+
+	go func() {
+		signingWait.Wait()
+
+		timeoutWaiterChannel, err := n.blockCounter.BlockHeightWaiter(
+			startBlockHeight + n.chainConfig.RelayEntryTimeout,
+		)
+		if err != nil {
+			logger.Warningf(
+				"waiter for a relay entry timeout block failed: [%v]",
+				err,
+			)
+		} else {
+			<-timeoutWaiterChannel
+		}
+		cancelObserverCtx()
+
+		n.observeShareDelivery(
+			deliveryObserver,
+			groupMembers,
+			startBlockHeight,
+		)
+	}()
+
 	for _, member := range memberships {
+		endSession, err := n.beginSession(&Session{
+			Type:           SigningSession,
+			MemberIndex:    member.Signer.MemberID(),
+			GroupPublicKey: groupPublicKey,
+			StartBlock:     startBlockHeight,
+			StartedAt:      time.Now(),
+		})
+		if err != nil {
+			logger.Warningf(
+				"not starting relay entry signing of member [%v]: [%v]",
+				member.Signer.MemberID(),
+				err,
+			)
+			signingWait.Done()
+			continue
+		}
+
+		recorder := n.ledger.NewRecorder(
+			&ledger.Record{
+				Type:          ledger.SigningSession,
+				MemberIndexes: []int{int(member.Signer.MemberID())},
+				GroupPublicKey: hex.EncodeToString(
+					member.Signer.GroupPublicKeyBytesCompressed(),
+				),
+				StartBlock: startBlockHeight,
+			},
+			n.blockCounter,
+		)
+
 		go func(member *registry.Membership) {
-			err = entry.SignAndSubmit(
+			defer endSession()
+			defer signingWait.Done()
+
+			err := entry.SignAndSubmit(
 				n.blockCounter,
 				channel,
-				relayChain,
+				recorder.Chain(relayChain),
 				previousEntry,
 				n.chainConfig.HonestThreshold,
 				member.Signer,
@@ -147,8 +393,33 @@ func (n *Node) GenerateRelayEntry(
 					"error creating threshold signature: [%v]",
 					err,
 				)
+				recorder.Fail(err)
 				return
 			}
+
+			recorder.Complete(ledger.OutcomeSigned)
 		}(member)
 	}
 }
+
+// observeShareDelivery updates reliability statistics of the operators of the
+// group members with signature shares delivered for the relay entry requested
+// at the given block.
+func (n *Node) observeShareDelivery(
+	deliveryObserver *entry.ShareDeliveryObserver,
+	groupMembers []relayChain.StakerAddress,
+	startBlockHeight uint64,
+) {
+	deliveries := deliveryObserver.Deliveries()
+
+	latencies := make(map[group.MemberIndex]uint64, len(deliveries))
+	for senderID, blockNumber := range deliveries {
+		if blockNumber > startBlockHeight {
+			latencies[senderID] = blockNumber - startBlockHeight
+		} else {
+			latencies[senderID] = 0
+		}
+	}
+
+	n.reliability.ObserveSigning(groupMembers, latencies)
+}
@@ -19,15 +19,13 @@ func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 		fmt.Printf("failed to setup a block counter: [%v]", err)
 	}
 
-	node := &Node{
-		blockCounter: blockCounter,
-	}
+	node := newTestNode(t, chain, address)
 
 	relayChain := chain.ThresholdRelay()
 	chainConfig := &relaychain.Config{
 		RelayEntryTimeout: uint64(relayEntryTimeout),
 	}
-	startBlockHeight, err := blockCounter.CurrentBlock()
+	startBlockHeight, err := chain.RequestRelayEntry(big.NewInt(1).Bytes())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -77,15 +75,13 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		fmt.Printf("failed to setup a block counter: [%v]", err)
 	}
 
-	node := &Node{
-		blockCounter: blockCounter,
-	}
+	node := newTestNode(t, chain, address)
 
 	relayChain := chain.ThresholdRelay()
 	chainConfig := &relaychain.Config{
 		RelayEntryTimeout: uint64(relayEntryTimeout),
 	}
-	startBlockHeight, err := blockCounter.CurrentBlock()
+	startBlockHeight, err := chain.RequestRelayEntry(big.NewInt(1).Bytes())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -121,3 +117,151 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		)
 	}
 }
+
+func TestMonitorRelayEntryOnChain_TimeoutReportedByOtherNode(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := []*Node{
+		newTestNode(t, chain, address),
+		newTestNode(t, chain, "0x3e6a5b8cd2a3a5f1e7a0a0b1e3c5b9d4f1d2e3a4"),
+		newTestNode(t, chain, "0x9b2f4e1a7c6d5b3e8f0a1c2d3e4f5a6b7c8d9e0f"),
+	}
+
+	relayChain := chain.ThresholdRelay()
+	chainConfig := &relaychain.Config{
+		GroupSize:                  3,
+		ResultPublicationBlockStep: 2,
+		RelayEntryTimeout:          uint64(relayEntryTimeout),
+	}
+	startBlockHeight, err := chain.RequestRelayEntry(big.NewInt(1).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, node := range nodes {
+		go node.MonitorRelayEntry(
+			relayChain,
+			startBlockHeight,
+			chainConfig,
+		)
+	}
+
+	// All nodes become eligible to report at most (GroupSize - 1) *
+	// ResultPublicationBlockStep blocks after the timeout. 2 is an arbitrary
+	// number to exceed the last eligibility block.
+	relayEntryTimeoutFromStart := startBlockHeight + relayEntryTimeout
+	blockCounter.WaitForBlockHeight(relayEntryTimeoutFromStart + 4 + 2)
+
+	timeoutsReport := chain.GetRelayEntryTimeoutReports()
+	if len(timeoutsReport) != 1 {
+		t.Fatalf(
+			"Number of timeout reports does not match\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(timeoutsReport),
+		)
+	}
+
+	firstEligibleBlock := relayEntryTimeoutFromStart + 4
+	for _, node := range nodes {
+		eligibleBlock := TimeoutReportingEligibilityBlock(
+			relayEntryTimeoutFromStart,
+			startBlockHeight,
+			node.Staker.Address(),
+			chainConfig.GroupSize,
+			chainConfig.ResultPublicationBlockStep,
+		)
+		if eligibleBlock < firstEligibleBlock {
+			firstEligibleBlock = eligibleBlock
+		}
+	}
+
+	if timeoutsReport[0] != firstEligibleBlock {
+		t.Fatalf(
+			"Timeout must be reported by the first eligible node\nexpected: [%v]\nactual:   [%v]",
+			firstEligibleBlock,
+			timeoutsReport[0],
+		)
+	}
+}
+
+func TestTimeoutReportingEligibilityBlock(t *testing.T) {
+	timeoutBlock := uint64(100)
+	groupSize := 64
+	blockStep := uint64(3)
+	stakerAddress := relaychain.StakerAddress(
+		[]byte("65ea55c1f10491038425725dc00dffeab2a1e28a"),
+	)
+
+	eligibleBlocks := make(map[uint64]bool)
+	for request := uint64(0); request < 20; request++ {
+		eligibleBlock := TimeoutReportingEligibilityBlock(
+			timeoutBlock,
+			request,
+			stakerAddress,
+			groupSize,
+			blockStep,
+		)
+
+		if eligibleBlock < timeoutBlock ||
+			eligibleBlock > timeoutBlock+uint64(groupSize-1)*blockStep {
+			t.Errorf(
+				"eligibility block [%v] out of range for request [%v]",
+				eligibleBlock,
+				request,
+			)
+		}
+		if (eligibleBlock-timeoutBlock)%blockStep != 0 {
+			t.Errorf(
+				"eligibility block [%v] is not at the block step",
+				eligibleBlock,
+			)
+		}
+
+		eligibleBlocks[eligibleBlock] = true
+	}
+
+	if len(eligibleBlocks) < 2 {
+		t.Errorf("expected eligibility block to change with the request")
+	}
+
+	eligibleBlock := TimeoutReportingEligibilityBlock(
+		timeoutBlock,
+		1,
+		stakerAddress,
+		0,
+		blockStep,
+	)
+	if eligibleBlock != timeoutBlock {
+		t.Errorf(
+			"unexpected eligibility block without group size\nexpected: [%v]\nactual:   [%v]",
+			timeoutBlock,
+			eligibleBlock,
+		)
+	}
+}
+
+func newTestNode(t *testing.T, chain chainLocal.Chain, address string) *Node {
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stakeMonitor, err := chain.StakeMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staker, err := stakeMonitor.StakerFor(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Node{
+		Staker:       staker,
+		blockCounter: blockCounter,
+	}
+}
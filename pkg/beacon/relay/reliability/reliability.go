@@ -0,0 +1,303 @@
+// Package reliability keeps rolling statistics of the behaviour of other
+// operators observed by the node in the protocol sessions: whether their
+// members delivered valid signature shares for relay entries and how fast,
+// and whether their members were marked as inactive or disqualified in DKG.
+// The statistics help to find operators which drag groups down.
+package reliability
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// statisticsWindow is the number of the most recent signing and DKG sessions
+// of each operator the statistics are computed from.
+const statisticsWindow = 100
+
+// UnreliableShareDeliveryRate is the share delivery rate below which the
+// operator is considered unreliable.
+const UnreliableShareDeliveryRate = 0.9
+
+// OperatorStatistics describes the behaviour of a single operator observed
+// in the most recent sessions.
+type OperatorStatistics struct {
+	// Address is the hex-encoded chain address of the operator.
+	Address string
+	// SigningSessions is the number of observed relay entry signings the
+	// operator had members in.
+	SigningSessions int
+	// SharesExpected is the number of signature shares expected from the
+	// members of the operator and SharesDelivered is the number of valid
+	// shares they delivered before the signing ended.
+	SharesExpected  int
+	SharesDelivered int
+	// ShareDeliveryRate is the ratio of delivered to expected shares.
+	ShareDeliveryRate float64
+	// AverageShareLatencyBlocks is the average number of blocks between the
+	// relay entry request and the delivery of a share.
+	AverageShareLatencyBlocks float64
+	// DKGSessions is the number of observed DKGs the operator had members in.
+	DKGSessions int
+	// DKGInactiveMembers and DKGDisqualifiedMembers are the numbers of the
+	// members of the operator marked as inactive and disqualified in the
+	// observed DKGs.
+	DKGInactiveMembers     int
+	DKGDisqualifiedMembers int
+	// Unreliable is set if the share delivery rate is below
+	// UnreliableShareDeliveryRate or if any member of the operator was
+	// inactive or disqualified in DKG.
+	Unreliable bool
+}
+
+type signingObservation struct {
+	sharesExpected  int
+	sharesDelivered int
+	latencyBlocks   uint64
+}
+
+type dkgObservation struct {
+	inactiveMembers     int
+	disqualifiedMembers int
+}
+
+type operatorObservations struct {
+	signings []*signingObservation
+	dkgs     []*dkgObservation
+}
+
+// Tracker keeps the statistics of operators observed by the node. The node's
+// own operator is never tracked. Tracker is safe for concurrent use.
+type Tracker struct {
+	selfAddress []byte
+
+	mutex     sync.RWMutex
+	operators map[string]*operatorObservations
+}
+
+// NewTracker creates a new tracker for the node operating with the given
+// chain address.
+func NewTracker(selfAddress []byte) *Tracker {
+	return &Tracker{
+		selfAddress: selfAddress,
+		operators:   make(map[string]*operatorObservations),
+	}
+}
+
+// ObserveSigning records the signature shares delivered for one relay entry
+// by the group with the given members, ordered by their member indexes.
+// Deliveries contain the latency in blocks of the valid share delivered by
+// each member. Members missing in deliveries have not delivered a valid
+// share.
+func (t *Tracker) ObserveSigning(
+	members []relaychain.StakerAddress,
+	deliveries map[group.MemberIndex]uint64,
+) {
+	observations := make(map[string]*signingObservation)
+
+	for i, member := range members {
+		if bytes.Equal(member, t.selfAddress) {
+			continue
+		}
+
+		address := hex.EncodeToString(member)
+		observation, ok := observations[address]
+		if !ok {
+			observation = &signingObservation{}
+			observations[address] = observation
+		}
+
+		observation.sharesExpected++
+		if latency, ok := deliveries[group.MemberIndex(i+1)]; ok {
+			observation.sharesDelivered++
+			observation.latencyBlocks += latency
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for address, observation := range observations {
+		operator := t.operator(address)
+		operator.signings = appendSigning(operator.signings, observation)
+	}
+}
+
+// ObserveDKG records members of the given DKG group marked as inactive or
+// disqualified. Members are the stakers selected to the group, ordered by
+// their member indexes.
+func (t *Tracker) ObserveDKG(
+	members []relaychain.StakerAddress,
+	dkgGroup *group.Group,
+) {
+	inactive := make(map[group.MemberIndex]bool)
+	for _, memberID := range dkgGroup.InactiveMemberIDs() {
+		inactive[memberID] = true
+	}
+	disqualified := make(map[group.MemberIndex]bool)
+	for _, memberID := range dkgGroup.DisqualifiedMemberIDs() {
+		disqualified[memberID] = true
+	}
+
+	observations := make(map[string]*dkgObservation)
+
+	for i, member := range members {
+		if bytes.Equal(member, t.selfAddress) {
+			continue
+		}
+
+		address := hex.EncodeToString(member)
+		observation, ok := observations[address]
+		if !ok {
+			observation = &dkgObservation{}
+			observations[address] = observation
+		}
+
+		memberID := group.MemberIndex(i + 1)
+		if inactive[memberID] {
+			observation.inactiveMembers++
+		}
+		if disqualified[memberID] {
+			observation.disqualifiedMembers++
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for address, observation := range observations {
+		operator := t.operator(address)
+		operator.dkgs = appendDKG(operator.dkgs, observation)
+	}
+}
+
+// Statistics returns the statistics of all the observed operators, the
+// least reliable first.
+func (t *Tracker) Statistics() []*OperatorStatistics {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	statistics := make([]*OperatorStatistics, 0, len(t.operators))
+	for address, operator := range t.operators {
+		statistics = append(statistics, operator.statistics(address))
+	}
+
+	sort.Slice(statistics, func(i, j int) bool {
+		if statistics[i].Unreliable != statistics[j].Unreliable {
+			return statistics[i].Unreliable
+		}
+		if statistics[i].ShareDeliveryRate != statistics[j].ShareDeliveryRate {
+			return statistics[i].ShareDeliveryRate < statistics[j].ShareDeliveryRate
+		}
+		return statistics[i].Address < statistics[j].Address
+	})
+
+	return statistics
+}
+
+// ObservedOperatorsCount returns the number of observed operators.
+func (t *Tracker) ObservedOperatorsCount() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return len(t.operators)
+}
+
+// UnreliableOperatorsCount returns the number of observed operators which
+// are considered unreliable.
+func (t *Tracker) UnreliableOperatorsCount() int {
+	count := 0
+	for _, statistics := range t.Statistics() {
+		if statistics.Unreliable {
+			count++
+		}
+	}
+	return count
+}
+
+// LowestShareDeliveryRate returns the lowest share delivery rate among the
+// observed operators. If no signing has been observed, it returns 1.
+func (t *Tracker) LowestShareDeliveryRate() float64 {
+	lowest := 1.0
+	for _, statistics := range t.Statistics() {
+		if statistics.SigningSessions > 0 && statistics.ShareDeliveryRate < lowest {
+			lowest = statistics.ShareDeliveryRate
+		}
+	}
+	return lowest
+}
+
+func (t *Tracker) operator(address string) *operatorObservations {
+	operator, ok := t.operators[address]
+	if !ok {
+		operator = &operatorObservations{}
+		t.operators[address] = operator
+	}
+	return operator
+}
+
+func (oo *operatorObservations) statistics(address string) *OperatorStatistics {
+	statistics := &OperatorStatistics{
+		Address:         "0x" + address,
+		SigningSessions: len(oo.signings),
+		DKGSessions:     len(oo.dkgs),
+	}
+
+	var latencyBlocks uint64
+	for _, signing := range oo.signings {
+		statistics.SharesExpected += signing.sharesExpected
+		statistics.SharesDelivered += signing.sharesDelivered
+		latencyBlocks += signing.latencyBlocks
+	}
+	if statistics.SharesExpected > 0 {
+		statistics.ShareDeliveryRate = float64(statistics.SharesDelivered) /
+			float64(statistics.SharesExpected)
+	}
+	if statistics.SharesDelivered > 0 {
+		statistics.AverageShareLatencyBlocks = float64(latencyBlocks) /
+			float64(statistics.SharesDelivered)
+	}
+
+	for _, dkg := range oo.dkgs {
+		statistics.DKGInactiveMembers += dkg.inactiveMembers
+		statistics.DKGDisqualifiedMembers += dkg.disqualifiedMembers
+	}
+
+	statistics.Unreliable =
+		(statistics.SigningSessions > 0 &&
+			statistics.ShareDeliveryRate < UnreliableShareDeliveryRate) ||
+			statistics.DKGInactiveMembers > 0 ||
+			statistics.DKGDisqualifiedMembers > 0
+
+	return statistics
+}
+
+// appendSigning appends the observation dropping the oldest ones outside of
+// the statistics window.
+func appendSigning(
+	signings []*signingObservation,
+	observation *signingObservation,
+) []*signingObservation {
+	signings = append(signings, observation)
+	if len(signings) > statisticsWindow {
+		signings = signings[len(signings)-statisticsWindow:]
+	}
+	return signings
+}
+
+// appendDKG appends the observation dropping the oldest ones outside of the
+// statistics window.
+func appendDKG(
+	dkgs []*dkgObservation,
+	observation *dkgObservation,
+) []*dkgObservation {
+	dkgs = append(dkgs, observation)
+	if len(dkgs) > statisticsWindow {
+		dkgs = dkgs[len(dkgs)-statisticsWindow:]
+	}
+	return dkgs
+}
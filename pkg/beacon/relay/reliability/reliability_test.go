@@ -0,0 +1,152 @@
+package reliability
+
+import (
+	"testing"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+var (
+	selfAddress   = relaychain.StakerAddress{0x01}
+	goodAddress   = relaychain.StakerAddress{0x02}
+	faultyAddress = relaychain.StakerAddress{0x03}
+)
+
+func TestObserveSigning(t *testing.T) {
+	tracker := NewTracker(selfAddress)
+
+	members := []relaychain.StakerAddress{
+		selfAddress,
+		goodAddress,
+		faultyAddress,
+		faultyAddress,
+	}
+
+	// The faulty operator delivers one of two shares in each signing.
+	tracker.ObserveSigning(
+		members,
+		map[group.MemberIndex]uint64{1: 0, 2: 2, 3: 4},
+	)
+	tracker.ObserveSigning(
+		members,
+		map[group.MemberIndex]uint64{2: 4, 4: 2},
+	)
+
+	statistics := tracker.Statistics()
+	if len(statistics) != 2 {
+		t.Fatalf(
+			"unexpected number of operators\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(statistics),
+		)
+	}
+
+	faulty := statistics[0]
+	if faulty.Address != "0x03" ||
+		faulty.SigningSessions != 2 ||
+		faulty.SharesExpected != 4 ||
+		faulty.SharesDelivered != 2 ||
+		faulty.ShareDeliveryRate != 0.5 ||
+		faulty.AverageShareLatencyBlocks != 3 ||
+		!faulty.Unreliable {
+		t.Errorf("unexpected faulty operator statistics [%+v]", faulty)
+	}
+
+	good := statistics[1]
+	if good.Address != "0x02" ||
+		good.SigningSessions != 2 ||
+		good.ShareDeliveryRate != 1 ||
+		good.AverageShareLatencyBlocks != 3 ||
+		good.Unreliable {
+		t.Errorf("unexpected good operator statistics [%+v]", good)
+	}
+
+	if tracker.ObservedOperatorsCount() != 2 {
+		t.Errorf(
+			"unexpected observed operators count [%v]",
+			tracker.ObservedOperatorsCount(),
+		)
+	}
+	if tracker.UnreliableOperatorsCount() != 1 {
+		t.Errorf(
+			"unexpected unreliable operators count [%v]",
+			tracker.UnreliableOperatorsCount(),
+		)
+	}
+	if tracker.LowestShareDeliveryRate() != 0.5 {
+		t.Errorf(
+			"unexpected lowest share delivery rate [%v]",
+			tracker.LowestShareDeliveryRate(),
+		)
+	}
+}
+
+func TestObserveSigningWindow(t *testing.T) {
+	tracker := NewTracker(selfAddress)
+
+	members := []relaychain.StakerAddress{faultyAddress}
+
+	tracker.ObserveSigning(members, map[group.MemberIndex]uint64{})
+	for i := 0; i < statisticsWindow; i++ {
+		tracker.ObserveSigning(members, map[group.MemberIndex]uint64{1: 1})
+	}
+
+	statistics := tracker.Statistics()[0]
+	if statistics.SigningSessions != statisticsWindow ||
+		statistics.ShareDeliveryRate != 1 {
+		t.Errorf(
+			"expected the oldest signing to be dropped; has [%+v]",
+			statistics,
+		)
+	}
+}
+
+func TestObserveDKG(t *testing.T) {
+	tracker := NewTracker(selfAddress)
+
+	dkgGroup := group.NewDkgGroup(2, 4)
+	dkgGroup.MarkMemberAsInactive(1)
+	dkgGroup.MarkMemberAsInactive(3)
+	dkgGroup.MarkMemberAsDisqualified(4)
+
+	tracker.ObserveDKG(
+		[]relaychain.StakerAddress{
+			selfAddress,
+			goodAddress,
+			faultyAddress,
+			faultyAddress,
+		},
+		dkgGroup,
+	)
+
+	statistics := tracker.Statistics()
+	if len(statistics) != 2 {
+		t.Fatalf(
+			"unexpected number of operators\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(statistics),
+		)
+	}
+
+	faulty := statistics[0]
+	if faulty.Address != "0x03" ||
+		faulty.DKGSessions != 1 ||
+		faulty.DKGInactiveMembers != 1 ||
+		faulty.DKGDisqualifiedMembers != 1 ||
+		!faulty.Unreliable {
+		t.Errorf("unexpected faulty operator statistics [%+v]", faulty)
+	}
+
+	good := statistics[1]
+	if good.DKGSessions != 1 || good.Unreliable {
+		t.Errorf("unexpected good operator statistics [%+v]", good)
+	}
+
+	if tracker.LowestShareDeliveryRate() != 1 {
+		t.Errorf(
+			"unexpected lowest share delivery rate [%v]",
+			tracker.LowestShareDeliveryRate(),
+		)
+	}
+}
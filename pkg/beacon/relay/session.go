@@ -0,0 +1,83 @@
+package relay
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
+)
+
+// Types of protocol sessions executed by the node. They are the same as the
+// types of sessions recorded in the ledger.
+const (
+	DKGSession     = ledger.DKGSession
+	SigningSession = ledger.SigningSession
+)
+
+// Session describes a single protocol execution, DKG or relay entry signing,
+// the node takes part in with one of its group members.
+type Session struct {
+	// Type is the type of the session, either DKGSession or SigningSession.
+	Type string
+	// MemberIndex is the index of the node's group member executing the
+	// session.
+	MemberIndex group.MemberIndex
+	// Seed is the group selection seed the DKG session has been started for.
+	// Set only for DKG sessions.
+	Seed *big.Int
+	// GroupPublicKey is the public key of the group producing the relay
+	// entry. Set only for signing sessions.
+	GroupPublicKey []byte
+	// StartBlock is the block at which the session started.
+	StartBlock uint64
+	// StartedAt is the time at which the node started the session.
+	StartedAt time.Time
+}
+
+// beginSession registers the given session as in-flight. The returned
+// function must be called once the session is completed. It returns an
+// error if the node is shutting down and the session must not be started.
+func (n *Node) beginSession(session *Session) (func(), error) {
+	n.sessionsMutex.Lock()
+	defer n.sessionsMutex.Unlock()
+
+	if n.shuttingDown {
+		return nil, fmt.Errorf("node is shutting down")
+	}
+
+	if n.sessions == nil {
+		n.sessions = make(map[*Session]bool)
+	}
+
+	n.sessions[session] = true
+	n.inFlightWork.Add(1)
+
+	return func() {
+		n.sessionsMutex.Lock()
+		defer n.sessionsMutex.Unlock()
+
+		delete(n.sessions, session)
+		n.inFlightWork.Done()
+	}, nil
+}
+
+// InFlightSessions returns all the sessions the node is currently executing
+// ordered by their start time.
+func (n *Node) InFlightSessions() []*Session {
+	n.sessionsMutex.Lock()
+	defer n.sessionsMutex.Unlock()
+
+	sessions := make([]*Session, 0, len(n.sessions))
+	for session := range n.sessions {
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+
+	return sessions
+}
@@ -1,7 +1,7 @@
 package bls
 
 import (
-	"errors"
+	"crypto/rand"
 	"fmt"
 	"math/big"
 
@@ -75,39 +75,123 @@ func VerifyG1(publicKey *bn256.G2, message *bn256.G1, signature *bn256.G1) bool
 	return bn256.PairingCheck(a, b)
 }
 
-// RecoverSignature reconstructs the full BLS signature from a threshold number of
-// signature shares using Lagrange interpolation.
-func RecoverSignature(shares []*SignatureShare, threshold int) (*bn256.G1, error) {
+// batchVerificationCoefficientBits is the bit length of random coefficients
+// used to combine signatures in the batch verification. A batch containing an
+// invalid signature passes the verification with probability of at most
+// 2^-128.
+const batchVerificationCoefficientBits = 128
+
+// BatchVerifyG1 checks if the signatures are correct for the provided G1 point
+// message and the corresponding public keys. The signature at the given
+// position is expected to be made with the public key at the same position.
+//
+// All signatures are checked at once, with a single multi-pairing over a
+// random linear combination of the signatures and public keys. Only if that
+// check fails, the batch is bisected to find the invalid signatures. The
+// function returns positions of the invalid signatures; the returned slice is
+// empty if all signatures are correct.
+func BatchVerifyG1(
+	publicKeys []*bn256.G2,
+	message *bn256.G1,
+	signatures []*bn256.G1,
+) ([]int, error) {
+	if len(publicKeys) != len(signatures) {
+		return nil, fmt.Errorf(
+			"number of public keys [%v] does not match number of "+
+				"signatures [%v]",
+			len(publicKeys),
+			len(signatures),
+		)
+	}
 
-	// Indexes of participants that have valid shares.
-	var validParticipants []*big.Int
+	coefficientLimit := new(big.Int).Lsh(
+		big.NewInt(1),
+		batchVerificationCoefficientBits,
+	)
+
+	coefficients := make([]*big.Int, len(signatures))
+	for i := range coefficients {
+		coefficient, err := rand.Int(rand.Reader, coefficientLimit)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not generate batch verification coefficient: [%v]",
+				err,
+			)
+		}
+		// The coefficient must not be zero so that no signature is left
+		// out of the combination.
+		coefficients[i] = coefficient.Add(coefficient, big.NewInt(1))
+	}
+
+	positions := make([]int, len(signatures))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	invalid := make([]int, 0)
+	var bisect func(positions []int)
+	bisect = func(positions []int) {
+		if len(positions) == 0 {
+			return
+		}
 
-	// Get sufficient number of participants with valid shares.
-	for _, s := range shares {
-		if len(validParticipants) == threshold {
-			break
+		if len(positions) == 1 {
+			position := positions[0]
+			if !VerifyG1(publicKeys[position], message, signatures[position]) {
+				invalid = append(invalid, position)
+			}
+			return
 		}
-		if s == nil || s.V == nil || s.I < 0 {
-			continue
+
+		if verifyCombination(
+			publicKeys,
+			message,
+			signatures,
+			coefficients,
+			positions,
+		) {
+			return
 		}
-		validParticipants = append(validParticipants, big.NewInt(int64(s.I)))
+
+		half := len(positions) / 2
+		bisect(positions[:half])
+		bisect(positions[half:])
 	}
+	bisect(positions)
 
-	if len(validParticipants) < threshold {
-		return nil, fmt.Errorf(
-			"not enough shares to reconstruct signature: has [%v] shares, threshold is [%v]",
-			len(validParticipants),
-			threshold,
+	return invalid, nil
+}
+
+// verifyCombination checks the signatures at the given positions at once:
+// e(sum(r_i * signature_i), G2) == e(message, sum(r_i * publicKey_i)).
+func verifyCombination(
+	publicKeys []*bn256.G2,
+	message *bn256.G1,
+	signatures []*bn256.G1,
+	coefficients []*big.Int,
+	positions []int,
+) bool {
+	combinedSignature := new(bn256.G1)
+	combinedPublicKey := new(bn256.G2)
+	for _, position := range positions {
+		combinedSignature.Add(
+			combinedSignature,
+			new(bn256.G1).ScalarMult(signatures[position], coefficients[position]),
+		)
+		combinedPublicKey.Add(
+			combinedPublicKey,
+			new(bn256.G2).ScalarMult(publicKeys[position], coefficients[position]),
 		)
 	}
 
-	result := new(bn256.G1)
-	for i := range validParticipants {
-		basis := lagrangeBasis(i, validParticipants)
-		result.Add(result, new(bn256.G1).ScalarMult(shares[i].V, basis))
-	}
+	return VerifyG1(combinedPublicKey, message, combinedSignature)
+}
 
-	return result, nil
+// RecoverSignature reconstructs the full BLS signature from a threshold number of
+// signature shares using Lagrange interpolation. Lagrange coefficients are
+// cached per set of participants, see Recoverer.
+func RecoverSignature(shares []*SignatureShare, threshold int) (*bn256.G1, error) {
+	return defaultRecoverer.RecoverSignature(shares, threshold)
 }
 
 // GetSecretKeyShare computes secret share by evaluating a polynomial with
@@ -131,55 +215,8 @@ func (s *SecretKeyShare) PublicKeyShare() *PublicKeyShare {
 }
 
 // RecoverPublicKey reconstructs public key from a threshold number of
-// public key shares using Lagrange interpolation.
+// public key shares using Lagrange interpolation. Lagrange coefficients are
+// cached per set of participants, see Recoverer.
 func RecoverPublicKey(shares []*PublicKeyShare, threshold int) (*bn256.G2, error) {
-
-	// Indexes of participants that have valid shares.
-	var validParticipants []*big.Int
-
-	// Get sufficient number of participants with valid shares.
-	for _, s := range shares {
-		if s == nil || s.V == nil || s.I < 0 {
-			continue
-		}
-		validParticipants = append(validParticipants, big.NewInt(int64(s.I)))
-		if len(validParticipants) == threshold {
-			break
-		}
-	}
-
-	if len(validParticipants) < threshold {
-		return nil, errors.New("not enough shares to reconstruct public key")
-	}
-
-	result := new(bn256.G2)
-
-	for i := range validParticipants {
-		basis := lagrangeBasis(i, validParticipants)
-
-		result.Add(result, new(bn256.G2).ScalarMult(shares[i].V, basis))
-	}
-
-	return result, nil
-}
-
-func lagrangeBasis(i int, validParticipants []*big.Int) *big.Int {
-
-	// Prepare numerator and denominator as part of Lagrange interpolation.
-	num := big.NewInt(1)
-	den := big.NewInt(1)
-
-	for j, xj := range validParticipants {
-		if i == j {
-			continue
-		}
-		num = new(big.Int).Mod(new(big.Int).Mul(num, xj), bn256.Order)
-		den = new(big.Int).Mod(new(big.Int).Mul(den, new(big.Int).Sub(xj, validParticipants[i])), bn256.Order)
-	}
-
-	// Perform modular division of num by den.
-	modInv := new(big.Int).ModInverse(den, bn256.Order)
-	result := new(big.Int).Mod(new(big.Int).Mul(num, modInv), bn256.Order)
-
-	return result
+	return defaultRecoverer.RecoverPublicKey(shares, threshold)
 }
@@ -2,7 +2,9 @@ package bls
 
 import (
 	"crypto/rand"
+	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
@@ -184,3 +186,127 @@ func TestThresholdBLS(t *testing.T) {
 	}
 
 }
+
+func TestBatchVerifyG1(t *testing.T) {
+	pi, _ := new(big.Int).SetString("31415926535897932384626433832795028841971693993751058209749445923078164062862", 10)
+	message := new(bn256.G1).ScalarBaseMult(pi)
+
+	var publicKeys []*bn256.G2
+	var signatures []*bn256.G1
+	for i := 0; i < 10; i++ {
+		secretKey, publicKey, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+		signatures = append(signatures, SignG1(secretKey, message))
+	}
+
+	// Signature made with another key than the one at its position.
+	otherSignature := SignG1(big.NewInt(123), message)
+
+	var tests = map[string]struct {
+		invalidPositions []int
+	}{
+		"all signatures valid": {
+			invalidPositions: []int{},
+		},
+		"one invalid signature": {
+			invalidPositions: []int{4},
+		},
+		"several invalid signatures": {
+			invalidPositions: []int{0, 5, 6, 9},
+		},
+		"all signatures invalid": {
+			invalidPositions: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			batchSignatures := make([]*bn256.G1, len(signatures))
+			copy(batchSignatures, signatures)
+			for _, position := range test.invalidPositions {
+				batchSignatures[position] = otherSignature
+			}
+
+			invalidPositions, err := BatchVerifyG1(
+				publicKeys,
+				message,
+				batchSignatures,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.invalidPositions, invalidPositions) {
+				t.Errorf(
+					"unexpected invalid positions\nexpected: [%v]\nactual:   [%v]",
+					test.invalidPositions,
+					invalidPositions,
+				)
+			}
+		})
+	}
+}
+
+func TestBatchVerifyG1_LengthMismatch(t *testing.T) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	publicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(123))
+
+	_, err := BatchVerifyG1([]*bn256.G2{publicKey}, message, []*bn256.G1{})
+	if err == nil {
+		t.Errorf("expected error for mismatched public keys and signatures")
+	}
+}
+
+func BenchmarkBatchVerifyG1(b *testing.B) {
+	for _, groupSize := range []int{64, 128} {
+		publicKeys, message, signatures := benchmarkSignatureShares(b, groupSize)
+
+		b.Run(fmt.Sprintf("batch/%v", groupSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				invalidPositions, err := BatchVerifyG1(
+					publicKeys,
+					message,
+					signatures,
+				)
+				if err != nil || len(invalidPositions) != 0 {
+					b.Fatalf("unexpected verification result: [%v]", err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("per-share/%v", groupSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range signatures {
+					if !VerifyG1(publicKeys[j], message, signatures[j]) {
+						b.Fatalf("signature [%v] is not valid", j)
+					}
+				}
+			}
+		})
+	}
+}
+
+func benchmarkSignatureShares(
+	b *testing.B,
+	count int,
+) ([]*bn256.G2, *bn256.G1, []*bn256.G1) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+
+	publicKeys := make([]*bn256.G2, count)
+	signatures := make([]*bn256.G1, count)
+	for i := 0; i < count; i++ {
+		secretKey, publicKey, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		publicKeys[i] = publicKey
+		signatures[i] = SignG1(secretKey, message)
+	}
+
+	return publicKeys, message, signatures
+}
@@ -0,0 +1,412 @@
+package bls
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// maxCachedParticipantSets is the maximum number of participant sets for
+// which a Recoverer keeps Lagrange coefficients.
+const maxCachedParticipantSets = 256
+
+// minBucketMultiScalarMultPoints is the minimum number of points for which
+// the bucket method is faster than separate scalar multiplications.
+const minBucketMultiScalarMultPoints = 8
+
+// defaultRecoverer is used by RecoverSignature and RecoverPublicKey. Lagrange
+// coefficients depend only on indexes of the participants so they can be
+// shared between all groups.
+var defaultRecoverer = NewRecoverer()
+
+// Recoverer reconstructs threshold signatures and public keys from their
+// shares using Lagrange interpolation. Lagrange coefficients are computed
+// once per set of participants and cached, so that recovering from the same
+// set of participants again, for example by many members of one operator
+// completing the same signature, requires only a multi-scalar multiplication.
+// Recoverer is safe for concurrent use.
+type Recoverer struct {
+	cacheMutex sync.Mutex
+	cache      map[string]map[int]*big.Int
+}
+
+// NewRecoverer creates a new Recoverer with an empty coefficient cache.
+func NewRecoverer() *Recoverer {
+	return &Recoverer{
+		cache: make(map[string]map[int]*big.Int),
+	}
+}
+
+// RecoverSignature reconstructs the full BLS signature from the first
+// threshold number of signature shares which are set.
+func (r *Recoverer) RecoverSignature(
+	shares []*SignatureShare,
+	threshold int,
+) (*bn256.G1, error) {
+	var indexes []int
+	var points []*bn256.G1
+
+	for _, s := range shares {
+		if len(indexes) == threshold {
+			break
+		}
+		if s == nil || s.V == nil || s.I < 0 {
+			continue
+		}
+		indexes = append(indexes, s.I)
+		points = append(points, s.V)
+	}
+
+	if len(indexes) < threshold {
+		return nil, fmt.Errorf(
+			"not enough shares to reconstruct signature: has [%v] shares, threshold is [%v]",
+			len(indexes),
+			threshold,
+		)
+	}
+
+	coefficients, err := r.coefficientsFor(indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return multiScalarMultG1(points, coefficients), nil
+}
+
+// RecoverPublicKey reconstructs the public key from the first threshold
+// number of public key shares which are set.
+func (r *Recoverer) RecoverPublicKey(
+	shares []*PublicKeyShare,
+	threshold int,
+) (*bn256.G2, error) {
+	var indexes []int
+	var points []*bn256.G2
+
+	for _, s := range shares {
+		if len(indexes) == threshold {
+			break
+		}
+		if s == nil || s.V == nil || s.I < 0 {
+			continue
+		}
+		indexes = append(indexes, s.I)
+		points = append(points, s.V)
+	}
+
+	if len(indexes) < threshold {
+		return nil, fmt.Errorf(
+			"not enough shares to reconstruct public key: has [%v] shares, threshold is [%v]",
+			len(indexes),
+			threshold,
+		)
+	}
+
+	coefficients, err := r.coefficientsFor(indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return multiScalarMultG2(points, coefficients), nil
+}
+
+// coefficientsFor returns Lagrange coefficients for the participants with
+// the given indexes, in the order of the indexes. The coefficients are taken
+// from the cache if the same set of participants has been seen before.
+func (r *Recoverer) coefficientsFor(indexes []int) ([]*big.Int, error) {
+	sortedIndexes := make([]int, len(indexes))
+	copy(sortedIndexes, indexes)
+	sort.Ints(sortedIndexes)
+
+	keyParts := make([]string, len(sortedIndexes))
+	for i, index := range sortedIndexes {
+		if i > 0 && index == sortedIndexes[i-1] {
+			return nil, fmt.Errorf("duplicate share index [%v]", index)
+		}
+		keyParts[i] = strconv.Itoa(index)
+	}
+	key := strings.Join(keyParts, ",")
+
+	r.cacheMutex.Lock()
+	coefficientsByIndex, ok := r.cache[key]
+	r.cacheMutex.Unlock()
+
+	if !ok {
+		var err error
+		coefficientsByIndex, err = lagrangeCoefficients(sortedIndexes)
+		if err != nil {
+			return nil, err
+		}
+
+		r.cacheMutex.Lock()
+		if len(r.cache) >= maxCachedParticipantSets {
+			// Evict an arbitrary participant set to make room for the
+			// new one.
+			for cachedKey := range r.cache {
+				delete(r.cache, cachedKey)
+				break
+			}
+		}
+		r.cache[key] = coefficientsByIndex
+		r.cacheMutex.Unlock()
+	}
+
+	coefficients := make([]*big.Int, len(indexes))
+	for i, index := range indexes {
+		coefficients[i] = coefficientsByIndex[index]
+	}
+
+	return coefficients, nil
+}
+
+// lagrangeCoefficients computes Lagrange coefficients at zero for the
+// participants with the given distinct indexes. All denominators are inverted
+// at once with Montgomery batch inversion.
+func lagrangeCoefficients(indexes []int) (map[int]*big.Int, error) {
+	numerators := make([]*big.Int, len(indexes))
+	denominators := make([]*big.Int, len(indexes))
+
+	for i, xi := range indexes {
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+
+		for j, xj := range indexes {
+			if i == j {
+				continue
+			}
+			num.Mod(num.Mul(num, big.NewInt(int64(xj))), bn256.Order)
+			den.Mod(den.Mul(den, big.NewInt(int64(xj-xi))), bn256.Order)
+		}
+
+		numerators[i] = num
+		denominators[i] = den
+	}
+
+	inverses, err := batchInverse(denominators)
+	if err != nil {
+		return nil, err
+	}
+
+	coefficients := make(map[int]*big.Int, len(indexes))
+	for i, index := range indexes {
+		coefficients[index] = new(big.Int).Mod(
+			new(big.Int).Mul(numerators[i], inverses[i]),
+			bn256.Order,
+		)
+	}
+
+	return coefficients, nil
+}
+
+// batchInverse computes modular inverses of all the given values with
+// a single modular inversion, using Montgomery's trick.
+func batchInverse(values []*big.Int) ([]*big.Int, error) {
+	if len(values) == 0 {
+		return []*big.Int{}, nil
+	}
+
+	// prefixes[i] is the product of values[0..i].
+	prefixes := make([]*big.Int, len(values))
+	product := big.NewInt(1)
+	for i, value := range values {
+		product = new(big.Int).Mod(new(big.Int).Mul(product, value), bn256.Order)
+		prefixes[i] = product
+	}
+
+	inverse := new(big.Int).ModInverse(product, bn256.Order)
+	if inverse == nil {
+		return nil, fmt.Errorf("value is not invertible")
+	}
+
+	inverses := make([]*big.Int, len(values))
+	for i := len(values) - 1; i > 0; i-- {
+		// inverse is the inverse of prefixes[i] here.
+		inverses[i] = new(big.Int).Mod(
+			new(big.Int).Mul(inverse, prefixes[i-1]),
+			bn256.Order,
+		)
+		inverse = new(big.Int).Mod(
+			new(big.Int).Mul(inverse, values[i]),
+			bn256.Order,
+		)
+	}
+	inverses[0] = inverse
+
+	return inverses, nil
+}
+
+// multiScalarMultG1 computes the sum of points multiplied by the scalars at
+// the same positions. Unless there are only a few points, Pippenger's bucket
+// method is used.
+func multiScalarMultG1(points []*bn256.G1, scalars []*big.Int) *bn256.G1 {
+	if len(points) < minBucketMultiScalarMultPoints {
+		result := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+		for i, point := range points {
+			result = new(bn256.G1).Add(
+				result,
+				new(bn256.G1).ScalarMult(point, scalars[i]),
+			)
+		}
+		return result
+	}
+
+	windowBits := multiScalarMultWindowBits(len(points))
+	windows := (maxBitLen(scalars) + windowBits - 1) / windowBits
+
+	// Sums are nil as long as they are the point at infinity.
+	var result *bn256.G1
+	for window := windows - 1; window >= 0; window-- {
+		if result != nil {
+			for i := 0; i < windowBits; i++ {
+				result = addG1(result, result)
+			}
+		}
+
+		buckets := make([]*bn256.G1, 1<<uint(windowBits))
+		for i, point := range points {
+			digit := scalarWindow(scalars[i], window, windowBits)
+			if digit != 0 {
+				buckets[digit] = addG1(buckets[digit], point)
+			}
+		}
+
+		// Sum of digit * bucket[digit] computed with running sums.
+		var runningSum, windowSum *bn256.G1
+		for digit := len(buckets) - 1; digit > 0; digit-- {
+			if buckets[digit] != nil {
+				runningSum = addG1(runningSum, buckets[digit])
+			}
+			if runningSum != nil {
+				windowSum = addG1(windowSum, runningSum)
+			}
+		}
+
+		if windowSum != nil {
+			result = addG1(result, windowSum)
+		}
+	}
+
+	if result == nil {
+		return new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	}
+
+	return result
+}
+
+// addG1 returns a new point being the sum of the given points, where nil
+// stands for the point at infinity. The sum is never computed in place, as
+// bn256 does not double correctly a point added to itself.
+func addG1(a, b *bn256.G1) *bn256.G1 {
+	if a == nil {
+		return new(bn256.G1).Set(b)
+	}
+
+	return new(bn256.G1).Add(a, new(bn256.G1).Set(b))
+}
+
+// multiScalarMultG2 computes the sum of points multiplied by the scalars at
+// the same positions. Unless there are only a few points, Pippenger's bucket
+// method is used.
+func multiScalarMultG2(points []*bn256.G2, scalars []*big.Int) *bn256.G2 {
+	if len(points) < minBucketMultiScalarMultPoints {
+		result := new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+		for i, point := range points {
+			result = new(bn256.G2).Add(
+				result,
+				new(bn256.G2).ScalarMult(point, scalars[i]),
+			)
+		}
+		return result
+	}
+
+	windowBits := multiScalarMultWindowBits(len(points))
+	windows := (maxBitLen(scalars) + windowBits - 1) / windowBits
+
+	// Sums are nil as long as they are the point at infinity.
+	var result *bn256.G2
+	for window := windows - 1; window >= 0; window-- {
+		if result != nil {
+			for i := 0; i < windowBits; i++ {
+				result = addG2(result, result)
+			}
+		}
+
+		buckets := make([]*bn256.G2, 1<<uint(windowBits))
+		for i, point := range points {
+			digit := scalarWindow(scalars[i], window, windowBits)
+			if digit != 0 {
+				buckets[digit] = addG2(buckets[digit], point)
+			}
+		}
+
+		// Sum of digit * bucket[digit] computed with running sums.
+		var runningSum, windowSum *bn256.G2
+		for digit := len(buckets) - 1; digit > 0; digit-- {
+			if buckets[digit] != nil {
+				runningSum = addG2(runningSum, buckets[digit])
+			}
+			if runningSum != nil {
+				windowSum = addG2(windowSum, runningSum)
+			}
+		}
+
+		if windowSum != nil {
+			result = addG2(result, windowSum)
+		}
+	}
+
+	if result == nil {
+		return new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+	}
+
+	return result
+}
+
+// addG2 returns a new point being the sum of the given points, where nil
+// stands for the point at infinity. The sum is never computed in place, as
+// bn256 does not double correctly a point added to itself.
+func addG2(a, b *bn256.G2) *bn256.G2 {
+	if a == nil {
+		return new(bn256.G2).Set(b)
+	}
+
+	return new(bn256.G2).Add(a, new(bn256.G2).Set(b))
+}
+
+// multiScalarMultWindowBits returns the width of the scalar window for
+// a multi-scalar multiplication of the given number of points.
+func multiScalarMultWindowBits(pointsCount int) int {
+	windowBits := bits.Len(uint(pointsCount)) - 2
+	if windowBits < 2 {
+		return 2
+	}
+	if windowBits > 8 {
+		return 8
+	}
+	return windowBits
+}
+
+// scalarWindow returns the value of the window-th group of windowBits bits of
+// the scalar, counting from the least significant bit.
+func scalarWindow(scalar *big.Int, window int, windowBits int) int {
+	digit := 0
+	for i := windowBits - 1; i >= 0; i-- {
+		digit = digit<<1 | int(scalar.Bit(window*windowBits+i))
+	}
+	return digit
+}
+
+func maxBitLen(scalars []*big.Int) int {
+	max := 0
+	for _, scalar := range scalars {
+		if scalar.BitLen() > max {
+			max = scalar.BitLen()
+		}
+	}
+	return max
+}
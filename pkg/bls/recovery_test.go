@@ -0,0 +1,215 @@
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestRecovererCachesCoefficients(t *testing.T) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	threshold := 3
+
+	var masterSecretKey []*big.Int
+	for i := 0; i < threshold; i++ {
+		secretKey, _, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		masterSecretKey = append(masterSecretKey, secretKey)
+	}
+
+	var signatureShares []*SignatureShare
+	for i := 1; i <= 5; i++ {
+		secretKeyShare := GetSecretKeyShare(masterSecretKey, i)
+		signatureShares = append(signatureShares, &SignatureShare{
+			I: i,
+			V: SignG1(secretKeyShare.V, message),
+		})
+	}
+
+	expectedSignature := SignG1(masterSecretKey[0], message)
+
+	recoverer := NewRecoverer()
+
+	// The same set of participants in a different order.
+	for _, shares := range [][]*SignatureShare{
+		{signatureShares[0], signatureShares[2], signatureShares[4]},
+		{signatureShares[4], signatureShares[0], signatureShares[2]},
+	} {
+		signature, err := recoverer.RecoverSignature(shares, threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBytesEqual(
+			t,
+			expectedSignature.Marshal(),
+			signature.Marshal(),
+		)
+	}
+
+	if len(recoverer.cache) != 1 {
+		t.Errorf(
+			"unexpected number of cached participant sets\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(recoverer.cache),
+		)
+	}
+}
+
+func TestRecovererSkipsUnsetShares(t *testing.T) {
+	message := new(bn256.G1).ScalarBaseMult(big.NewInt(456))
+	masterSecretKey := []*big.Int{big.NewInt(123), big.NewInt(789)}
+
+	share := func(i int) *SignatureShare {
+		secretKeyShare := GetSecretKeyShare(masterSecretKey, i)
+		return &SignatureShare{I: i, V: SignG1(secretKeyShare.V, message)}
+	}
+
+	signature, err := NewRecoverer().RecoverSignature(
+		[]*SignatureShare{nil, share(2), {I: 3}, share(4)},
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		SignG1(masterSecretKey[0], message).Marshal(),
+		signature.Marshal(),
+	)
+}
+
+func TestRecovererRejectsDuplicateIndexes(t *testing.T) {
+	share := &SignatureShare{
+		I: 1,
+		V: new(bn256.G1).ScalarBaseMult(big.NewInt(1)),
+	}
+
+	_, err := NewRecoverer().RecoverSignature([]*SignatureShare{share, share}, 2)
+	if err == nil {
+		t.Errorf("expected error for duplicate share indexes")
+	}
+}
+
+func TestBatchInverse(t *testing.T) {
+	values := []*big.Int{big.NewInt(2), big.NewInt(3), big.NewInt(12345)}
+
+	inverses, err := batchInverse(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, value := range values {
+		expected := new(big.Int).ModInverse(value, bn256.Order)
+		if expected.Cmp(inverses[i]) != 0 {
+			t.Errorf(
+				"unexpected inverse of [%v]\nexpected: [%v]\nactual:   [%v]",
+				value,
+				expected,
+				inverses[i],
+			)
+		}
+	}
+
+	if _, err := batchInverse([]*big.Int{big.NewInt(2), big.NewInt(0)}); err == nil {
+		t.Errorf("expected error for value which is not invertible")
+	}
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	var g1Points []*bn256.G1
+	var g2Points []*bn256.G2
+	var scalars []*big.Int
+
+	expectedG1 := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	expectedG2 := new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+
+	for i := 0; i < 20; i++ {
+		scalar, g1Point, err := bn256.RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, g2Point, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Repeat a point to cover buckets where a point is added to itself.
+		if i%5 == 4 {
+			g1Point = g1Points[0]
+			g2Point = g2Points[0]
+			scalar = scalars[0]
+		}
+
+		g1Points = append(g1Points, g1Point)
+		g2Points = append(g2Points, g2Point)
+		scalars = append(scalars, scalar)
+
+		expectedG1 = new(bn256.G1).Add(
+			expectedG1,
+			new(bn256.G1).ScalarMult(g1Point, scalar),
+		)
+		expectedG2 = new(bn256.G2).Add(
+			expectedG2,
+			new(bn256.G2).ScalarMult(g2Point, scalar),
+		)
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		expectedG1.Marshal(),
+		multiScalarMultG1(g1Points, scalars).Marshal(),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		expectedG2.Marshal(),
+		multiScalarMultG2(g2Points, scalars).Marshal(),
+	)
+}
+
+func BenchmarkRecoverSignature(b *testing.B) {
+	for _, groupSize := range []int{64, 128} {
+		threshold := groupSize/2 + 1
+
+		shares := make([]*SignatureShare, threshold)
+		for i := range shares {
+			shares[i] = &SignatureShare{
+				I: i + 1,
+				V: new(bn256.G1).ScalarBaseMult(big.NewInt(int64(i + 1))),
+			}
+		}
+
+		// Lagrange coefficients are computed for every recovery.
+		b.Run(fmt.Sprintf("cold/%v", groupSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := NewRecoverer().RecoverSignature(shares, threshold)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		// Lagrange coefficients are computed once and read from the cache.
+		b.Run(fmt.Sprintf("warm/%v", groupSize), func(b *testing.B) {
+			recoverer := NewRecoverer()
+			if _, err := recoverer.RecoverSignature(shares, threshold); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := recoverer.RecoverSignature(shares, threshold)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
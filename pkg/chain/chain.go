@@ -96,6 +96,16 @@ type Handle interface {
 	Signing() Signing
 }
 
+// ReadOnlyHandle represents a handle to a blockchain for nodes which do not
+// operate, such as bootstrap nodes. It provides access to the chain state
+// and events but it has no operator account; transactions submitted through
+// the threshold relay are refused.
+type ReadOnlyHandle interface {
+	BlockCounter() (BlockCounter, error)
+	StakeMonitor() (StakeMonitor, error)
+	ThresholdRelay() relaychain.Interface
+}
+
 // Utility represents a handle to a blockchain that provides access to certain
 // utility functions for Keep network interactions. Notably, these functions can
 // either be application or operator functionality, and they are generally not
@@ -105,4 +115,26 @@ type Utility interface {
 
 	Genesis() error
 	RequestRelayEntry() *async.EventEntryGeneratedPromise
+
+	// NumberOfGroups returns the number of groups registered on-chain,
+	// including expired and terminated ones.
+	NumberOfGroups() (uint64, error)
+	// FirstActiveGroupIndex returns the index of the first group which has
+	// not expired yet. All groups with lower indexes are expired.
+	FirstActiveGroupIndex() (uint64, error)
+	// GetGroup returns details of the group registered on-chain at the given
+	// index.
+	GetGroup(index uint64) (*Group, error)
+}
+
+// Group describes a group registered on-chain.
+type Group struct {
+	Index             uint64
+	PublicKey         []byte
+	RegistrationBlock uint64
+	// Members holds addresses of operators in the order of their member
+	// indexes. An operator is present once for each seat it has in the group.
+	Members    []relaychain.StakerAddress
+	Terminated bool
+	Stale      bool
 }
@@ -0,0 +1,166 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/signer"
+)
+
+// signingTimeout is the maximum time the external signer has to sign a
+// transaction or a message.
+const signingTimeout = 30 * time.Second
+
+// errReadOnlyAccount is returned when a transaction is submitted from the
+// read-only account.
+var errReadOnlyAccount = fmt.Errorf("read-only chain connection can not submit transactions")
+
+// account is the operator account submitting transactions and signing
+// messages. It signs either with the key decrypted from the key file or by
+// delegating to an external signer holding the key. The read-only account
+// has neither and refuses to sign.
+type account struct {
+	address common.Address
+
+	// key is the decrypted operator key; nil if the key is held by the
+	// external signer.
+	key *keystore.Key
+
+	// signer is the external signer holding the operator key and publicKey
+	// is the public key of that key; both are nil if the key is decrypted.
+	signer    *signer.Client
+	publicKey *ecdsa.PublicKey
+}
+
+func newKeyAccount(key *keystore.Key) *account {
+	return &account{
+		address: key.Address,
+		key:     key,
+	}
+}
+
+// newReadOnlyAccount returns the account of a connection which only reads
+// the chain state.
+func newReadOnlyAccount() *account {
+	return &account{}
+}
+
+// isReadOnly returns true if the account can not sign.
+func (a *account) isReadOnly() bool {
+	return a.key == nil && a.signer == nil
+}
+
+func newSignerAccount(
+	ctx context.Context,
+	signerClient *signer.Client,
+	address common.Address,
+) (*account, error) {
+	publicKey, err := signerClient.PublicKey(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get public key of account [%v] from signer: [%v]",
+			address.Hex(),
+			err,
+		)
+	}
+
+	return &account{
+		address:   address,
+		signer:    signerClient,
+		publicKey: publicKey,
+	}, nil
+}
+
+// transactorOptions returns the options of contract transactions submitted
+// from the account to the chain with the given ID.
+func (a *account) transactorOptions(chainID *big.Int) (*bind.TransactOpts, error) {
+	if a.isReadOnly() {
+		return &bind.TransactOpts{
+			From: a.address,
+			Signer: func(
+				common.Address,
+				*types.Transaction,
+			) (*types.Transaction, error) {
+				return nil, errReadOnlyAccount
+			},
+		}, nil
+	}
+
+	if a.signer == nil {
+		return ethutil.NewKeyedTransactorWithChainID(a.key.PrivateKey, chainID)
+	}
+
+	return &bind.TransactOpts{
+		From: a.address,
+		Signer: func(
+			address common.Address,
+			transaction *types.Transaction,
+		) (*types.Transaction, error) {
+			if address != a.address {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			ctx, cancelCtx := context.WithTimeout(
+				context.Background(),
+				signingTimeout,
+			)
+			defer cancelCtx()
+
+			return a.signer.SignTransaction(ctx, address, transaction, chainID)
+		},
+	}, nil
+}
+
+// signing returns the signing of messages with the account key.
+func (a *account) signing() chain.Signing {
+	if a.signer == nil {
+		return ethutil.NewSigner(a.key.PrivateKey)
+	}
+
+	return &externalSigning{
+		// The signer uses only the public part of the key to verify
+		// signatures and to convert public keys to addresses.
+		EthereumSigner: ethutil.NewSigner(
+			&ecdsa.PrivateKey{PublicKey: *a.publicKey},
+		),
+		signer:  a.signer,
+		address: a.address,
+	}
+}
+
+// operatorKeys returns the operator key pair or nils if the key is held by
+// the external signer.
+func (a *account) operatorKeys() (*operator.PrivateKey, *operator.PublicKey) {
+	if a.key == nil {
+		return nil, nil
+	}
+
+	return operator.ChainKeyToOperatorKey(a.key)
+}
+
+// externalSigning signs messages with the key held by the external signer.
+type externalSigning struct {
+	*ethutil.EthereumSigner
+
+	signer  *signer.Client
+	address common.Address
+}
+
+// Sign asks the external signer to sign the message in the Ethereum-specific
+// format.
+func (es *externalSigning) Sign(message []byte) ([]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), signingTimeout)
+	defer cancelCtx()
+
+	return es.signer.SignText(ctx, es.address, message)
+}
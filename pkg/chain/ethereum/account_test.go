@@ -0,0 +1,155 @@
+package ethereum
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/signer"
+)
+
+func TestSignerAccount(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	chainID := big.NewInt(1101)
+	operatorContract := common.HexToAddress(
+		"0x440a9a3fcc8eb4a2d73fad7c3ea11d6f40e2f7e3",
+	)
+
+	standIn, err := signer.NewStandIn(
+		accountKey,
+		chainID,
+		signer.Policy{AllowedRecipients: []common.Address{operatorContract}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer standIn.Stop()
+
+	directory, err := ioutil.TempDir("", "signer-account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	socket := filepath.Join(directory, "signer.ipc")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go standIn.Serve(listener)
+
+	signerClient, err := signer.Dial(context.Background(), socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signerClient.Close()
+
+	account, err := newSignerAccount(
+		context.Background(),
+		signerClient,
+		accountKey.Address,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactorOptions, err := account.transactorOptions(chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction, err := transactorOptions.Signer(
+		accountKey.Address,
+		types.NewTransaction(1, operatorContract, nil, 21000, big.NewInt(1), nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(chainID), transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != accountKey.Address {
+		t.Errorf(
+			"unexpected sender\nexpected: [%v]\nactual:   [%v]",
+			accountKey.Address.Hex(),
+			sender.Hex(),
+		)
+	}
+
+	// The signer policy allows only transactions to the operator contract.
+	_, err = transactorOptions.Signer(
+		accountKey.Address,
+		types.NewTransaction(2, accountKey.Address, nil, 21000, big.NewInt(1), nil),
+	)
+	if err == nil {
+		t.Error("expected error for transaction refused by the signer")
+	}
+
+	signing := account.signing()
+	message := []byte("dkg result hash")
+
+	signature, err := signing.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := signing.VerifyWithPublicKey(
+		message,
+		signature,
+		crypto.FromECDSAPub(&privateKey.PublicKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("signature is not valid")
+	}
+}
+
+func TestReadOnlyAccount(t *testing.T) {
+	account := newReadOnlyAccount()
+
+	if !account.isReadOnly() {
+		t.Fatal("account should be read-only")
+	}
+
+	transactorOptions, err := account.transactorOptions(big.NewInt(1101))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = transactorOptions.Signer(
+		account.address,
+		types.NewTransaction(1, common.Address{}, nil, 21000, big.NewInt(1), nil),
+	)
+	if err != errReadOnlyAccount {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			errReadOnlyAccount,
+			err,
+		)
+	}
+
+	privateKey, publicKey := account.operatorKeys()
+	if privateKey != nil || publicKey != nil {
+		t.Fatal("read-only account should have no operator keys")
+	}
+}
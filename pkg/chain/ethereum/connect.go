@@ -7,19 +7,19 @@ import (
 	"sync"
 	"time"
 
-	"github.com/keep-network/keep-common/pkg/rate"
-
 	"github.com/keep-network/keep-common/pkg/chain/ethlike"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/gen/contract"
+	"github.com/keep-network/keep-core/pkg/signer"
 )
 
 // Definitions of contract names.
@@ -46,14 +46,16 @@ var (
 
 type ethereumChain struct {
 	config                           ethereum.Config
-	accountKey                       *keystore.Key
+	account                          *account
 	client                           ethutil.EthereumClient
+	wrappedClient                    *reconfigurableClient
 	clientRPC                        *rpc.Client
 	clientWS                         *rpc.Client
 	chainID                          *big.Int
 	keepRandomBeaconOperatorContract *contract.KeepRandomBeaconOperator
 	stakingContract                  *contract.TokenStaking
 	blockCounter                     *ethlike.BlockCounter
+	events                           *eventHub
 	chainConfig                      *relaychain.Config
 
 	// transactionMutex allows interested parties to forcibly serialize
@@ -100,45 +102,62 @@ func connectWithClient(
 	clientWS *rpc.Client,
 	clientRPC *rpc.Client,
 ) (*ethereumChain, error) {
-	chainID, err := client.ChainID(context.Background())
+	key, err := ethutil.DecryptKeyFile(
+		config.Account.KeyFile,
+		config.Account.KeyFilePassword,
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to resolve Ethereum chain id: [%v]",
+			"failed to read KeyFile: %s: [%v]",
+			config.Account.KeyFile,
 			err,
 		)
 	}
 
-	ec := &ethereumChain{
-		config:           config,
-		client:           addClientWrappers(config, client),
-		clientRPC:        clientRPC,
-		clientWS:         clientWS,
-		chainID:          chainID,
-		transactionMutex: &sync.Mutex{},
+	chains, err := connectOperatorsWithClient(
+		ctx,
+		config,
+		client,
+		clientWS,
+		clientRPC,
+		[]*account{newKeyAccount(key)},
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	blockCounter, err := ethutil.NewBlockCounter(ec.client)
+	return chains[0], nil
+}
+
+// connectOperatorsWithClient creates chain handles for all the given
+// operator accounts. The handles share the Ethereum client, the block
+// counter, the mining waiter and subscriptions to contract events. Each of
+// them has its own nonce manager and contract bindings signing transactions
+// with the operator account.
+func connectOperatorsWithClient(
+	ctx context.Context,
+	config ethereum.Config,
+	client *ethclient.Client,
+	clientWS *rpc.Client,
+	clientRPC *rpc.Client,
+	accounts []*account,
+) ([]*ethereumChain, error) {
+	chainID, err := client.ChainID(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to create Ethereum blockcounter: [%v]",
+			"failed to resolve Ethereum chain id: [%v]",
 			err,
 		)
 	}
-	ec.blockCounter = blockCounter
 
-	if ec.accountKey == nil {
-		key, err := ethutil.DecryptKeyFile(
-			config.Account.KeyFile,
-			config.Account.KeyFilePassword,
+	wrappedClient := addClientWrappers(config, client)
+
+	blockCounter, err := ethutil.NewBlockCounter(wrappedClient)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create Ethereum blockcounter: [%v]",
+			err,
 		)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to read KeyFile: %s: [%v]",
-				config.Account.KeyFile,
-				err,
-			)
-		}
-		ec.accountKey = key
 	}
 
 	checkInterval := DefaultMiningCheckInterval
@@ -153,73 +172,106 @@ func connectWithClient(
 	logger.Infof("using [%v] mining check interval", checkInterval)
 	logger.Infof("using [%v] wei max gas price", maxGasPrice)
 	miningWaiter := ethutil.NewMiningWaiter(
-		ec.client,
+		wrappedClient,
 		checkInterval,
 		maxGasPrice,
 	)
 
-	address, err := config.ContractAddress(KeepRandomBeaconOperatorContractName)
-	if err != nil {
-		return nil, fmt.Errorf("error resolving KeepRandomBeaconOperator contract: [%v]", err)
-	}
-
-	nonceManager := ethutil.NewNonceManager(
-		ec.client,
-		ec.accountKey.Address,
+	operatorAddress, err := config.ContractAddress(
+		KeepRandomBeaconOperatorContractName,
 	)
-
-	keepRandomBeaconOperatorContract, err :=
-		contract.NewKeepRandomBeaconOperator(
-			address,
-			ec.chainID,
-			ec.accountKey,
-			ec.client,
-			nonceManager,
-			miningWaiter,
-			blockCounter,
-			ec.transactionMutex,
-		)
 	if err != nil {
-		return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator contract: [%v]", err)
+		return nil, fmt.Errorf("error resolving KeepRandomBeaconOperator contract: [%v]", err)
 	}
-	ec.keepRandomBeaconOperatorContract = keepRandomBeaconOperatorContract
 
-	address, err = config.ContractAddress(TokenStakingContractName)
+	stakingAddress, err := config.ContractAddress(TokenStakingContractName)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving TokenStaking contract: [%v]", err)
 	}
 
-	stakingContract, err :=
-		contract.NewTokenStaking(
-			address,
-			ec.chainID,
-			ec.accountKey,
+	events := newEventHub()
+
+	var chainConfig *relaychain.Config
+
+	chains := make([]*ethereumChain, 0, len(accounts))
+	for _, operatorAccount := range accounts {
+		ec := &ethereumChain{
+			config:           config,
+			account:          operatorAccount,
+			client:           wrappedClient,
+			wrappedClient:    wrappedClient,
+			clientRPC:        clientRPC,
+			clientWS:         clientWS,
+			chainID:          chainID,
+			blockCounter:     blockCounter,
+			events:           events,
+			transactionMutex: &sync.Mutex{},
+		}
+
+		nonceManager := ethutil.NewNonceManager(
 			ec.client,
-			nonceManager,
-			miningWaiter,
-			blockCounter,
-			ec.transactionMutex,
+			ec.account.address,
 		)
-	if err != nil {
-		return nil, fmt.Errorf("error attaching to TokenStaking contract: [%v]", err)
-	}
-	ec.stakingContract = stakingContract
 
-	chainConfig, err := fetchChainConfig(ec)
-	if err != nil {
-		return nil, fmt.Errorf("could not fetch chain config: [%v]", err)
-	}
-	ec.chainConfig = chainConfig
+		transactorOptions, err := ec.account.transactorOptions(ec.chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate transactor: [%v]", err)
+		}
+
+		keepRandomBeaconOperatorContract, err :=
+			contract.NewKeepRandomBeaconOperatorWithTransactor(
+				operatorAddress,
+				transactorOptions,
+				ec.client,
+				nonceManager,
+				miningWaiter,
+				blockCounter,
+				ec.transactionMutex,
+			)
+		if err != nil {
+			return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator contract: [%v]", err)
+		}
+		ec.keepRandomBeaconOperatorContract = keepRandomBeaconOperatorContract
+
+		stakingContract, err :=
+			contract.NewTokenStakingWithTransactor(
+				stakingAddress,
+				transactorOptions,
+				ec.client,
+				nonceManager,
+				miningWaiter,
+				blockCounter,
+				ec.transactionMutex,
+			)
+		if err != nil {
+			return nil, fmt.Errorf("error attaching to TokenStaking contract: [%v]", err)
+		}
+		ec.stakingContract = stakingContract
+
+		// The chain config is the same for all the operators.
+		if chainConfig == nil {
+			chainConfig, err = fetchChainConfig(ec)
+			if err != nil {
+				return nil, fmt.Errorf("could not fetch chain config: [%v]", err)
+			}
+		}
+		ec.chainConfig = chainConfig
 
-	ec.initializeBalanceMonitoring(ctx)
+		// There is no balance to monitor for a read-only connection.
+		if !operatorAccount.isReadOnly() {
+			ec.initializeBalanceMonitoring(ctx)
+		}
 
-	return ec, nil
+		chains = append(chains, ec)
+	}
+
+	return chains, nil
 }
 
 func addClientWrappers(
 	config ethereum.Config,
 	backend ethutil.EthereumClient,
-) ethutil.EthereumClient {
+) *reconfigurableClient {
 	loggingBackend := ethutil.WrapCallLogging(logger, backend)
 
 	if config.RequestsPerSecondLimit > 0 || config.ConcurrencyLimit > 0 {
@@ -230,17 +282,30 @@ func addClientWrappers(
 			config.RequestsPerSecondLimit,
 			config.ConcurrencyLimit,
 		)
-
-		return ethutil.WrapRateLimiting(
-			loggingBackend,
-			&rate.LimiterConfig{
-				RequestsPerSecondLimit: config.RequestsPerSecondLimit,
-				ConcurrencyLimit:       config.ConcurrencyLimit,
-			},
-		)
 	}
 
-	return loggingBackend
+	return newReconfigurableClient(
+		loggingBackend,
+		config.RequestsPerSecondLimit,
+		config.ConcurrencyLimit,
+	)
+}
+
+// SetRateLimits changes the request rate limits of the Ethereum client. Zero
+// values disable the respective limit.
+func (ec *ethereumChain) SetRateLimits(
+	requestsPerSecondLimit int,
+	concurrencyLimit int,
+) {
+	logger.Infof(
+		"changing ethereum client request rate limiter; "+
+			"rps limit [%v]; "+
+			"concurrency limit [%v]",
+		requestsPerSecondLimit,
+		concurrencyLimit,
+	)
+
+	ec.wrappedClient.setRateLimits(requestsPerSecondLimit, concurrencyLimit)
 }
 
 // ConnectUtility makes the network connection to the Ethereum network and
@@ -299,14 +364,18 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 
 	nonceManager := ethutil.NewNonceManager(
 		client,
-		base.accountKey.Address,
+		base.account.address,
 	)
 
+	transactorOptions, err := base.account.transactorOptions(base.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate transactor: [%v]", err)
+	}
+
 	keepRandomBeaconServiceContract, err :=
-		contract.NewKeepRandomBeaconService(
+		contract.NewKeepRandomBeaconServiceWithTransactor(
 			address,
-			base.chainID,
-			base.accountKey,
+			transactorOptions,
 			base.client,
 			nonceManager,
 			miningWaiter,
@@ -334,6 +403,128 @@ func Connect(
 	return connect(ctx, config)
 }
 
+// ConnectWithSigner makes the network connection to the Ethereum network and
+// returns a standard handle to the chain interface for the given operator
+// account. The operator key is not read; transactions and messages are
+// signed by the external signer holding it. The account configured in the
+// Ethereum config is not used.
+func ConnectWithSigner(
+	ctx context.Context,
+	config ethereum.Config,
+	signerClient *signer.Client,
+	address common.Address,
+) (chain.Handle, error) {
+	signerAccount, err := newSignerAccount(ctx, signerClient, address)
+	if err != nil {
+		return nil, err
+	}
+
+	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	chains, err := connectOperatorsWithClient(
+		ctx,
+		config,
+		client,
+		clientWS,
+		clientRPC,
+		[]*account{signerAccount},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chains[0], nil
+}
+
+// ConnectReadOnly makes the network connection to the Ethereum network and
+// returns a read-only handle to the chain interface, for nodes which do not
+// operate, such as bootstrap nodes. No key is read; the handle can check
+// stakes and watch chain events but all the transactions are refused. The
+// account configured in the Ethereum config is not used.
+func ConnectReadOnly(
+	ctx context.Context,
+	config ethereum.Config,
+) (chain.ReadOnlyHandle, error) {
+	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	chains, err := connectOperatorsWithClient(
+		ctx,
+		config,
+		client,
+		clientWS,
+		clientRPC,
+		[]*account{newReadOnlyAccount()},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chains[0], nil
+}
+
+// ConnectOperators makes a single network connection to the Ethereum network
+// and returns a handle to the chain interface for each of the given operator
+// keys, in the same order. The handles share the Ethereum client, the block
+// counter and subscriptions to contract events; transactions are signed with
+// the respective operator key. The account configured in the Ethereum config
+// is not used.
+func ConnectOperators(
+	ctx context.Context,
+	config ethereum.Config,
+	operatorKeys []*keystore.Key,
+) ([]chain.Handle, error) {
+	if len(operatorKeys) == 0 {
+		return nil, fmt.Errorf("no operator keys")
+	}
+
+	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	accounts := make([]*account, len(operatorKeys))
+	for i, operatorKey := range operatorKeys {
+		accounts[i] = newKeyAccount(operatorKey)
+	}
+
+	chains, err := connectOperatorsWithClient(
+		ctx,
+		config,
+		client,
+		clientWS,
+		clientRPC,
+		accounts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	handles := make([]chain.Handle, len(chains))
+	for i, ec := range chains {
+		handles[i] = ec
+	}
+
+	return handles, nil
+}
+
 // BlockCounter creates a BlockCounter that uses the block number in ethereum.
 func (ec *ethereumChain) BlockCounter() (chain.BlockCounter, error) {
 	return ec.blockCounter, nil
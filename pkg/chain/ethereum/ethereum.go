@@ -26,11 +26,11 @@ func (ec *ethereumChain) ThresholdRelay() relayChain.Interface {
 }
 
 func (ec *ethereumChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
-	return operator.ChainKeyToOperatorKey(ec.accountKey)
+	return ec.account.operatorKeys()
 }
 
 func (ec *ethereumChain) Signing() chain.Signing {
-	return ethutil.NewSigner(ec.accountKey.PrivateKey)
+	return ec.account.signing()
 }
 
 func (ec *ethereumChain) GetConfig() *relayChain.Config {
@@ -156,37 +156,35 @@ func (ec *ethereumChain) SubmitRelayEntry(
 		}
 	}
 
-	generatedEntry := make(chan *event.EntrySubmitted)
+	// The handler never blocks so that it does not hold up the subscription
+	// when the promise has already been completed.
+	generatedEntry := make(chan *event.EntrySubmitted, 1)
+	submissionFailed := make(chan struct{})
 
 	subscription := ec.OnRelayEntrySubmitted(
 		func(onChainEvent *event.EntrySubmitted) {
-			generatedEntry <- onChainEvent
+			select {
+			case generatedEntry <- onChainEvent:
+			default:
+			}
 		},
 	)
 
 	go func() {
-		for {
-			select {
-			case event, success := <-generatedEntry:
-				// Channel is closed when SubmitRelayEntry failed.
-				// When this happens, event is nil.
-				if !success {
-					return
-				}
-
-				subscription.Unsubscribe()
-				close(generatedEntry)
-
-				err := relayEntryPromise.Fulfill(event)
-				if err != nil {
-					logger.Errorf(
-						"failed to fulfill promise: [%v]",
-						err,
-					)
-				}
-
-				return
+		select {
+		case event := <-generatedEntry:
+			subscription.Unsubscribe()
+
+			err := relayEntryPromise.Fulfill(event)
+			if err != nil {
+				logger.Errorf(
+					"failed to fulfill promise: [%v]",
+					err,
+				)
 			}
+		case <-submissionFailed:
+			// The subscription is already closed and the promise failed
+			// by the submitting code.
 		}
 	}()
 
@@ -204,7 +202,7 @@ func (ec *ethereumChain) SubmitRelayEntry(
 	)
 	if err != nil {
 		subscription.Unsubscribe()
-		close(generatedEntry)
+		close(submissionFailed)
 		failPromise(err)
 	}
 
@@ -214,81 +212,97 @@ func (ec *ethereumChain) SubmitRelayEntry(
 func (ec *ethereumChain) OnRelayEntrySubmitted(
 	handle func(entry *event.EntrySubmitted),
 ) subscription.EventSubscription {
-	onEvent := func(blockNumber uint64) {
-		handle(&event.EntrySubmitted{
-			BlockNumber: blockNumber,
-		})
-	}
-
-	subscription := ec.keepRandomBeaconOperatorContract.RelayEntrySubmitted(
-		nil,
-	).OnEvent(onEvent)
-
-	return subscription
+	return ec.events.subscribe(
+		"RelayEntrySubmitted",
+		func(emit func(event interface{})) subscription.EventSubscription {
+			return ec.keepRandomBeaconOperatorContract.RelayEntrySubmitted(
+				nil,
+			).OnEvent(func(blockNumber uint64) {
+				emit(&event.EntrySubmitted{
+					BlockNumber: blockNumber,
+				})
+			})
+		},
+		func(e interface{}) {
+			handle(e.(*event.EntrySubmitted))
+		},
+	)
 }
 
 func (ec *ethereumChain) OnRelayEntryRequested(
 	handle func(request *event.Request),
 ) subscription.EventSubscription {
-	onEvent := func(
-		previousEntry []byte,
-		groupPublicKey []byte,
-		blockNumber uint64,
-	) {
-		handle(&event.Request{
-			PreviousEntry:  previousEntry,
-			GroupPublicKey: groupPublicKey,
-			BlockNumber:    blockNumber,
-		})
-	}
-
-	subscription := ec.keepRandomBeaconOperatorContract.RelayEntryRequested(
-		nil,
-	).OnEvent(onEvent)
-
-	return subscription
+	return ec.events.subscribe(
+		"RelayEntryRequested",
+		func(emit func(event interface{})) subscription.EventSubscription {
+			return ec.keepRandomBeaconOperatorContract.RelayEntryRequested(
+				nil,
+			).OnEvent(func(
+				previousEntry []byte,
+				groupPublicKey []byte,
+				blockNumber uint64,
+			) {
+				emit(&event.Request{
+					PreviousEntry:  previousEntry,
+					GroupPublicKey: groupPublicKey,
+					BlockNumber:    blockNumber,
+				})
+			})
+		},
+		func(e interface{}) {
+			handle(e.(*event.Request))
+		},
+	)
 }
 
 func (ec *ethereumChain) OnGroupSelectionStarted(
 	handle func(groupSelectionStart *event.GroupSelectionStart),
 ) subscription.EventSubscription {
-	onEvent := func(
-		newEntry *big.Int,
-		blockNumber uint64,
-	) {
-		handle(&event.GroupSelectionStart{
-			NewEntry:    newEntry,
-			BlockNumber: blockNumber,
-		})
-	}
-
-	subscription := ec.keepRandomBeaconOperatorContract.GroupSelectionStarted(
-		nil,
-	).OnEvent(onEvent)
-
-	return subscription
+	return ec.events.subscribe(
+		"GroupSelectionStarted",
+		func(emit func(event interface{})) subscription.EventSubscription {
+			return ec.keepRandomBeaconOperatorContract.GroupSelectionStarted(
+				nil,
+			).OnEvent(func(
+				newEntry *big.Int,
+				blockNumber uint64,
+			) {
+				emit(&event.GroupSelectionStart{
+					NewEntry:    newEntry,
+					BlockNumber: blockNumber,
+				})
+			})
+		},
+		func(e interface{}) {
+			handle(e.(*event.GroupSelectionStart))
+		},
+	)
 }
 
 func (ec *ethereumChain) OnGroupRegistered(
 	handle func(groupRegistration *event.GroupRegistration),
 ) subscription.EventSubscription {
-	onEvent := func(
-		memberIndex *big.Int,
-		groupPublicKey []byte,
-		misbehaved []byte,
-		blockNumber uint64,
-	) {
-		handle(&event.GroupRegistration{
-			GroupPublicKey: groupPublicKey,
-			BlockNumber:    blockNumber,
-		})
-	}
-
-	subscription := ec.keepRandomBeaconOperatorContract.DkgResultSubmittedEvent(
-		nil,
-	).OnEvent(onEvent)
-
-	return subscription
+	return ec.events.subscribe(
+		"GroupRegistered",
+		func(emit func(event interface{})) subscription.EventSubscription {
+			return ec.keepRandomBeaconOperatorContract.DkgResultSubmittedEvent(
+				nil,
+			).OnEvent(func(
+				memberIndex *big.Int,
+				groupPublicKey []byte,
+				misbehaved []byte,
+				blockNumber uint64,
+			) {
+				emit(&event.GroupRegistration{
+					GroupPublicKey: groupPublicKey,
+					BlockNumber:    blockNumber,
+				})
+			})
+		},
+		func(e interface{}) {
+			handle(e.(*event.GroupRegistration))
+		},
+	)
 }
 
 func (ec *ethereumChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
@@ -321,25 +335,29 @@ func (ec *ethereumChain) GetGroupMembers(groupPublicKey []byte) (
 func (ec *ethereumChain) OnDKGResultSubmitted(
 	handler func(dkgResultPublication *event.DKGResultSubmission),
 ) subscription.EventSubscription {
-	onEvent := func(
-		memberIndex *big.Int,
-		groupPublicKey []byte,
-		misbehaved []byte,
-		blockNumber uint64,
-	) {
-		handler(&event.DKGResultSubmission{
-			MemberIndex:    uint32(memberIndex.Uint64()),
-			GroupPublicKey: groupPublicKey,
-			Misbehaved:     misbehaved,
-			BlockNumber:    blockNumber,
-		})
-	}
-
-	subscription := ec.keepRandomBeaconOperatorContract.DkgResultSubmittedEvent(
-		nil,
-	).OnEvent(onEvent)
-
-	return subscription
+	return ec.events.subscribe(
+		"DKGResultSubmitted",
+		func(emit func(event interface{})) subscription.EventSubscription {
+			return ec.keepRandomBeaconOperatorContract.DkgResultSubmittedEvent(
+				nil,
+			).OnEvent(func(
+				memberIndex *big.Int,
+				groupPublicKey []byte,
+				misbehaved []byte,
+				blockNumber uint64,
+			) {
+				emit(&event.DKGResultSubmission{
+					MemberIndex:    uint32(memberIndex.Uint64()),
+					GroupPublicKey: groupPublicKey,
+					Misbehaved:     misbehaved,
+					BlockNumber:    blockNumber,
+				})
+			})
+		},
+		func(e interface{}) {
+			handler(e.(*event.DKGResultSubmission))
+		},
+	)
 }
 
 func (ec *ethereumChain) ReportRelayEntryTimeout() error {
@@ -490,5 +508,5 @@ func (ec *ethereumChain) CalculateDKGResultHash(
 }
 
 func (ec *ethereumChain) Address() common.Address {
-	return ec.accountKey.Address
+	return ec.account.address
 }
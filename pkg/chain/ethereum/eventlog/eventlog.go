@@ -0,0 +1,354 @@
+// Package eventlog decodes event logs emitted by contracts based on their
+// ABI and streams them from a range of blocks, following new blocks as they
+// get mined.
+package eventlog
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Event is a decoded contract event log.
+type Event struct {
+	Contract        string                 `json:"contract"`
+	Event           string                 `json:"event"`
+	BlockNumber     uint64                 `json:"blockNumber"`
+	TransactionHash common.Hash            `json:"transactionHash"`
+	LogIndex        uint                   `json:"logIndex"`
+	Fields          map[string]interface{} `json:"fields"`
+}
+
+// Decoder decodes event logs of the given contract.
+type Decoder struct {
+	contract string
+	events   map[common.Hash]abi.Event
+}
+
+// NewDecoder creates a decoder of the given events of the contract with the
+// given ABI. All events of the contract are decoded if no event names are
+// given.
+func NewDecoder(
+	contract string,
+	contractABI string,
+	eventNames ...string,
+) (*Decoder, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse ABI of [%v]: [%v]", contract, err)
+	}
+
+	events := make(map[common.Hash]abi.Event)
+	if len(eventNames) == 0 {
+		for _, event := range parsedABI.Events {
+			events[event.ID] = event
+		}
+	}
+	for _, eventName := range eventNames {
+		event, ok := parsedABI.Events[eventName]
+		if !ok {
+			return nil, fmt.Errorf(
+				"contract [%v] has no event [%v]; available events are [%v]",
+				contract,
+				eventName,
+				strings.Join(EventNames(parsedABI), ", "),
+			)
+		}
+		events[event.ID] = event
+	}
+
+	return &Decoder{
+		contract: contract,
+		events:   events,
+	}, nil
+}
+
+// EventNames returns sorted names of all events in the given ABI.
+func EventNames(contractABI abi.ABI) []string {
+	names := make([]string, 0, len(contractABI.Events))
+	for name := range contractABI.Events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Topics returns topics of a filter query matching the decoded events.
+func (d *Decoder) Topics() [][]common.Hash {
+	ids := make([]common.Hash, 0, len(d.events))
+	for id := range d.events {
+		ids = append(ids, id)
+	}
+	return [][]common.Hash{ids}
+}
+
+// Decode decodes the given log. Values of indexed fields of dynamic types are
+// not recoverable from the log and are decoded as hashes of the values.
+func (d *Decoder) Decode(log types.Log) (*Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
+	event, ok := d.events[log.Topics[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown event with ID [%v]", log.Topics[0].Hex())
+	}
+
+	fields := make(map[string]interface{})
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(fields, log.Data); err != nil {
+		return nil, fmt.Errorf(
+			"could not unpack data of event [%v]: [%v]",
+			event.Name,
+			err,
+		)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(log.Topics)-1 {
+		return nil, fmt.Errorf(
+			"event [%v] has [%v] indexed fields but log has [%v] topics",
+			event.Name,
+			len(indexed),
+			len(log.Topics)-1,
+		)
+	}
+
+	for i, input := range indexed {
+		topic := log.Topics[i+1]
+		if isDynamic(input.Type) {
+			fields[input.Name] = topic
+			continue
+		}
+		if err := abi.ParseTopicsIntoMap(
+			fields,
+			abi.Arguments{input},
+			[]common.Hash{topic},
+		); err != nil {
+			return nil, fmt.Errorf(
+				"could not parse topic of field [%v] of event [%v]: [%v]",
+				input.Name,
+				event.Name,
+				err,
+			)
+		}
+	}
+
+	for name, value := range fields {
+		fields[name] = jsonValue(value)
+	}
+
+	return &Event{
+		Contract:        d.contract,
+		Event:           event.Name,
+		BlockNumber:     log.BlockNumber,
+		TransactionHash: log.TxHash,
+		LogIndex:        log.Index,
+		Fields:          fields,
+	}, nil
+}
+
+func isDynamic(argumentType abi.Type) bool {
+	switch argumentType.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonValue converts byte slices and arrays to hex strings and integers to
+// decimal strings so that they are not misinterpreted by JSON consumers.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Bytes(v)
+	case [32]byte:
+		return hexutil.Bytes(v[:])
+	case *big.Int:
+		return v.String()
+	case []*big.Int:
+		values := make([]string, len(v))
+		for i, value := range v {
+			values[i] = value.String()
+		}
+		return values
+	case [][]byte:
+		values := make([]hexutil.Bytes, len(v))
+		for i, value := range v {
+			values[i] = value
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// Client is the subset of the Ethereum client used to stream event logs.
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// StreamOptions control the range of blocks from which event logs are
+// streamed.
+type StreamOptions struct {
+	// FromBlock is the first block from which event logs are streamed. If
+	// nil, event logs are streamed starting from the block following the
+	// current confirmed one or, if no block has been confirmed yet, from the
+	// current block.
+	FromBlock *uint64
+	// UntilBlock is the last block from which event logs are streamed. If
+	// nil, new blocks are followed until the context is done.
+	UntilBlock *uint64
+	// PollInterval is the time between checks for new blocks.
+	PollInterval time.Duration
+	// ChunkSize is the maximum number of blocks fetched with a single query.
+	ChunkSize uint64
+	// Confirmations is the number of blocks which have to be mined on top
+	// of a block before event logs from it are streamed, so that logs from
+	// blocks which may still be reorganized out of the chain are not
+	// streamed.
+	Confirmations uint64
+}
+
+// Stream passes to the handler every event log of the decoded events
+// emitted by the contract at the given address, in the order in which they
+// were emitted. Event logs are streamed only from blocks with the number of
+// confirmations given in the options. It returns once all the blocks up to
+// the until block have been processed, when the context is done or when the
+// handler returns an error.
+func Stream(
+	ctx context.Context,
+	client Client,
+	address common.Address,
+	decoder *Decoder,
+	options *StreamOptions,
+	handler func(*Event) error,
+) error {
+	if options.ChunkSize == 0 {
+		return fmt.Errorf("chunk size must be greater than zero")
+	}
+
+	var nextBlock uint64
+	if options.FromBlock != nil {
+		nextBlock = *options.FromBlock
+	} else {
+		currentBlock, err := client.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get current block: [%v]", err)
+		}
+
+		// If the chain is shorter than the number of confirmations, event
+		// logs are streamed from the current block rather than from the
+		// genesis one.
+		nextBlock = currentBlock
+		if currentBlock >= options.Confirmations {
+			nextBlock = currentBlock - options.Confirmations + 1
+		}
+	}
+
+	for {
+		confirmedBlock, isConfirmed, err := currentConfirmedBlock(
+			ctx,
+			client,
+			options.Confirmations,
+		)
+		if err != nil {
+			return err
+		}
+
+		lastBlock := confirmedBlock
+		if options.UntilBlock != nil && *options.UntilBlock < lastBlock {
+			lastBlock = *options.UntilBlock
+		}
+
+		for isConfirmed && nextBlock <= lastBlock {
+			chunkEnd := nextBlock + options.ChunkSize - 1
+			if chunkEnd > lastBlock {
+				chunkEnd = lastBlock
+			}
+
+			logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(nextBlock),
+				ToBlock:   new(big.Int).SetUint64(chunkEnd),
+				Addresses: []common.Address{address},
+				Topics:    decoder.Topics(),
+			})
+			if err != nil {
+				return fmt.Errorf(
+					"could not get logs from blocks [%v-%v]: [%v]",
+					nextBlock,
+					chunkEnd,
+					err,
+				)
+			}
+
+			sort.SliceStable(logs, func(i, j int) bool {
+				if logs[i].BlockNumber != logs[j].BlockNumber {
+					return logs[i].BlockNumber < logs[j].BlockNumber
+				}
+				return logs[i].Index < logs[j].Index
+			})
+
+			for _, log := range logs {
+				if log.Removed {
+					continue
+				}
+
+				event, err := decoder.Decode(log)
+				if err != nil {
+					return err
+				}
+
+				if err := handler(event); err != nil {
+					return err
+				}
+			}
+
+			nextBlock = chunkEnd + 1
+		}
+
+		if options.UntilBlock != nil && nextBlock > *options.UntilBlock {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(options.PollInterval):
+		}
+	}
+}
+
+// currentConfirmedBlock returns the most recent block with the given number
+// of confirmations. It returns false if no block has been confirmed yet.
+func currentConfirmedBlock(
+	ctx context.Context,
+	client Client,
+	confirmations uint64,
+) (uint64, bool, error) {
+	currentBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	if currentBlock < confirmations {
+		return 0, false, nil
+	}
+
+	return currentBlock - confirmations, true, nil
+}
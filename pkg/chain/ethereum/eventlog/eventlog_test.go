@@ -0,0 +1,449 @@
+package eventlog
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const testABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "groupIndex", "type": "uint256"},
+			{"indexed": false, "name": "entry", "type": "bytes"}
+		],
+		"name": "EntrySubmitted",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "operator", "type": "address"}
+		],
+		"name": "OperatorRegistered",
+		"type": "event"
+	}
+]`
+
+var testAddress = common.HexToAddress("0x65EA55c1f10491038425725dC00dFFEAb2A1e28A")
+
+func TestDecode(t *testing.T) {
+	decoder, err := NewDecoder("test", testABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := entrySubmittedLog(t, 12, 7, []byte{0xca, 0xfe})
+
+	event, err := decoder.Decode(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEvent := &Event{
+		Contract:        "test",
+		Event:           "EntrySubmitted",
+		BlockNumber:     12,
+		TransactionHash: log.TxHash,
+		LogIndex:        log.Index,
+		Fields: map[string]interface{}{
+			"groupIndex": "7",
+			"entry":      hexutil.Bytes{0xca, 0xfe},
+		},
+	}
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf(
+			"unexpected event\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvent,
+			event,
+		)
+	}
+}
+
+func TestDecodeIndexedOnly(t *testing.T) {
+	decoder, err := NewDecoder("test", testABI, "OperatorRegistered")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operator := common.HexToAddress("0x3712C6fED51CECA83cA953f6FF3458f2339436b4")
+
+	event, err := decoder.Decode(operatorRegisteredLog(t, 3, operator))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFields := map[string]interface{}{"operator": operator}
+	if !reflect.DeepEqual(expectedFields, event.Fields) {
+		t.Errorf(
+			"unexpected fields\nexpected: [%v]\nactual:   [%v]",
+			expectedFields,
+			event.Fields,
+		)
+	}
+}
+
+func TestNewDecoderUnknownEvent(t *testing.T) {
+	_, err := NewDecoder("test", testABI, "EntryRequested")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(err.Error(), "EntrySubmitted, OperatorRegistered") {
+		t.Errorf("expected available events in error [%v]", err)
+	}
+}
+
+func TestStreamFromBlockUntilBlock(t *testing.T) {
+	operator := common.HexToAddress("0x3712C6fED51CECA83cA953f6FF3458f2339436b4")
+
+	client := &testClient{
+		currentBlock: 20,
+		logs: []types.Log{
+			entrySubmittedLog(t, 2, 1, []byte{0x01}),
+			entrySubmittedLog(t, 5, 2, []byte{0x02}),
+			operatorRegisteredLog(t, 6, operator),
+			entrySubmittedLog(t, 9, 3, []byte{0x03}),
+			entrySubmittedLog(t, 11, 4, []byte{0x04}),
+		},
+	}
+
+	decoder, err := NewDecoder("test", testABI, "EntrySubmitted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromBlock, untilBlock := uint64(3), uint64(10)
+
+	var blocks []uint64
+	err = Stream(
+		context.Background(),
+		client,
+		testAddress,
+		decoder,
+		&StreamOptions{
+			FromBlock:    &fromBlock,
+			UntilBlock:   &untilBlock,
+			PollInterval: time.Millisecond,
+			ChunkSize:    3,
+		},
+		func(event *Event) error {
+			blocks = append(blocks, event.BlockNumber)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlocks := []uint64{5, 9}
+	if !reflect.DeepEqual(expectedBlocks, blocks) {
+		t.Errorf(
+			"unexpected event blocks\nexpected: [%v]\nactual:   [%v]",
+			expectedBlocks,
+			blocks,
+		)
+	}
+
+	expectedQueries := [][2]uint64{{3, 5}, {6, 8}, {9, 10}}
+	if !reflect.DeepEqual(expectedQueries, client.queries) {
+		t.Errorf(
+			"unexpected queries\nexpected: [%v]\nactual:   [%v]",
+			expectedQueries,
+			client.queries,
+		)
+	}
+}
+
+func TestStreamFollowsNewBlocks(t *testing.T) {
+	client := &testClient{
+		currentBlock: 10,
+		blockStep:    1,
+		logs: []types.Log{
+			entrySubmittedLog(t, 9, 1, []byte{0x01}),
+			entrySubmittedLog(t, 12, 2, []byte{0x02}),
+			entrySubmittedLog(t, 14, 3, []byte{0x03}),
+			entrySubmittedLog(t, 16, 4, []byte{0x04}),
+		},
+	}
+
+	decoder, err := NewDecoder("test", testABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	untilBlock := uint64(15)
+
+	var blocks []uint64
+	err = Stream(
+		context.Background(),
+		client,
+		testAddress,
+		decoder,
+		&StreamOptions{
+			UntilBlock:   &untilBlock,
+			PollInterval: time.Millisecond,
+			ChunkSize:    100,
+		},
+		func(event *Event) error {
+			blocks = append(blocks, event.BlockNumber)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlocks := []uint64{12, 14}
+	if !reflect.DeepEqual(expectedBlocks, blocks) {
+		t.Errorf(
+			"unexpected event blocks\nexpected: [%v]\nactual:   [%v]",
+			expectedBlocks,
+			blocks,
+		)
+	}
+}
+
+func TestStreamWaitsForConfirmations(t *testing.T) {
+	client := &testClient{
+		currentBlock: 20,
+		logs: []types.Log{
+			entrySubmittedLog(t, 5, 1, []byte{0x01}),
+			entrySubmittedLog(t, 15, 2, []byte{0x02}),
+			entrySubmittedLog(t, 16, 3, []byte{0x03}),
+		},
+	}
+
+	decoder, err := NewDecoder("test", testABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelCtx()
+
+	fromBlock := uint64(3)
+
+	var blocks []uint64
+	err = Stream(
+		ctx,
+		client,
+		testAddress,
+		decoder,
+		&StreamOptions{
+			FromBlock:     &fromBlock,
+			PollInterval:  10 * time.Millisecond,
+			ChunkSize:     100,
+			Confirmations: 5,
+		},
+		func(event *Event) error {
+			blocks = append(blocks, event.BlockNumber)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlocks := []uint64{5, 15}
+	if !reflect.DeepEqual(expectedBlocks, blocks) {
+		t.Errorf(
+			"unexpected event blocks\nexpected: [%v]\nactual:   [%v]",
+			expectedBlocks,
+			blocks,
+		)
+	}
+
+	expectedQueries := [][2]uint64{{3, 15}}
+	if !reflect.DeepEqual(expectedQueries, client.queries) {
+		t.Errorf(
+			"unexpected queries\nexpected: [%v]\nactual:   [%v]",
+			expectedQueries,
+			client.queries,
+		)
+	}
+}
+
+func TestStreamStartsAtCurrentBlockBeforeConfirmations(t *testing.T) {
+	client := &testClient{
+		currentBlock: 3,
+		blockStep:    1,
+		logs: []types.Log{
+			entrySubmittedLog(t, 1, 1, []byte{0x01}),
+			entrySubmittedLog(t, 3, 2, []byte{0x02}),
+			entrySubmittedLog(t, 4, 3, []byte{0x03}),
+		},
+	}
+
+	decoder, err := NewDecoder("test", testABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	untilBlock := uint64(4)
+
+	var blocks []uint64
+	err = Stream(
+		context.Background(),
+		client,
+		testAddress,
+		decoder,
+		&StreamOptions{
+			UntilBlock:    &untilBlock,
+			PollInterval:  time.Millisecond,
+			ChunkSize:     100,
+			Confirmations: 5,
+		},
+		func(event *Event) error {
+			blocks = append(blocks, event.BlockNumber)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlocks := []uint64{3, 4}
+	if !reflect.DeepEqual(expectedBlocks, blocks) {
+		t.Errorf(
+			"unexpected event blocks\nexpected: [%v]\nactual:   [%v]",
+			expectedBlocks,
+			blocks,
+		)
+	}
+}
+
+func TestStreamStopsWhenContextIsDone(t *testing.T) {
+	client := &testClient{currentBlock: 10}
+
+	decoder, err := NewDecoder("test", testABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelCtx()
+
+	err = Stream(
+		ctx,
+		client,
+		testAddress,
+		decoder,
+		&StreamOptions{PollInterval: 10 * time.Millisecond, ChunkSize: 100},
+		func(event *Event) error { return nil },
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func entrySubmittedLog(
+	t *testing.T,
+	blockNumber uint64,
+	groupIndex int64,
+	entry []byte,
+) types.Log {
+	event := testEvent(t, "EntrySubmitted")
+
+	data, err := event.Inputs.NonIndexed().Pack(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return types.Log{
+		Address: testAddress,
+		Topics: []common.Hash{
+			event.ID,
+			common.BigToHash(big.NewInt(groupIndex)),
+		},
+		Data:        data,
+		BlockNumber: blockNumber,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+	}
+}
+
+func operatorRegisteredLog(
+	t *testing.T,
+	blockNumber uint64,
+	operator common.Address,
+) types.Log {
+	event := testEvent(t, "OperatorRegistered")
+
+	return types.Log{
+		Address: testAddress,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(operator.Bytes()),
+		},
+		BlockNumber: blockNumber,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+	}
+}
+
+func testEvent(t *testing.T, name string) abi.Event {
+	parsedABI, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parsedABI.Events[name]
+}
+
+// testClient serves logs from memory, advancing the current block by the
+// block step every time it is queried.
+type testClient struct {
+	mutex        sync.Mutex
+	currentBlock uint64
+	blockStep    uint64
+	logs         []types.Log
+	queries      [][2]uint64
+}
+
+func (tc *testClient) BlockNumber(ctx context.Context) (uint64, error) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	currentBlock := tc.currentBlock
+	tc.currentBlock += tc.blockStep
+	return currentBlock, nil
+}
+
+func (tc *testClient) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	fromBlock, toBlock := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	tc.queries = append(tc.queries, [2]uint64{fromBlock, toBlock})
+
+	topics := make(map[common.Hash]bool)
+	for _, topic := range query.Topics[0] {
+		topics[topic] = true
+	}
+
+	var logs []types.Log
+	for _, log := range tc.logs {
+		if log.BlockNumber < fromBlock || log.BlockNumber > toBlock {
+			continue
+		}
+		if !topics[log.Topics[0]] {
+			continue
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
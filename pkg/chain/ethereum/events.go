@@ -0,0 +1,178 @@
+package ethereum
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// eventHub shares a single contract event subscription of each event type
+// among all the handlers registered for it. Chain handles of operators
+// connected through the same Ethereum client share one hub so that the
+// number of subscriptions, and of past events pulled by them, does not grow
+// with the number of operators.
+type eventHub struct {
+	mutex         sync.Mutex
+	subscriptions map[string]*sharedSubscription
+}
+
+type sharedSubscription struct {
+	subscription  subscription.EventSubscription
+	handlers      map[uint64]*handlerQueue
+	nextHandlerID uint64
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscriptions: make(map[string]*sharedSubscription),
+	}
+}
+
+// subscribe registers the handler of events with the given name. The
+// subscribe function creates the underlying contract subscription, passing
+// all the received events to the emit function; it is called only if there
+// is no subscription to the event yet. The underlying subscription is closed
+// when the last handler unsubscribes. Each handler receives events in its own
+// goroutine, in the order in which they were emitted, so a handler which
+// blocks or panics does not prevent other handlers from receiving events.
+func (eh *eventHub) subscribe(
+	eventName string,
+	subscribe func(emit func(event interface{})) subscription.EventSubscription,
+	handler func(event interface{}),
+) subscription.EventSubscription {
+	eh.mutex.Lock()
+	defer eh.mutex.Unlock()
+
+	shared, ok := eh.subscriptions[eventName]
+	if !ok {
+		shared = &sharedSubscription{
+			handlers: make(map[uint64]*handlerQueue),
+		}
+		eh.subscriptions[eventName] = shared
+		shared.subscription = subscribe(func(event interface{}) {
+			eh.emit(eventName, event)
+		})
+	}
+
+	handlerID := shared.nextHandlerID
+	shared.nextHandlerID++
+	queue := newHandlerQueue(eventName, handler)
+	shared.handlers[handlerID] = queue
+
+	return subscription.NewEventSubscription(func() {
+		eh.mutex.Lock()
+		defer eh.mutex.Unlock()
+
+		if _, ok := shared.handlers[handlerID]; !ok {
+			return
+		}
+
+		delete(shared.handlers, handlerID)
+		queue.close()
+
+		if len(shared.handlers) == 0 && eh.subscriptions[eventName] == shared {
+			delete(eh.subscriptions, eventName)
+			shared.subscription.Unsubscribe()
+		}
+	})
+}
+
+func (eh *eventHub) emit(eventName string, event interface{}) {
+	eh.mutex.Lock()
+	defer eh.mutex.Unlock()
+
+	if shared, ok := eh.subscriptions[eventName]; ok {
+		for _, queue := range shared.handlers {
+			queue.push(event)
+		}
+	}
+}
+
+// handlerQueue passes events to the handler in its own goroutine. Events are
+// queued without a limit so that emitting them never waits for the handler.
+type handlerQueue struct {
+	eventName string
+	handler   func(event interface{})
+
+	mutex   sync.Mutex
+	pending []interface{}
+	signal  chan struct{}
+	quit    chan struct{}
+}
+
+func newHandlerQueue(
+	eventName string,
+	handler func(event interface{}),
+) *handlerQueue {
+	queue := &handlerQueue{
+		eventName: eventName,
+		handler:   handler,
+		signal:    make(chan struct{}, 1),
+		quit:      make(chan struct{}),
+	}
+
+	go queue.loop()
+
+	return queue
+}
+
+func (hq *handlerQueue) push(event interface{}) {
+	hq.mutex.Lock()
+	hq.pending = append(hq.pending, event)
+	hq.mutex.Unlock()
+
+	select {
+	case hq.signal <- struct{}{}:
+	default:
+	}
+}
+
+// close stops passing events to the handler. Events still queued are
+// dropped.
+func (hq *handlerQueue) close() {
+	close(hq.quit)
+}
+
+func (hq *handlerQueue) loop() {
+	for {
+		select {
+		case <-hq.quit:
+			return
+		case <-hq.signal:
+		}
+
+		for {
+			select {
+			case <-hq.quit:
+				return
+			default:
+			}
+
+			hq.mutex.Lock()
+			if len(hq.pending) == 0 {
+				hq.mutex.Unlock()
+				break
+			}
+			event := hq.pending[0]
+			hq.pending[0] = nil
+			hq.pending = hq.pending[1:]
+			hq.mutex.Unlock()
+
+			hq.handle(event)
+		}
+	}
+}
+
+func (hq *handlerQueue) handle(event interface{}) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Errorf(
+				"handler of event [%v] panicked: [%v]",
+				hq.eventName,
+				recovered,
+			)
+		}
+	}()
+
+	hq.handler(event)
+}
@@ -0,0 +1,139 @@
+package ethereum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+func TestEventHubSharesSubscription(t *testing.T) {
+	hub := newEventHub()
+
+	subscribeCount := 0
+	unsubscribeCount := 0
+	var emit func(event interface{})
+	subscribe := func(
+		emitFn func(event interface{}),
+	) subscription.EventSubscription {
+		subscribeCount++
+		emit = emitFn
+		return subscription.NewEventSubscription(func() {
+			unsubscribeCount++
+		})
+	}
+
+	first := make(chan string, 10)
+	third := make(chan string, 10)
+
+	subscription1 := hub.subscribe("Event", subscribe, func(event interface{}) {
+		first <- event.(string)
+	})
+	subscription2 := hub.subscribe("Event", subscribe, func(event interface{}) {
+		panic("handler failure")
+	})
+	subscription3 := hub.subscribe("Event", subscribe, func(event interface{}) {
+		third <- event.(string)
+	})
+
+	if subscribeCount != 1 {
+		t.Fatalf("expected one underlying subscription, has [%v]", subscribeCount)
+	}
+
+	emit("a")
+	assertReceived(t, first, "a")
+	assertReceived(t, third, "a")
+
+	subscription1.Unsubscribe()
+	subscription2.Unsubscribe()
+	if unsubscribeCount != 0 {
+		t.Errorf("underlying subscription closed with handlers left")
+	}
+
+	emit("b")
+	assertReceived(t, third, "b")
+	assertNotReceived(t, first)
+
+	subscription3.Unsubscribe()
+	if unsubscribeCount != 1 {
+		t.Errorf("underlying subscription not closed")
+	}
+
+	hub.subscribe("Event", subscribe, func(event interface{}) {})
+	if subscribeCount != 2 {
+		t.Errorf("expected new underlying subscription")
+	}
+}
+
+func TestEventHubBlockingHandler(t *testing.T) {
+	hub := newEventHub()
+
+	var emit func(event interface{})
+	subscribe := func(
+		emitFn func(event interface{}),
+	) subscription.EventSubscription {
+		emit = emitFn
+		return subscription.NewEventSubscription(func() {})
+	}
+
+	// Unbuffered and never read, so the handler blocks on the first event.
+	blocked := make(chan string)
+	blockingSubscription := hub.subscribe(
+		"Event",
+		subscribe,
+		func(event interface{}) {
+			blocked <- event.(string)
+		},
+	)
+	defer blockingSubscription.Unsubscribe()
+
+	received := make(chan string, 10)
+	hub.subscribe("Event", subscribe, func(event interface{}) {
+		received <- event.(string)
+	})
+
+	emitted := make(chan struct{})
+	go func() {
+		emit("a")
+		emit("b")
+		emit("c")
+		close(emitted)
+	}()
+
+	select {
+	case <-emitted:
+	case <-time.After(time.Second):
+		t.Fatal("emitting events blocked on the blocking handler")
+	}
+
+	assertReceived(t, received, "a")
+	assertReceived(t, received, "b")
+	assertReceived(t, received, "c")
+}
+
+func assertReceived(t *testing.T, events <-chan string, expected string) {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		if event != expected {
+			t.Errorf(
+				"unexpected event\nexpected: [%v]\nactual:   [%v]",
+				expected,
+				event,
+			)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("event [%v] not received", expected)
+	}
+}
+
+func assertNotReceived(t *testing.T, events <-chan string) {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event [%v]", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
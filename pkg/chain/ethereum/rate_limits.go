@@ -0,0 +1,204 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/rate"
+)
+
+// reconfigurableClient is an Ethereum client whose request rate limits can be
+// changed while the client is in use. Contracts are bound to this client once
+// and all their requests go through the rate limiter configured at the time
+// of the request.
+type reconfigurableClient struct {
+	backend ethutil.EthereumClient
+
+	mutex  sync.RWMutex
+	client ethutil.EthereumClient
+}
+
+func newReconfigurableClient(
+	backend ethutil.EthereumClient,
+	requestsPerSecondLimit int,
+	concurrencyLimit int,
+) *reconfigurableClient {
+	rc := &reconfigurableClient{backend: backend}
+	rc.setRateLimits(requestsPerSecondLimit, concurrencyLimit)
+
+	return rc
+}
+
+// setRateLimits replaces the rate limiter of the client. Requests already
+// waiting for or holding a permit of the previous limiter complete under the
+// previous limits, so the concurrency limit may be briefly exceeded right
+// after the change.
+func (rc *reconfigurableClient) setRateLimits(
+	requestsPerSecondLimit int,
+	concurrencyLimit int,
+) {
+	client := rc.backend
+	if requestsPerSecondLimit > 0 || concurrencyLimit > 0 {
+		client = ethutil.WrapRateLimiting(
+			rc.backend,
+			&rate.LimiterConfig{
+				RequestsPerSecondLimit: requestsPerSecondLimit,
+				ConcurrencyLimit:       concurrencyLimit,
+			},
+		)
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.client = client
+}
+
+func (rc *reconfigurableClient) current() ethutil.EthereumClient {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	return rc.client
+}
+
+func (rc *reconfigurableClient) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return rc.current().CodeAt(ctx, contract, blockNumber)
+}
+
+func (rc *reconfigurableClient) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return rc.current().CallContract(ctx, call, blockNumber)
+}
+
+func (rc *reconfigurableClient) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	return rc.current().PendingCodeAt(ctx, account)
+}
+
+func (rc *reconfigurableClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return rc.current().PendingNonceAt(ctx, account)
+}
+
+func (rc *reconfigurableClient) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return rc.current().SuggestGasPrice(ctx)
+}
+
+func (rc *reconfigurableClient) EstimateGas(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) (uint64, error) {
+	return rc.current().EstimateGas(ctx, call)
+}
+
+func (rc *reconfigurableClient) SendTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) error {
+	return rc.current().SendTransaction(ctx, tx)
+}
+
+func (rc *reconfigurableClient) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	return rc.current().FilterLogs(ctx, query)
+}
+
+func (rc *reconfigurableClient) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return rc.current().SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (rc *reconfigurableClient) BlockByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Block, error) {
+	return rc.current().BlockByHash(ctx, hash)
+}
+
+func (rc *reconfigurableClient) BlockByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Block, error) {
+	return rc.current().BlockByNumber(ctx, number)
+}
+
+func (rc *reconfigurableClient) HeaderByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Header, error) {
+	return rc.current().HeaderByHash(ctx, hash)
+}
+
+func (rc *reconfigurableClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return rc.current().HeaderByNumber(ctx, number)
+}
+
+func (rc *reconfigurableClient) TransactionCount(
+	ctx context.Context,
+	blockHash common.Hash,
+) (uint, error) {
+	return rc.current().TransactionCount(ctx, blockHash)
+}
+
+func (rc *reconfigurableClient) TransactionInBlock(
+	ctx context.Context,
+	blockHash common.Hash,
+	index uint,
+) (*types.Transaction, error) {
+	return rc.current().TransactionInBlock(ctx, blockHash, index)
+}
+
+func (rc *reconfigurableClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	return rc.current().SubscribeNewHead(ctx, ch)
+}
+
+func (rc *reconfigurableClient) TransactionByHash(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Transaction, bool, error) {
+	return rc.current().TransactionByHash(ctx, txHash)
+}
+
+func (rc *reconfigurableClient) TransactionReceipt(
+	ctx context.Context,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	return rc.current().TransactionReceipt(ctx, txHash)
+}
+
+func (rc *reconfigurableClient) BalanceAt(
+	ctx context.Context,
+	account common.Address,
+	blockNumber *big.Int,
+) (*big.Int, error) {
+	return rc.current().BalanceAt(ctx, account, blockNumber)
+}
@@ -1,9 +1,18 @@
 package ethereum
 
 import (
+	"context"
+	"fmt"
 	"math/big"
+	"strings"
 
+	hostchain "github.com/ethereum/go-ethereum"
+	hostchainabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 )
 
@@ -29,51 +38,472 @@ func (euc *ethereumUtilityChain) Genesis() error {
 func (euc *ethereumUtilityChain) RequestRelayEntry() *async.EventEntryGeneratedPromise {
 	promise := &async.EventEntryGeneratedPromise{}
 
-	callbackGas := big.NewInt(0) // no callback
-	payment, err := euc.keepRandomBeaconServiceContract.EntryFeeEstimate(callbackGas)
-	if err != nil {
+	failPromise := func(err error) {
 		failErr := promise.Fail(err)
 		if failErr != nil {
 			logger.Errorf("could not fail the promise: [%v]", failErr)
 		}
+	}
 
+	callbackGas := big.NewInt(0) // no callback
+	payment, err := euc.keepRandomBeaconServiceContract.EntryFeeEstimate(callbackGas)
+	if err != nil {
+		failPromise(err)
 		return promise
 	}
 
-	// In the rare case relay entry submission happens before relay request in
-	// the same block, we need to make sure we install relay entry generated
-	// callback after relay entry request tx has been confirmed to do not
-	// react on the previous relay entry.
-	_ = euc.keepRandomBeaconServiceContract.RelayEntryRequested(nil).OnEvent(
-		func(requestId *big.Int, blockNumber uint64) {
-			logger.Infof(
-				"Relay request with id [%v] created at block [%v]",
-				requestId,
-				blockNumber,
-			)
-			_ = euc.keepRandomBeaconServiceContract.RelayEntryGenerated(nil).OnEvent(
-				func(_, entry *big.Int, blockNumber uint64) {
-					fulfillErr := promise.Fulfill(&event.EntryGenerated{
-						Value:       entry,
-						BlockNumber: blockNumber,
-					})
-					if fulfillErr != nil {
-						logger.Errorf("could not fulfill the promise: [%v]", fulfillErr)
-					}
-				},
-			)
+	// Subscriptions are installed before the request is submitted so that no
+	// event related to the request is missed. Generated entries are matched
+	// with the request by the request ID, so entries generated for other
+	// requests are ignored.
+	done := make(chan struct{})
+
+	requestedBlocks := make(chan uint64, 16)
+	requestedSubscription := euc.keepRandomBeaconServiceContract.RelayEntryRequested(
+		nil,
+	).OnEvent(
+		func(_ *big.Int, blockNumber uint64) {
+			select {
+			case requestedBlocks <- blockNumber:
+			case <-done:
+			}
 		},
 	)
 
+	generatedEntries := make(chan *event.EntryGenerated, 16)
+	generatedSubscription := euc.keepRandomBeaconServiceContract.RelayEntryGenerated(
+		nil,
+	).OnEvent(
+		func(requestID, entry *big.Int, blockNumber uint64) {
+			select {
+			case generatedEntries <- &event.EntryGenerated{
+				RequestID:   requestID,
+				Value:       entry,
+				BlockNumber: blockNumber,
+			}:
+			case <-done:
+			}
+		},
+	)
+
+	cleanup := func() {
+		close(done)
+		requestedSubscription.Unsubscribe()
+		generatedSubscription.Unsubscribe()
+	}
+
 	_, err = euc.keepRandomBeaconServiceContract.RequestRelayEntry(payment)
 	if err != nil {
-		failErr := promise.Fail(err)
-		if failErr != nil {
-			logger.Errorf("could not fail the promise: [%v]", failErr)
-		}
-
+		cleanup()
+		failPromise(err)
 		return promise
 	}
 
+	go func() {
+		defer cleanup()
+
+		var requestID *big.Int
+		var request *event.EntryRequest
+		var err error
+		for request == nil {
+			blockNumber := <-requestedBlocks
+
+			requestID, request, err = euc.findOwnRelayRequest(blockNumber)
+			if err != nil {
+				failPromise(err)
+				return
+			}
+		}
+
+		logger.Infof(
+			"relay request with id [%v] created at block [%v]",
+			requestID,
+			request.BlockNumber,
+		)
+
+		for generatedEntry := range generatedEntries {
+			if generatedEntry.RequestID.Cmp(requestID) != 0 {
+				continue
+			}
+
+			entry, submissionLog, err := euc.findSubmittedRelayEntry(
+				generatedEntry.BlockNumber,
+			)
+			if err != nil {
+				failPromise(err)
+				return
+			}
+
+			signingRequest, err := euc.findSigningRequest(
+				request.BlockNumber,
+				submissionLog,
+			)
+			if err != nil {
+				failPromise(err)
+				return
+			}
+
+			generatedEntry.Entry = entry
+			generatedEntry.PreviousEntry = signingRequest.PreviousEntry
+			generatedEntry.GroupPublicKey = signingRequest.GroupPublicKey
+			generatedEntry.Request = request
+
+			fulfillErr := promise.Fulfill(generatedEntry)
+			if fulfillErr != nil {
+				logger.Errorf("could not fulfill the promise: [%v]", fulfillErr)
+			}
+
+			return
+		}
+	}()
+
 	return promise
 }
+
+// findOwnRelayRequest looks for the relay request submitted by the client's
+// account in the given block. If there is no such request in the block, nil
+// request is returned.
+func (euc *ethereumUtilityChain) findOwnRelayRequest(blockNumber uint64) (
+	*big.Int,
+	*event.EntryRequest,
+	error,
+) {
+	serviceABI, err := hostchainabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconServiceImplV1ABI),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operatorABI, err := hostchainabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceLogs, err := euc.filterContractLogs(
+		KeepRandomBeaconServiceContractName,
+		serviceABI.Events["RelayEntryRequested"].ID,
+		blockNumber,
+		blockNumber,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer := types.LatestSignerForChainID(euc.chainID)
+
+	for _, serviceLog := range serviceLogs {
+		transaction, _, err := euc.client.TransactionByHash(
+			context.Background(),
+			serviceLog.TxHash,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not get relay request transaction: [%v]",
+				err,
+			)
+		}
+
+		sender, err := types.Sender(signer, transaction)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not get relay request transaction sender: [%v]",
+				err,
+			)
+		}
+
+		if sender != euc.account.address {
+			continue
+		}
+
+		serviceEvent, err := serviceABI.Unpack(
+			"RelayEntryRequested",
+			serviceLog.Data,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not unpack relay request event: [%v]",
+				err,
+			)
+		}
+
+		operatorLogs, err := euc.filterContractLogs(
+			KeepRandomBeaconOperatorContractName,
+			operatorABI.Events["RelayEntryRequested"].ID,
+			blockNumber,
+			blockNumber,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, operatorLog := range operatorLogs {
+			if operatorLog.TxHash != serviceLog.TxHash {
+				continue
+			}
+
+			operatorEvent, err := operatorABI.Unpack(
+				"RelayEntryRequested",
+				operatorLog.Data,
+			)
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"could not unpack operator relay request event: [%v]",
+					err,
+				)
+			}
+
+			return serviceEvent[0].(*big.Int), &event.EntryRequest{
+				TransactionHash: serviceLog.TxHash.Hex(),
+				Fee:             transaction.Value(),
+				PreviousEntry:   operatorEvent[0].([]byte),
+				GroupPublicKey:  operatorEvent[1].([]byte),
+				BlockNumber:     blockNumber,
+			}, nil
+		}
+
+		return nil, nil, fmt.Errorf(
+			"no operator contract relay request found in transaction [%v]",
+			serviceLog.TxHash.Hex(),
+		)
+	}
+
+	return nil, nil, nil
+}
+
+// findSubmittedRelayEntry returns the group signature submitted to the
+// operator contract in the given block along with the log of the submission.
+// The signature is not a part of any event so it is read from the input of
+// the submitting transaction.
+func (euc *ethereumUtilityChain) findSubmittedRelayEntry(
+	blockNumber uint64,
+) ([]byte, types.Log, error) {
+	operatorABI, err := hostchainabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, types.Log{}, err
+	}
+
+	logs, err := euc.filterContractLogs(
+		KeepRandomBeaconOperatorContractName,
+		operatorABI.Events["RelayEntrySubmitted"].ID,
+		blockNumber,
+		blockNumber,
+	)
+	if err != nil {
+		return nil, types.Log{}, err
+	}
+
+	if len(logs) != 1 {
+		return nil, types.Log{}, fmt.Errorf(
+			"expected one relay entry submitted at block [%v]; found [%v]",
+			blockNumber,
+			len(logs),
+		)
+	}
+
+	transaction, _, err := euc.client.TransactionByHash(
+		context.Background(),
+		logs[0].TxHash,
+	)
+	if err != nil {
+		return nil, types.Log{}, fmt.Errorf(
+			"could not get relay entry transaction: [%v]",
+			err,
+		)
+	}
+
+	method, err := operatorABI.MethodById(transaction.Data())
+	if err != nil {
+		return nil, types.Log{}, fmt.Errorf(
+			"could not resolve relay entry transaction method: [%v]",
+			err,
+		)
+	}
+
+	if method.Name != "relayEntry" {
+		return nil, types.Log{}, fmt.Errorf(
+			"unexpected relay entry transaction method [%v]",
+			method.Name,
+		)
+	}
+
+	arguments, err := method.Inputs.Unpack(transaction.Data()[4:])
+	if err != nil {
+		return nil, types.Log{}, fmt.Errorf(
+			"could not unpack relay entry transaction input: [%v]",
+			err,
+		)
+	}
+
+	return arguments[0].([]byte), logs[0], nil
+}
+
+// findSigningRequest returns the operator contract relay request for which
+// the entry from the given submission log has been generated. If the group
+// selected for the relay request submitted at the given block did not submit
+// the entry on time, the request is repeated for another group, so the last
+// request preceding the submission is returned. The operator contract
+// processes one relay request at a time so there are no requests of other
+// service contract relay requests in between.
+func (euc *ethereumUtilityChain) findSigningRequest(
+	requestBlockNumber uint64,
+	submissionLog types.Log,
+) (*event.Request, error) {
+	operatorABI, err := hostchainabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := euc.filterContractLogs(
+		KeepRandomBeaconOperatorContractName,
+		operatorABI.Events["RelayEntryRequested"].ID,
+		requestBlockNumber,
+		submissionLog.BlockNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var requestLog *types.Log
+	for i := range logs {
+		if logs[i].BlockNumber == submissionLog.BlockNumber &&
+			logs[i].Index > submissionLog.Index {
+			break
+		}
+
+		requestLog = &logs[i]
+	}
+
+	if requestLog == nil {
+		return nil, fmt.Errorf(
+			"no operator contract relay request found between blocks [%v] and [%v]",
+			requestBlockNumber,
+			submissionLog.BlockNumber,
+		)
+	}
+
+	requestEvent, err := operatorABI.Unpack(
+		"RelayEntryRequested",
+		requestLog.Data,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not unpack operator relay request event: [%v]",
+			err,
+		)
+	}
+
+	return &event.Request{
+		PreviousEntry:  requestEvent[0].([]byte),
+		GroupPublicKey: requestEvent[1].([]byte),
+		BlockNumber:    requestLog.BlockNumber,
+	}, nil
+}
+
+func (euc *ethereumUtilityChain) filterContractLogs(
+	contractName string,
+	eventID common.Hash,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]types.Log, error) {
+	address, err := euc.config.ContractAddress(contractName)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error resolving %v contract: [%v]",
+			contractName,
+			err,
+		)
+	}
+
+	logs, err := euc.client.FilterLogs(
+		context.Background(),
+		hostchain.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: []common.Address{address},
+			Topics:    [][]common.Hash{{eventID}},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter %v contract logs: [%v]",
+			contractName,
+			err,
+		)
+	}
+
+	return logs, nil
+}
+
+func (euc *ethereumUtilityChain) NumberOfGroups() (uint64, error) {
+	numberOfGroups, err := euc.keepRandomBeaconOperatorContract.NumberOfGroups()
+	if err != nil {
+		return 0, err
+	}
+
+	return numberOfGroups.Uint64(), nil
+}
+
+func (euc *ethereumUtilityChain) FirstActiveGroupIndex() (uint64, error) {
+	index, err := euc.keepRandomBeaconOperatorContract.GetFirstActiveGroupIndex()
+	if err != nil {
+		return 0, err
+	}
+
+	return index.Uint64(), nil
+}
+
+func (euc *ethereumUtilityChain) GetGroup(index uint64) (*chain.Group, error) {
+	groupIndex := new(big.Int).SetUint64(index)
+
+	publicKey, err := euc.keepRandomBeaconOperatorContract.GetGroupPublicKey(
+		groupIndex,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not get group public key: [%v]", err)
+	}
+
+	registrationBlock, err :=
+		euc.keepRandomBeaconOperatorContract.GetGroupRegistrationTime(groupIndex)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get group registration block: [%v]",
+			err,
+		)
+	}
+
+	members, err := euc.GetGroupMembers(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not get group members: [%v]", err)
+	}
+
+	isTerminated, err := euc.keepRandomBeaconOperatorContract.IsGroupTerminated(
+		groupIndex,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not check if group is terminated: [%v]",
+			err,
+		)
+	}
+
+	isStale, err := euc.IsStaleGroup(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if group is stale: [%v]", err)
+	}
+
+	return &chain.Group{
+		Index:             index,
+		PublicKey:         publicKey,
+		RegistrationBlock: registrationBlock.Uint64(),
+		Members:           members,
+		Terminated:        isTerminated,
+		Stale:             isStale,
+	}, nil
+}
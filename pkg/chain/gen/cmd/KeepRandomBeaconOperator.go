@@ -43,6 +43,14 @@ var keepRandomBeaconOperatorDescription = `The keep-random-beacon-operator comma
 	successfully once the transaction has been submitted, but will not wait for
 	the transaction to be included in a block. They return the transaction hash.
 
+	Subcommands for mutating methods may estimate the gas needed to submit them
+	as a transaction by passing the --estimate-gas flag. By default, or when the
+	--call flag is passed, they are executed as a non-mutating call, which can
+	be used as a dry run of the transaction.
+
+	All subcommands can print their output in JSON format by passing the --json
+	flag.
+
 	Calls that require ether to be paid will get 0 ether by default, which can
 	be changed by passing the -v/--value flag.`
 
@@ -57,294 +65,294 @@ func init() {
 			ArgsUsage: "",
 			Action:    krboCurrentRequestGroupIndex,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "current-request-previous-entry",
 			Usage:     "Calls the constant method currentRequestPreviousEntry on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboCurrentRequestPreviousEntry,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "current-request-start-block",
 			Usage:     "Calls the constant method currentRequestStartBlock on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboCurrentRequestStartBlock,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "dkg-gas-estimate",
 			Usage:     "Calls the constant method dkgGasEstimate on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboDkgGasEstimate,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "dkg-submitter-reimbursement-fee",
 			Usage:     "Calls the constant method dkgSubmitterReimbursementFee on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboDkgSubmitterReimbursementFee,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "entry-verification-fee",
 			Usage:     "Calls the constant method entryVerificationFee on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboEntryVerificationFee,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "entry-verification-gas-estimate",
 			Usage:     "Calls the constant method entryVerificationGasEstimate on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboEntryVerificationGasEstimate,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "gas-price-ceiling",
 			Usage:     "Calls the constant method gasPriceCeiling on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGasPriceCeiling,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-first-active-group-index",
 			Usage:     "Calls the constant method getFirstActiveGroupIndex on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGetFirstActiveGroupIndex,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-group-member-rewards",
 			Usage:     "Calls the constant method getGroupMemberRewards on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupPubKey] ",
 			Action:    krboGetGroupMemberRewards,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-group-members",
 			Usage:     "Calls the constant method getGroupMembers on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupPubKey] ",
 			Action:    krboGetGroupMembers,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-group-public-key",
 			Usage:     "Calls the constant method getGroupPublicKey on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupIndex] ",
 			Action:    krboGetGroupPublicKey,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-group-registration-time",
 			Usage:     "Calls the constant method getGroupRegistrationTime on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupIndex] ",
 			Action:    krboGetGroupRegistrationTime,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-number-of-created-groups",
 			Usage:     "Calls the constant method getNumberOfCreatedGroups on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGetNumberOfCreatedGroups,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "group-creation-fee",
 			Usage:     "Calls the constant method groupCreationFee on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGroupCreationFee,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "group-member-base-reward",
 			Usage:     "Calls the constant method groupMemberBaseReward on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGroupMemberBaseReward,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "group-profit-fee",
 			Usage:     "Calls the constant method groupProfitFee on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGroupProfitFee,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "group-selection-gas-estimate",
 			Usage:     "Calls the constant method groupSelectionGasEstimate on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGroupSelectionGasEstimate,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "group-size",
 			Usage:     "Calls the constant method groupSize on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGroupSize,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "group-threshold",
 			Usage:     "Calls the constant method groupThreshold on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGroupThreshold,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "has-minimum-stake",
 			Usage:     "Calls the constant method hasMinimumStake on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[staker] ",
 			Action:    krboHasMinimumStake,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "has-withdrawn-rewards",
 			Usage:     "Calls the constant method hasWithdrawnRewards on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[operator] [groupIndex] ",
 			Action:    krboHasWithdrawnRewards,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-entry-in-progress",
 			Usage:     "Calls the constant method isEntryInProgress on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboIsEntryInProgress,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-group-registered",
 			Usage:     "Calls the constant method isGroupRegistered on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupPubKey] ",
 			Action:    krboIsGroupRegistered,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-group-selection-possible",
 			Usage:     "Calls the constant method isGroupSelectionPossible on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboIsGroupSelectionPossible,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-group-terminated",
 			Usage:     "Calls the constant method isGroupTerminated on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupIndex] ",
 			Action:    krboIsGroupTerminated,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-stale-group",
 			Usage:     "Calls the constant method isStaleGroup on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupPubKey] ",
 			Action:    krboIsStaleGroup,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "number-of-groups",
 			Usage:     "Calls the constant method numberOfGroups on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboNumberOfGroups,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "relay-entry-timeout",
 			Usage:     "Calls the constant method relayEntryTimeout on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboRelayEntryTimeout,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "result-publication-block-step",
 			Usage:     "Calls the constant method resultPublicationBlockStep on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboResultPublicationBlockStep,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "selected-participants",
 			Usage:     "Calls the constant method selectedParticipants on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboSelectedParticipants,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "submitted-tickets",
 			Usage:     "Calls the constant method submittedTickets on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboSubmittedTickets,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "ticket-submission-timeout",
 			Usage:     "Calls the constant method ticketSubmissionTimeout on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboTicketSubmissionTimeout,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "add-service-contract",
 			Usage:     "Calls the method addServiceContract on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[serviceContract] ",
 			Action:    krboAddServiceContract,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "create-group",
 			Usage:     "Calls the payable method createGroup on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[_newEntry] [submitter] ",
 			Action:    krboCreateGroup,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     PayableFlags,
 		}, {
 			Name:      "genesis",
 			Usage:     "Calls the payable method genesis on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboGenesis,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
+			Flags:     PayableFlags,
 		}, {
 			Name:      "refresh-gas-price",
 			Usage:     "Calls the method refreshGasPrice on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboRefreshGasPrice,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(0))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(0))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "relay-entry",
 			Usage:     "Calls the method relayEntry on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[_groupSignature] ",
 			Action:    krboRelayEntry,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "report-relay-entry-timeout",
 			Usage:     "Calls the method reportRelayEntryTimeout on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "",
 			Action:    krboReportRelayEntryTimeout,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(0))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(0))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "report-unauthorized-signing",
 			Usage:     "Calls the method reportUnauthorizedSigning on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[groupIndex] [signedMsgSender] ",
 			Action:    krboReportUnauthorizedSigning,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "sign",
 			Usage:     "Calls the payable method sign on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[requestId] [previousEntry] ",
 			Action:    krboSign,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     PayableFlags,
 		}, {
 			Name:      "withdraw-group-member-rewards",
 			Usage:     "Calls the method withdrawGroupMemberRewards on the KeepRandomBeaconOperator contract.",
 			ArgsUsage: "[operator] [groupIndex] ",
 			Action:    krboWithdrawGroupMemberRewards,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}},
 	})
 }
@@ -366,9 +374,7 @@ func krboCurrentRequestGroupIndex(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboCurrentRequestPreviousEntry(c *cli.Context) error {
@@ -386,9 +392,7 @@ func krboCurrentRequestPreviousEntry(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboCurrentRequestStartBlock(c *cli.Context) error {
@@ -406,9 +410,7 @@ func krboCurrentRequestStartBlock(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboDkgGasEstimate(c *cli.Context) error {
@@ -426,9 +428,7 @@ func krboDkgGasEstimate(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboDkgSubmitterReimbursementFee(c *cli.Context) error {
@@ -446,9 +446,7 @@ func krboDkgSubmitterReimbursementFee(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboEntryVerificationFee(c *cli.Context) error {
@@ -466,9 +464,7 @@ func krboEntryVerificationFee(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboEntryVerificationGasEstimate(c *cli.Context) error {
@@ -486,9 +482,7 @@ func krboEntryVerificationGasEstimate(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGasPriceCeiling(c *cli.Context) error {
@@ -506,9 +500,7 @@ func krboGasPriceCeiling(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGetFirstActiveGroupIndex(c *cli.Context) error {
@@ -526,9 +518,7 @@ func krboGetFirstActiveGroupIndex(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGetGroupMemberRewards(c *cli.Context) error {
@@ -554,9 +544,7 @@ func krboGetGroupMemberRewards(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGetGroupMembers(c *cli.Context) error {
@@ -582,9 +570,7 @@ func krboGetGroupMembers(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGetGroupPublicKey(c *cli.Context) error {
@@ -610,9 +596,7 @@ func krboGetGroupPublicKey(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGetGroupRegistrationTime(c *cli.Context) error {
@@ -638,9 +622,7 @@ func krboGetGroupRegistrationTime(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGetNumberOfCreatedGroups(c *cli.Context) error {
@@ -658,9 +640,7 @@ func krboGetNumberOfCreatedGroups(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGroupCreationFee(c *cli.Context) error {
@@ -678,9 +658,7 @@ func krboGroupCreationFee(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGroupMemberBaseReward(c *cli.Context) error {
@@ -698,9 +676,7 @@ func krboGroupMemberBaseReward(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGroupProfitFee(c *cli.Context) error {
@@ -718,9 +694,7 @@ func krboGroupProfitFee(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGroupSelectionGasEstimate(c *cli.Context) error {
@@ -738,9 +712,7 @@ func krboGroupSelectionGasEstimate(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGroupSize(c *cli.Context) error {
@@ -758,9 +730,7 @@ func krboGroupSize(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboGroupThreshold(c *cli.Context) error {
@@ -778,9 +748,7 @@ func krboGroupThreshold(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboHasMinimumStake(c *cli.Context) error {
@@ -806,9 +774,7 @@ func krboHasMinimumStake(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboHasWithdrawnRewards(c *cli.Context) error {
@@ -843,9 +809,7 @@ func krboHasWithdrawnRewards(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboIsEntryInProgress(c *cli.Context) error {
@@ -863,9 +827,7 @@ func krboIsEntryInProgress(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboIsGroupRegistered(c *cli.Context) error {
@@ -891,9 +853,7 @@ func krboIsGroupRegistered(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboIsGroupSelectionPossible(c *cli.Context) error {
@@ -911,9 +871,7 @@ func krboIsGroupSelectionPossible(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboIsGroupTerminated(c *cli.Context) error {
@@ -939,9 +897,7 @@ func krboIsGroupTerminated(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboIsStaleGroup(c *cli.Context) error {
@@ -967,9 +923,7 @@ func krboIsStaleGroup(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboNumberOfGroups(c *cli.Context) error {
@@ -987,9 +941,7 @@ func krboNumberOfGroups(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboRelayEntryTimeout(c *cli.Context) error {
@@ -1007,9 +959,7 @@ func krboRelayEntryTimeout(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboResultPublicationBlockStep(c *cli.Context) error {
@@ -1027,9 +977,7 @@ func krboResultPublicationBlockStep(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboSelectedParticipants(c *cli.Context) error {
@@ -1047,9 +995,7 @@ func krboSelectedParticipants(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboSubmittedTickets(c *cli.Context) error {
@@ -1067,9 +1013,7 @@ func krboSubmittedTickets(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krboTicketSubmissionTimeout(c *cli.Context) error {
@@ -1087,9 +1031,7 @@ func krboTicketSubmissionTimeout(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 /// ------------------- Non-const methods -------------------
@@ -1112,7 +1054,8 @@ func krboAddServiceContract(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.AddServiceContract(
 			serviceContract,
@@ -1121,8 +1064,18 @@ func krboAddServiceContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.AddServiceContractGasEstimate(
+			serviceContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallAddServiceContract(
 			serviceContract,
@@ -1132,10 +1085,8 @@ func krboAddServiceContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboCreateGroup(c *cli.Context) error {
@@ -1164,7 +1115,8 @@ func krboCreateGroup(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.CreateGroup(
 			_newEntry,
@@ -1174,8 +1126,19 @@ func krboCreateGroup(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.CreateGroupGasEstimate(
+			_newEntry,
+			submitter,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallCreateGroup(
 			_newEntry,
@@ -1186,10 +1149,8 @@ func krboCreateGroup(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboGenesis(c *cli.Context) error {
@@ -1202,7 +1163,8 @@ func krboGenesis(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Genesis(
 			cmd.ValueFlagValue.Uint)
@@ -1210,8 +1172,16 @@ func krboGenesis(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.GenesisGasEstimate()
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallGenesis(
 			cmd.ValueFlagValue.Uint, cmd.BlockFlagValue.Uint,
@@ -1220,10 +1190,8 @@ func krboGenesis(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboRefreshGasPrice(c *cli.Context) error {
@@ -1236,15 +1204,24 @@ func krboRefreshGasPrice(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RefreshGasPrice()
 		if err != nil {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RefreshGasPriceGasEstimate()
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallRefreshGasPrice(
 			cmd.BlockFlagValue.Uint,
@@ -1253,10 +1230,8 @@ func krboRefreshGasPrice(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboRelayEntry(c *cli.Context) error {
@@ -1277,7 +1252,8 @@ func krboRelayEntry(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RelayEntry(
 			_groupSignature,
@@ -1286,8 +1262,18 @@ func krboRelayEntry(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RelayEntryGasEstimate(
+			_groupSignature,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallRelayEntry(
 			_groupSignature,
@@ -1297,10 +1283,8 @@ func krboRelayEntry(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboReportRelayEntryTimeout(c *cli.Context) error {
@@ -1313,15 +1297,24 @@ func krboReportRelayEntryTimeout(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ReportRelayEntryTimeout()
 		if err != nil {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ReportRelayEntryTimeoutGasEstimate()
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallReportRelayEntryTimeout(
 			cmd.BlockFlagValue.Uint,
@@ -1330,10 +1323,8 @@ func krboReportRelayEntryTimeout(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboReportUnauthorizedSigning(c *cli.Context) error {
@@ -1362,7 +1353,8 @@ func krboReportUnauthorizedSigning(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ReportUnauthorizedSigning(
 			groupIndex,
@@ -1372,8 +1364,19 @@ func krboReportUnauthorizedSigning(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ReportUnauthorizedSigningGasEstimate(
+			groupIndex,
+			signedMsgSender,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallReportUnauthorizedSigning(
 			groupIndex,
@@ -1384,10 +1387,8 @@ func krboReportUnauthorizedSigning(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboSign(c *cli.Context) error {
@@ -1416,7 +1417,8 @@ func krboSign(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Sign(
 			requestId,
@@ -1426,8 +1428,19 @@ func krboSign(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.SignGasEstimate(
+			requestId,
+			previousEntry,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallSign(
 			requestId,
@@ -1438,10 +1451,8 @@ func krboSign(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krboWithdrawGroupMemberRewards(c *cli.Context) error {
@@ -1470,7 +1481,8 @@ func krboWithdrawGroupMemberRewards(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.WithdrawGroupMemberRewards(
 			operator,
@@ -1480,8 +1492,19 @@ func krboWithdrawGroupMemberRewards(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.WithdrawGroupMemberRewardsGasEstimate(
+			operator,
+			groupIndex,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallWithdrawGroupMemberRewards(
 			operator,
@@ -1492,10 +1515,8 @@ func krboWithdrawGroupMemberRewards(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 /// ------------------- Initialization -------------------
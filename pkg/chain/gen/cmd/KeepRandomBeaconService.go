@@ -44,6 +44,14 @@ var keepRandomBeaconServiceDescription = `The keep-random-beacon-service command
 	successfully once the transaction has been submitted, but will not wait for
 	the transaction to be included in a block. They return the transaction hash.
 
+	Subcommands for mutating methods may estimate the gas needed to submit them
+	as a transaction by passing the --estimate-gas flag. By default, or when the
+	--call flag is passed, they are executed as a non-mutating call, which can
+	be used as a dry run of the transaction.
+
+	All subcommands can print their output in JSON format by passing the --json
+	flag.
+
 	Calls that require ether to be paid will get 0 ether by default, which can
 	be changed by passing the -v/--value flag.`
 
@@ -58,133 +66,133 @@ func init() {
 			ArgsUsage: "",
 			Action:    krbsBaseCallbackGas,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "callback-surplus-recipient",
 			Usage:     "Calls the constant method callbackSurplusRecipient on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[requestId] ",
 			Action:    krbsCallbackSurplusRecipient,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "dkg-contribution-margin",
 			Usage:     "Calls the constant method dkgContributionMargin on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsDkgContributionMargin,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "dkg-fee-pool",
 			Usage:     "Calls the constant method dkgFeePool on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsDkgFeePool,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "entry-fee-breakdown",
 			Usage:     "Calls the constant method entryFeeBreakdown on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsEntryFeeBreakdown,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "entry-fee-estimate",
 			Usage:     "Calls the constant method entryFeeEstimate on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[callbackGas] ",
 			Action:    krbsEntryFeeEstimate,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "initialized",
 			Usage:     "Calls the constant method initialized on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsInitialized,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "request-subsidy-fee-pool",
 			Usage:     "Calls the constant method requestSubsidyFeePool on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsRequestSubsidyFeePool,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "select-operator-contract",
 			Usage:     "Calls the constant method selectOperatorContract on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[seed] ",
 			Action:    krbsSelectOperatorContract,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "version",
 			Usage:     "Calls the constant method version on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsVersion,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "add-operator-contract",
 			Usage:     "Calls the method addOperatorContract on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[operatorContract] ",
 			Action:    krbsAddOperatorContract,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "entry-created",
 			Usage:     "Calls the method entryCreated on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[requestId] [entry] [submitter] ",
 			Action:    krbsEntryCreated,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(3))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(3))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "execute-callback",
 			Usage:     "Calls the method executeCallback on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[requestId] [entry] ",
 			Action:    krbsExecuteCallback,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "fund-dkg-fee-pool",
 			Usage:     "Calls the payable method fundDkgFeePool on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsFundDkgFeePool,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
+			Flags:     PayableFlags,
 		}, {
 			Name:      "fund-request-subsidy-fee-pool",
 			Usage:     "Calls the payable method fundRequestSubsidyFeePool on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsFundRequestSubsidyFeePool,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
+			Flags:     PayableFlags,
 		}, {
 			Name:      "initialize",
 			Usage:     "Calls the method initialize on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[dkgContributionMargin] [registry] ",
 			Action:    krbsInitialize,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "remove-operator-contract",
 			Usage:     "Calls the method removeOperatorContract on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[operatorContract] ",
 			Action:    krbsRemoveOperatorContract,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "request-relay-entry",
 			Usage:     "Calls the payable method requestRelayEntry on the KeepRandomBeaconService contract.",
 			ArgsUsage: "",
 			Action:    krbsRequestRelayEntry,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(0))),
+			Flags:     PayableFlags,
 		}, {
 			Name:      "request-relay-entry0",
 			Usage:     "Calls the payable method requestRelayEntry0 on the KeepRandomBeaconService contract.",
 			ArgsUsage: "[callbackContract] [callbackGas] ",
 			Action:    krbsRequestRelayEntry0,
-			Before:    cli.BeforeFunc(cmd.PayableArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.PayableFlags,
+			Before:    cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     PayableFlags,
 		}},
 	})
 }
@@ -206,9 +214,7 @@ func krbsBaseCallbackGas(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsCallbackSurplusRecipient(c *cli.Context) error {
@@ -234,9 +240,7 @@ func krbsCallbackSurplusRecipient(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsDkgContributionMargin(c *cli.Context) error {
@@ -254,9 +258,7 @@ func krbsDkgContributionMargin(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsDkgFeePool(c *cli.Context) error {
@@ -274,9 +276,7 @@ func krbsDkgFeePool(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsEntryFeeBreakdown(c *cli.Context) error {
@@ -294,9 +294,7 @@ func krbsEntryFeeBreakdown(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsEntryFeeEstimate(c *cli.Context) error {
@@ -322,9 +320,7 @@ func krbsEntryFeeEstimate(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsInitialized(c *cli.Context) error {
@@ -342,9 +338,7 @@ func krbsInitialized(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsRequestSubsidyFeePool(c *cli.Context) error {
@@ -362,9 +356,7 @@ func krbsRequestSubsidyFeePool(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsSelectOperatorContract(c *cli.Context) error {
@@ -390,9 +382,7 @@ func krbsSelectOperatorContract(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func krbsVersion(c *cli.Context) error {
@@ -410,9 +400,7 @@ func krbsVersion(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 /// ------------------- Non-const methods -------------------
@@ -435,7 +423,8 @@ func krbsAddOperatorContract(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.AddOperatorContract(
 			operatorContract,
@@ -444,8 +433,18 @@ func krbsAddOperatorContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.AddOperatorContractGasEstimate(
+			operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallAddOperatorContract(
 			operatorContract,
@@ -455,10 +454,8 @@ func krbsAddOperatorContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsEntryCreated(c *cli.Context) error {
@@ -495,7 +492,8 @@ func krbsEntryCreated(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.EntryCreated(
 			requestId,
@@ -506,8 +504,20 @@ func krbsEntryCreated(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.EntryCreatedGasEstimate(
+			requestId,
+			entry,
+			submitter,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallEntryCreated(
 			requestId,
@@ -519,10 +529,8 @@ func krbsEntryCreated(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsExecuteCallback(c *cli.Context) error {
@@ -551,7 +559,8 @@ func krbsExecuteCallback(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ExecuteCallback(
 			requestId,
@@ -561,8 +570,19 @@ func krbsExecuteCallback(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ExecuteCallbackGasEstimate(
+			requestId,
+			entry,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallExecuteCallback(
 			requestId,
@@ -573,10 +593,8 @@ func krbsExecuteCallback(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsFundDkgFeePool(c *cli.Context) error {
@@ -589,7 +607,8 @@ func krbsFundDkgFeePool(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.FundDkgFeePool(
 			cmd.ValueFlagValue.Uint)
@@ -597,8 +616,16 @@ func krbsFundDkgFeePool(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.FundDkgFeePoolGasEstimate()
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallFundDkgFeePool(
 			cmd.ValueFlagValue.Uint, cmd.BlockFlagValue.Uint,
@@ -607,10 +634,8 @@ func krbsFundDkgFeePool(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsFundRequestSubsidyFeePool(c *cli.Context) error {
@@ -623,7 +648,8 @@ func krbsFundRequestSubsidyFeePool(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.FundRequestSubsidyFeePool(
 			cmd.ValueFlagValue.Uint)
@@ -631,8 +657,16 @@ func krbsFundRequestSubsidyFeePool(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.FundRequestSubsidyFeePoolGasEstimate()
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallFundRequestSubsidyFeePool(
 			cmd.ValueFlagValue.Uint, cmd.BlockFlagValue.Uint,
@@ -641,10 +675,8 @@ func krbsFundRequestSubsidyFeePool(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsInitialize(c *cli.Context) error {
@@ -673,7 +705,8 @@ func krbsInitialize(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Initialize(
 			dkgContributionMargin,
@@ -683,8 +716,19 @@ func krbsInitialize(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.InitializeGasEstimate(
+			dkgContributionMargin,
+			registry,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallInitialize(
 			dkgContributionMargin,
@@ -695,10 +739,8 @@ func krbsInitialize(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsRemoveOperatorContract(c *cli.Context) error {
@@ -719,7 +761,8 @@ func krbsRemoveOperatorContract(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RemoveOperatorContract(
 			operatorContract,
@@ -728,8 +771,18 @@ func krbsRemoveOperatorContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RemoveOperatorContractGasEstimate(
+			operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallRemoveOperatorContract(
 			operatorContract,
@@ -739,10 +792,8 @@ func krbsRemoveOperatorContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func krbsRequestRelayEntry(c *cli.Context) error {
@@ -756,7 +807,8 @@ func krbsRequestRelayEntry(c *cli.Context) error {
 		result      *big.Int
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RequestRelayEntry(
 			cmd.ValueFlagValue.Uint)
@@ -764,8 +816,16 @@ func krbsRequestRelayEntry(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RequestRelayEntryGasEstimate()
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		result, err = contract.CallRequestRelayEntry(
 			cmd.ValueFlagValue.Uint, cmd.BlockFlagValue.Uint,
@@ -774,10 +834,8 @@ func krbsRequestRelayEntry(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(result)
+		return printResult(c, result)
 	}
-
-	return nil
 }
 
 func krbsRequestRelayEntry0(c *cli.Context) error {
@@ -807,7 +865,8 @@ func krbsRequestRelayEntry0(c *cli.Context) error {
 		result      *big.Int
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RequestRelayEntry0(
 			callbackContract,
@@ -817,8 +876,19 @@ func krbsRequestRelayEntry0(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RequestRelayEntry0GasEstimate(
+			callbackContract,
+			callbackGas,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		result, err = contract.CallRequestRelayEntry0(
 			callbackContract,
@@ -829,10 +899,8 @@ func krbsRequestRelayEntry0(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(result)
+		return printResult(c, result)
 	}
-
-	return nil
 }
 
 /// ------------------- Initialization -------------------
@@ -43,6 +43,14 @@ var tokenGrantDescription = `The token-grant command allows calling the TokenGra
 	successfully once the transaction has been submitted, but will not wait for
 	the transaction to be included in a block. They return the transaction hash.
 
+	Subcommands for mutating methods may estimate the gas needed to submit them
+	as a transaction by passing the --estimate-gas flag. By default, or when the
+	--call flag is passed, they are executed as a non-mutating call, which can
+	be used as a dry run of the transaction.
+
+	All subcommands can print their output in JSON format by passing the --json
+	flag.
+
 	Calls that require ether to be paid will get 0 ether by default, which can
 	be changed by passing the -v/--value flag.`
 
@@ -57,196 +65,196 @@ func init() {
 			ArgsUsage: "[_grantId] ",
 			Action:    tgAvailableToStake,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "balance-of",
 			Usage:     "Calls the constant method balanceOf on the TokenGrant contract.",
 			ArgsUsage: "[_owner] ",
 			Action:    tgBalanceOf,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "balances",
 			Usage:     "Calls the constant method balances on the TokenGrant contract.",
 			ArgsUsage: "[arg0] ",
 			Action:    tgBalances,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-grant",
 			Usage:     "Calls the constant method getGrant on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgGetGrant,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-grant-stake-details",
 			Usage:     "Calls the constant method getGrantStakeDetails on the TokenGrant contract.",
 			ArgsUsage: "[operator] ",
 			Action:    tgGetGrantStakeDetails,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-grant-unlocking-schedule",
 			Usage:     "Calls the constant method getGrantUnlockingSchedule on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgGetGrantUnlockingSchedule,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-grantee-operators",
 			Usage:     "Calls the constant method getGranteeOperators on the TokenGrant contract.",
 			ArgsUsage: "[grantee] ",
 			Action:    tgGetGranteeOperators,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-grants",
 			Usage:     "Calls the constant method getGrants on the TokenGrant contract.",
 			ArgsUsage: "[_granteeOrGrantManager] ",
 			Action:    tgGetGrants,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "grant-indices",
 			Usage:     "Calls the constant method grantIndices on the TokenGrant contract.",
 			ArgsUsage: "[arg0] [arg1] ",
 			Action:    tgGrantIndices,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "grant-stakes",
 			Usage:     "Calls the constant method grantStakes on the TokenGrant contract.",
 			ArgsUsage: "[arg0] ",
 			Action:    tgGrantStakes,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "grantees-to-operators",
 			Usage:     "Calls the constant method granteesToOperators on the TokenGrant contract.",
 			ArgsUsage: "[arg0] [arg1] ",
 			Action:    tgGranteesToOperators,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "grants",
 			Usage:     "Calls the constant method grants on the TokenGrant contract.",
 			ArgsUsage: "[arg0] ",
 			Action:    tgGrants,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "num-grants",
 			Usage:     "Calls the constant method numGrants on the TokenGrant contract.",
 			ArgsUsage: "",
 			Action:    tgNumGrants,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "stake-balance-of",
 			Usage:     "Calls the constant method stakeBalanceOf on the TokenGrant contract.",
 			ArgsUsage: "[_address] ",
 			Action:    tgStakeBalanceOf,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "token",
 			Usage:     "Calls the constant method token on the TokenGrant contract.",
 			ArgsUsage: "",
 			Action:    tgToken,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "unlocked-amount",
 			Usage:     "Calls the constant method unlockedAmount on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgUnlockedAmount,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "withdrawable",
 			Usage:     "Calls the constant method withdrawable on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgWithdrawable,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "authorize-staking-contract",
 			Usage:     "Calls the method authorizeStakingContract on the TokenGrant contract.",
 			ArgsUsage: "[_stakingContract] ",
 			Action:    tgAuthorizeStakingContract,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "cancel-revoked-stake",
 			Usage:     "Calls the method cancelRevokedStake on the TokenGrant contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tgCancelRevokedStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "cancel-stake",
 			Usage:     "Calls the method cancelStake on the TokenGrant contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tgCancelStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "receive-approval",
 			Usage:     "Calls the method receiveApproval on the TokenGrant contract.",
 			ArgsUsage: "[_from] [_amount] [_token] [_extraData] ",
 			Action:    tgReceiveApproval,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(4))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(4))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "recover-stake",
 			Usage:     "Calls the method recoverStake on the TokenGrant contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tgRecoverStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "revoke",
 			Usage:     "Calls the method revoke on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgRevoke,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "stake",
 			Usage:     "Calls the method stake on the TokenGrant contract.",
 			ArgsUsage: "[_id] [_stakingContract] [_amount] [_extraData] ",
 			Action:    tgStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(4))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(4))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "undelegate",
 			Usage:     "Calls the method undelegate on the TokenGrant contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tgUndelegate,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "undelegate-revoked",
 			Usage:     "Calls the method undelegateRevoked on the TokenGrant contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tgUndelegateRevoked,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "withdraw",
 			Usage:     "Calls the method withdraw on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgWithdraw,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "withdraw-revoked",
 			Usage:     "Calls the method withdrawRevoked on the TokenGrant contract.",
 			ArgsUsage: "[_id] ",
 			Action:    tgWithdrawRevoked,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}},
 	})
 }
@@ -276,9 +284,7 @@ func tgAvailableToStake(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgBalanceOf(c *cli.Context) error {
@@ -304,9 +310,7 @@ func tgBalanceOf(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgBalances(c *cli.Context) error {
@@ -332,9 +336,7 @@ func tgBalances(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGetGrant(c *cli.Context) error {
@@ -360,9 +362,7 @@ func tgGetGrant(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGetGrantStakeDetails(c *cli.Context) error {
@@ -388,9 +388,7 @@ func tgGetGrantStakeDetails(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGetGrantUnlockingSchedule(c *cli.Context) error {
@@ -416,9 +414,7 @@ func tgGetGrantUnlockingSchedule(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGetGranteeOperators(c *cli.Context) error {
@@ -444,9 +440,7 @@ func tgGetGranteeOperators(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGetGrants(c *cli.Context) error {
@@ -472,9 +466,7 @@ func tgGetGrants(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGrantIndices(c *cli.Context) error {
@@ -509,9 +501,7 @@ func tgGrantIndices(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGrantStakes(c *cli.Context) error {
@@ -537,9 +527,7 @@ func tgGrantStakes(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGranteesToOperators(c *cli.Context) error {
@@ -574,9 +562,7 @@ func tgGranteesToOperators(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgGrants(c *cli.Context) error {
@@ -602,9 +588,7 @@ func tgGrants(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgNumGrants(c *cli.Context) error {
@@ -622,9 +606,7 @@ func tgNumGrants(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgStakeBalanceOf(c *cli.Context) error {
@@ -650,9 +632,7 @@ func tgStakeBalanceOf(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgToken(c *cli.Context) error {
@@ -670,9 +650,7 @@ func tgToken(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgUnlockedAmount(c *cli.Context) error {
@@ -698,9 +676,7 @@ func tgUnlockedAmount(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tgWithdrawable(c *cli.Context) error {
@@ -726,9 +702,7 @@ func tgWithdrawable(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 /// ------------------- Non-const methods -------------------
@@ -751,7 +725,8 @@ func tgAuthorizeStakingContract(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.AuthorizeStakingContract(
 			_stakingContract,
@@ -760,8 +735,18 @@ func tgAuthorizeStakingContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.AuthorizeStakingContractGasEstimate(
+			_stakingContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallAuthorizeStakingContract(
 			_stakingContract,
@@ -771,10 +756,8 @@ func tgAuthorizeStakingContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgCancelRevokedStake(c *cli.Context) error {
@@ -795,7 +778,8 @@ func tgCancelRevokedStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.CancelRevokedStake(
 			_operator,
@@ -804,8 +788,18 @@ func tgCancelRevokedStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.CancelRevokedStakeGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallCancelRevokedStake(
 			_operator,
@@ -815,10 +809,8 @@ func tgCancelRevokedStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgCancelStake(c *cli.Context) error {
@@ -839,7 +831,8 @@ func tgCancelStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.CancelStake(
 			_operator,
@@ -848,8 +841,18 @@ func tgCancelStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.CancelStakeGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallCancelStake(
 			_operator,
@@ -859,10 +862,8 @@ func tgCancelStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgReceiveApproval(c *cli.Context) error {
@@ -907,7 +908,8 @@ func tgReceiveApproval(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ReceiveApproval(
 			_from,
@@ -919,8 +921,21 @@ func tgReceiveApproval(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ReceiveApprovalGasEstimate(
+			_from,
+			_amount,
+			_token,
+			_extraData,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallReceiveApproval(
 			_from,
@@ -933,10 +948,8 @@ func tgReceiveApproval(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgRecoverStake(c *cli.Context) error {
@@ -957,7 +970,8 @@ func tgRecoverStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RecoverStake(
 			_operator,
@@ -966,8 +980,18 @@ func tgRecoverStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RecoverStakeGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallRecoverStake(
 			_operator,
@@ -977,10 +1001,8 @@ func tgRecoverStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgRevoke(c *cli.Context) error {
@@ -1001,7 +1023,8 @@ func tgRevoke(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Revoke(
 			_id,
@@ -1010,8 +1033,18 @@ func tgRevoke(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RevokeGasEstimate(
+			_id,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallRevoke(
 			_id,
@@ -1021,10 +1054,8 @@ func tgRevoke(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgStake(c *cli.Context) error {
@@ -1069,7 +1100,8 @@ func tgStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Stake(
 			_id,
@@ -1081,8 +1113,21 @@ func tgStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.StakeGasEstimate(
+			_id,
+			_stakingContract,
+			_amount,
+			_extraData,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallStake(
 			_id,
@@ -1095,10 +1140,8 @@ func tgStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgUndelegate(c *cli.Context) error {
@@ -1119,7 +1162,8 @@ func tgUndelegate(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Undelegate(
 			_operator,
@@ -1128,8 +1172,18 @@ func tgUndelegate(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.UndelegateGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallUndelegate(
 			_operator,
@@ -1139,10 +1193,8 @@ func tgUndelegate(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgUndelegateRevoked(c *cli.Context) error {
@@ -1163,7 +1215,8 @@ func tgUndelegateRevoked(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.UndelegateRevoked(
 			_operator,
@@ -1172,8 +1225,18 @@ func tgUndelegateRevoked(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.UndelegateRevokedGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallUndelegateRevoked(
 			_operator,
@@ -1183,10 +1246,8 @@ func tgUndelegateRevoked(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgWithdraw(c *cli.Context) error {
@@ -1207,7 +1268,8 @@ func tgWithdraw(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Withdraw(
 			_id,
@@ -1216,8 +1278,18 @@ func tgWithdraw(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.WithdrawGasEstimate(
+			_id,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallWithdraw(
 			_id,
@@ -1227,10 +1299,8 @@ func tgWithdraw(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tgWithdrawRevoked(c *cli.Context) error {
@@ -1251,7 +1321,8 @@ func tgWithdrawRevoked(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.WithdrawRevoked(
 			_id,
@@ -1260,8 +1331,18 @@ func tgWithdrawRevoked(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.WithdrawRevokedGasEstimate(
+			_id,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallWithdrawRevoked(
 			_id,
@@ -1271,10 +1352,8 @@ func tgWithdrawRevoked(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 /// ------------------- Initialization -------------------
@@ -43,6 +43,14 @@ var tokenStakingDescription = `The token-staking command allows calling the Toke
 	successfully once the transaction has been submitted, but will not wait for
 	the transaction to be included in a block. They return the transaction hash.
 
+	Subcommands for mutating methods may estimate the gas needed to submit them
+	as a transaction by passing the --estimate-gas flag. By default, or when the
+	--call flag is passed, they are executed as a non-mutating call, which can
+	be used as a dry run of the transaction.
+
+	All subcommands can print their output in JSON format by passing the --json
+	flag.
+
 	Calls that require ether to be paid will get 0 ether by default, which can
 	be changed by passing the -v/--value flag.`
 
@@ -57,203 +65,203 @@ func init() {
 			ArgsUsage: "[_operator] [_operatorContract] ",
 			Action:    tsActiveStake,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "authorizer-of",
 			Usage:     "Calls the constant method authorizerOf on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsAuthorizerOf,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "balance-of",
 			Usage:     "Calls the constant method balanceOf on the TokenStaking contract.",
 			ArgsUsage: "[_address] ",
 			Action:    tsBalanceOf,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "beneficiary-of",
 			Usage:     "Calls the constant method beneficiaryOf on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsBeneficiaryOf,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "deployed-at",
 			Usage:     "Calls the constant method deployedAt on the TokenStaking contract.",
 			ArgsUsage: "",
 			Action:    tsDeployedAt,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "eligible-stake",
 			Usage:     "Calls the constant method eligibleStake on the TokenStaking contract.",
 			ArgsUsage: "[_operator] [_operatorContract] ",
 			Action:    tsEligibleStake,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-authority-source",
 			Usage:     "Calls the constant method getAuthoritySource on the TokenStaking contract.",
 			ArgsUsage: "[operatorContract] ",
 			Action:    tsGetAuthoritySource,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-delegation-info",
 			Usage:     "Calls the constant method getDelegationInfo on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsGetDelegationInfo,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "get-locks",
 			Usage:     "Calls the constant method getLocks on the TokenStaking contract.",
 			ArgsUsage: "[operator] ",
 			Action:    tsGetLocks,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "has-minimum-stake",
 			Usage:     "Calls the constant method hasMinimumStake on the TokenStaking contract.",
 			ArgsUsage: "[staker] [operatorContract] ",
 			Action:    tsHasMinimumStake,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "initialization-period",
 			Usage:     "Calls the constant method initializationPeriod on the TokenStaking contract.",
 			ArgsUsage: "",
 			Action:    tsInitializationPeriod,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-approved-operator-contract",
 			Usage:     "Calls the constant method isApprovedOperatorContract on the TokenStaking contract.",
 			ArgsUsage: "[_operatorContract] ",
 			Action:    tsIsApprovedOperatorContract,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-authorized-for-operator",
 			Usage:     "Calls the constant method isAuthorizedForOperator on the TokenStaking contract.",
 			ArgsUsage: "[_operator] [_operatorContract] ",
 			Action:    tsIsAuthorizedForOperator,
 			Before:    cmd.ArgCountChecker(2),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "is-stake-locked",
 			Usage:     "Calls the constant method isStakeLocked on the TokenStaking contract.",
 			ArgsUsage: "[operator] ",
 			Action:    tsIsStakeLocked,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "minimum-stake",
 			Usage:     "Calls the constant method minimumStake on the TokenStaking contract.",
 			ArgsUsage: "",
 			Action:    tsMinimumStake,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "owner-of",
 			Usage:     "Calls the constant method ownerOf on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsOwnerOf,
 			Before:    cmd.ArgCountChecker(1),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "undelegation-period",
 			Usage:     "Calls the constant method undelegationPeriod on the TokenStaking contract.",
 			ArgsUsage: "",
 			Action:    tsUndelegationPeriod,
 			Before:    cmd.ArgCountChecker(0),
-			Flags:     cmd.ConstFlags,
+			Flags:     ConstFlags,
 		}, {
 			Name:      "authorize-operator-contract",
 			Usage:     "Calls the method authorizeOperatorContract on the TokenStaking contract.",
 			ArgsUsage: "[_operator] [_operatorContract] ",
 			Action:    tsAuthorizeOperatorContract,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "cancel-stake",
 			Usage:     "Calls the method cancelStake on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsCancelStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "claim-delegated-authority",
 			Usage:     "Calls the method claimDelegatedAuthority on the TokenStaking contract.",
 			ArgsUsage: "[delegatedAuthoritySource] ",
 			Action:    tsClaimDelegatedAuthority,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "commit-top-up",
 			Usage:     "Calls the method commitTopUp on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsCommitTopUp,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "lock-stake",
 			Usage:     "Calls the method lockStake on the TokenStaking contract.",
 			ArgsUsage: "[operator] [duration] ",
 			Action:    tsLockStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "receive-approval",
 			Usage:     "Calls the method receiveApproval on the TokenStaking contract.",
 			ArgsUsage: "[_from] [_value] [_token] [_extraData] ",
 			Action:    tsReceiveApproval,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(4))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(4))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "recover-stake",
 			Usage:     "Calls the method recoverStake on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsRecoverStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "release-expired-lock",
 			Usage:     "Calls the method releaseExpiredLock on the TokenStaking contract.",
 			ArgsUsage: "[operator] [operatorContract] ",
 			Action:    tsReleaseExpiredLock,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "transfer-stake-ownership",
 			Usage:     "Calls the method transferStakeOwnership on the TokenStaking contract.",
 			ArgsUsage: "[operator] [newOwner] ",
 			Action:    tsTransferStakeOwnership,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "undelegate",
 			Usage:     "Calls the method undelegate on the TokenStaking contract.",
 			ArgsUsage: "[_operator] ",
 			Action:    tsUndelegate,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "undelegate-at",
 			Usage:     "Calls the method undelegateAt on the TokenStaking contract.",
 			ArgsUsage: "[_operator] [_undelegationTimestamp] ",
 			Action:    tsUndelegateAt,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(2))),
+			Flags:     NonConstFlags,
 		}, {
 			Name:      "unlock-stake",
 			Usage:     "Calls the method unlockStake on the TokenStaking contract.",
 			ArgsUsage: "[operator] ",
 			Action:    tsUnlockStake,
-			Before:    cli.BeforeFunc(cmd.NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
-			Flags:     cmd.NonConstFlags,
+			Before:    cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker(1))),
+			Flags:     NonConstFlags,
 		}},
 	})
 }
@@ -292,9 +300,7 @@ func tsActiveStake(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsAuthorizerOf(c *cli.Context) error {
@@ -320,9 +326,7 @@ func tsAuthorizerOf(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsBalanceOf(c *cli.Context) error {
@@ -348,9 +352,7 @@ func tsBalanceOf(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsBeneficiaryOf(c *cli.Context) error {
@@ -376,9 +378,7 @@ func tsBeneficiaryOf(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsDeployedAt(c *cli.Context) error {
@@ -396,9 +396,7 @@ func tsDeployedAt(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsEligibleStake(c *cli.Context) error {
@@ -433,9 +431,7 @@ func tsEligibleStake(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsGetAuthoritySource(c *cli.Context) error {
@@ -461,9 +457,7 @@ func tsGetAuthoritySource(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsGetDelegationInfo(c *cli.Context) error {
@@ -489,9 +483,7 @@ func tsGetDelegationInfo(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsGetLocks(c *cli.Context) error {
@@ -517,9 +509,7 @@ func tsGetLocks(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsHasMinimumStake(c *cli.Context) error {
@@ -554,9 +544,7 @@ func tsHasMinimumStake(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsInitializationPeriod(c *cli.Context) error {
@@ -574,9 +562,7 @@ func tsInitializationPeriod(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsIsApprovedOperatorContract(c *cli.Context) error {
@@ -602,9 +588,7 @@ func tsIsApprovedOperatorContract(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsIsAuthorizedForOperator(c *cli.Context) error {
@@ -639,9 +623,7 @@ func tsIsAuthorizedForOperator(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsIsStakeLocked(c *cli.Context) error {
@@ -667,9 +649,7 @@ func tsIsStakeLocked(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsMinimumStake(c *cli.Context) error {
@@ -687,9 +667,7 @@ func tsMinimumStake(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsOwnerOf(c *cli.Context) error {
@@ -715,9 +693,7 @@ func tsOwnerOf(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 func tsUndelegationPeriod(c *cli.Context) error {
@@ -735,9 +711,7 @@ func tsUndelegationPeriod(c *cli.Context) error {
 		return err
 	}
 
-	cmd.PrintOutput(result)
-
-	return nil
+	return printResult(c, result)
 }
 
 /// ------------------- Non-const methods -------------------
@@ -768,7 +742,8 @@ func tsAuthorizeOperatorContract(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.AuthorizeOperatorContract(
 			_operator,
@@ -778,8 +753,19 @@ func tsAuthorizeOperatorContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.AuthorizeOperatorContractGasEstimate(
+			_operator,
+			_operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallAuthorizeOperatorContract(
 			_operator,
@@ -790,10 +776,8 @@ func tsAuthorizeOperatorContract(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsCancelStake(c *cli.Context) error {
@@ -814,7 +798,8 @@ func tsCancelStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.CancelStake(
 			_operator,
@@ -823,8 +808,18 @@ func tsCancelStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.CancelStakeGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallCancelStake(
 			_operator,
@@ -834,10 +829,8 @@ func tsCancelStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsClaimDelegatedAuthority(c *cli.Context) error {
@@ -858,7 +851,8 @@ func tsClaimDelegatedAuthority(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ClaimDelegatedAuthority(
 			delegatedAuthoritySource,
@@ -867,8 +861,18 @@ func tsClaimDelegatedAuthority(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ClaimDelegatedAuthorityGasEstimate(
+			delegatedAuthoritySource,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallClaimDelegatedAuthority(
 			delegatedAuthoritySource,
@@ -878,10 +882,8 @@ func tsClaimDelegatedAuthority(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsCommitTopUp(c *cli.Context) error {
@@ -902,7 +904,8 @@ func tsCommitTopUp(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.CommitTopUp(
 			_operator,
@@ -911,8 +914,18 @@ func tsCommitTopUp(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.CommitTopUpGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallCommitTopUp(
 			_operator,
@@ -922,10 +935,8 @@ func tsCommitTopUp(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsLockStake(c *cli.Context) error {
@@ -954,7 +965,8 @@ func tsLockStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.LockStake(
 			operator,
@@ -964,8 +976,19 @@ func tsLockStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.LockStakeGasEstimate(
+			operator,
+			duration,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallLockStake(
 			operator,
@@ -976,10 +999,8 @@ func tsLockStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsReceiveApproval(c *cli.Context) error {
@@ -1024,7 +1045,8 @@ func tsReceiveApproval(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ReceiveApproval(
 			_from,
@@ -1036,8 +1058,21 @@ func tsReceiveApproval(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ReceiveApprovalGasEstimate(
+			_from,
+			_value,
+			_token,
+			_extraData,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallReceiveApproval(
 			_from,
@@ -1050,10 +1085,8 @@ func tsReceiveApproval(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsRecoverStake(c *cli.Context) error {
@@ -1074,7 +1107,8 @@ func tsRecoverStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.RecoverStake(
 			_operator,
@@ -1083,8 +1117,18 @@ func tsRecoverStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.RecoverStakeGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallRecoverStake(
 			_operator,
@@ -1094,10 +1138,8 @@ func tsRecoverStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsReleaseExpiredLock(c *cli.Context) error {
@@ -1126,7 +1168,8 @@ func tsReleaseExpiredLock(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.ReleaseExpiredLock(
 			operator,
@@ -1136,8 +1179,19 @@ func tsReleaseExpiredLock(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.ReleaseExpiredLockGasEstimate(
+			operator,
+			operatorContract,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallReleaseExpiredLock(
 			operator,
@@ -1148,10 +1202,8 @@ func tsReleaseExpiredLock(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsTransferStakeOwnership(c *cli.Context) error {
@@ -1180,7 +1232,8 @@ func tsTransferStakeOwnership(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.TransferStakeOwnership(
 			operator,
@@ -1190,8 +1243,19 @@ func tsTransferStakeOwnership(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.TransferStakeOwnershipGasEstimate(
+			operator,
+			newOwner,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallTransferStakeOwnership(
 			operator,
@@ -1202,10 +1266,8 @@ func tsTransferStakeOwnership(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsUndelegate(c *cli.Context) error {
@@ -1226,7 +1288,8 @@ func tsUndelegate(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.Undelegate(
 			_operator,
@@ -1235,8 +1298,18 @@ func tsUndelegate(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.UndelegateGasEstimate(
+			_operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallUndelegate(
 			_operator,
@@ -1246,10 +1319,8 @@ func tsUndelegate(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsUndelegateAt(c *cli.Context) error {
@@ -1278,7 +1349,8 @@ func tsUndelegateAt(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.UndelegateAt(
 			_operator,
@@ -1288,8 +1360,19 @@ func tsUndelegateAt(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.UndelegateAtGasEstimate(
+			_operator,
+			_undelegationTimestamp,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallUndelegateAt(
 			_operator,
@@ -1300,10 +1383,8 @@ func tsUndelegateAt(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 func tsUnlockStake(c *cli.Context) error {
@@ -1324,7 +1405,8 @@ func tsUnlockStake(c *cli.Context) error {
 		transaction *types.Transaction
 	)
 
-	if c.Bool(cmd.SubmitFlag) {
+	switch {
+	case c.Bool(cmd.SubmitFlag):
 		// Do a regular submission. Take payable into account.
 		transaction, err = contract.UnlockStake(
 			operator,
@@ -1333,8 +1415,18 @@ func tsUnlockStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(transaction.Hash)
-	} else {
+		return printTransaction(c, transaction.Hash())
+	case c.Bool(estimateGasFlag):
+		// Estimate gas needed to submit the transaction.
+		gasEstimate, err := contract.UnlockStakeGasEstimate(
+			operator,
+		)
+		if err != nil {
+			return err
+		}
+
+		return printGasEstimate(c, gasEstimate)
+	default:
 		// Do a call.
 		err = contract.CallUnlockStake(
 			operator,
@@ -1344,10 +1436,8 @@ func tsUnlockStake(c *cli.Context) error {
 			return err
 		}
 
-		cmd.PrintOutput(nil)
+		return printResult(c, nil)
 	}
-
-	return nil
 }
 
 /// ------------------- Initialization -------------------
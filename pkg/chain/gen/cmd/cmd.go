@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/keep-network/keep-common/pkg/cmd"
 	"github.com/urfave/cli"
 )
 
@@ -11,3 +18,167 @@ import (
 // reference this variable and expect it to contain all generated contract
 // commands.
 var AvailableCommands []cli.Command
+
+const (
+	// estimateGasFlag allows for urfave/cli definition and lookup of a boolean
+	// `--estimate-gas` command-line flag indicating that the gas needed to
+	// execute a given mutating contract interaction should be estimated
+	// instead of submitting the transaction.
+	estimateGasFlag string = "estimate-gas"
+	// callFlag allows for urfave/cli definition and lookup of a boolean
+	// `--call` command-line flag indicating that a given mutating contract
+	// interaction should be executed as a non-mutating call, without
+	// submitting the transaction. This is the default mode of mutating
+	// contract interactions.
+	callFlag string = "call"
+	// jsonFlag allows for urfave/cli definition and lookup of a boolean
+	// `--json` command-line flag indicating that the result of a contract
+	// interaction should be printed in JSON format.
+	jsonFlag string = "json"
+)
+
+var (
+	jsonFlagDefinition = &cli.BoolFlag{
+		Name:  jsonFlag,
+		Usage: "Print the output in JSON format.",
+	}
+	modeFlagDefinitions = []cli.Flag{
+		&cli.BoolFlag{
+			Name:  estimateGasFlag,
+			Usage: "Estimate gas needed to submit this call as a transaction.",
+		},
+		&cli.BoolFlag{
+			Name:  callFlag,
+			Usage: "Execute this call without submitting a transaction; default.",
+		},
+	}
+)
+
+var (
+	// ConstFlags extends the flags for constant contract interactions with
+	// the --json flag.
+	ConstFlags = withFlags(cmd.ConstFlags, jsonFlagDefinition)
+	// NonConstFlags extends the flags for non-constant contract interactions
+	// with the --estimate-gas, --call and --json flags.
+	NonConstFlags = withFlags(
+		cmd.NonConstFlags,
+		append(modeFlagDefinitions, jsonFlagDefinition)...,
+	)
+	// PayableFlags extends the flags for payable contract interactions with
+	// the --estimate-gas, --call and --json flags.
+	PayableFlags = withFlags(
+		cmd.PayableFlags,
+		append(modeFlagDefinitions, jsonFlagDefinition)...,
+	)
+)
+
+// withFlags returns a new slice with the given flags appended to the base
+// flags, leaving the base slice intact.
+func withFlags(base []cli.Flag, flags ...cli.Flag) []cli.Flag {
+	result := make([]cli.Flag, 0, len(base)+len(flags))
+	result = append(result, base...)
+	return append(result, flags...)
+}
+
+var modeArgChecker cmd.ComposableArgChecker = func(c *cli.Context) error {
+	modes := 0
+	for _, flag := range []string{cmd.SubmitFlag, estimateGasFlag, callFlag} {
+		if c.Bool(flag) {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return fmt.Errorf(
+			"only one of --%v, --%v and --%v can be specified",
+			cmd.SubmitFlag,
+			estimateGasFlag,
+			callFlag,
+		)
+	}
+
+	if c.Bool(estimateGasFlag) && c.IsSet("block") {
+		return fmt.Errorf("cannot specify --block for a gas estimate")
+	}
+
+	return nil
+}
+
+var (
+	// NonConstArgsChecker runs validation of parameters of a non-constant
+	// contract interaction. In addition to the checks of keep-common's
+	// NonConstArgsChecker, it ensures at most one of --submit, --estimate-gas
+	// and --call is specified.
+	NonConstArgsChecker = cmd.NonConstArgsChecker.AndThen(modeArgChecker)
+	// PayableArgsChecker runs validation of parameters of a payable contract
+	// interaction. In addition to the checks of keep-common's
+	// PayableArgsChecker, it ensures at most one of --submit, --estimate-gas
+	// and --call is specified.
+	PayableArgsChecker = cmd.PayableArgsChecker.AndThen(modeArgChecker)
+)
+
+// printResult prints the result of a constant contract interaction or of
+// a mutating contract interaction executed as a call.
+func printResult(c *cli.Context, result interface{}) error {
+	if !c.Bool(jsonFlag) {
+		cmd.PrintOutput(result)
+		return nil
+	}
+
+	output := struct {
+		Block  *big.Int    `json:"block,omitempty"`
+		Result interface{} `json:"result"`
+	}{
+		Block:  cmd.BlockFlagValue.Uint,
+		Result: jsonValue(result),
+	}
+
+	return printJSON(output)
+}
+
+// printTransaction prints the hash of a submitted transaction.
+func printTransaction(c *cli.Context, transactionHash common.Hash) error {
+	if !c.Bool(jsonFlag) {
+		cmd.PrintOutput(transactionHash)
+		return nil
+	}
+
+	return printJSON(struct {
+		TransactionHash common.Hash `json:"transactionHash"`
+	}{transactionHash})
+}
+
+// printGasEstimate prints the estimated gas of a mutating contract
+// interaction.
+func printGasEstimate(c *cli.Context, gasEstimate uint64) error {
+	if !c.Bool(jsonFlag) {
+		cmd.PrintOutput(gasEstimate)
+		return nil
+	}
+
+	return printJSON(struct {
+		GasEstimate uint64 `json:"gasEstimate"`
+	}{gasEstimate})
+}
+
+// jsonValue converts byte slices and arrays, which would be otherwise encoded
+// in base64 or as arrays of numbers, to hex strings.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Bytes(v)
+	case [32]byte:
+		return hexutil.Bytes(v[:])
+	default:
+		return value
+	}
+}
+
+func printJSON(value interface{}) error {
+	bytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal output: [%v]", err)
+	}
+
+	fmt.Println(string(bytes))
+	return nil
+}
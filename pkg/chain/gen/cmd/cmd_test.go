@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/keep-network/keep-common/pkg/cmd"
+	"github.com/urfave/cli"
+)
+
+func TestModeArgChecker(t *testing.T) {
+	var tests = map[string]struct {
+		args          []string
+		expectedError bool
+	}{
+		"no mode": {
+			args: []string{},
+		},
+		"submit": {
+			args: []string{"--" + cmd.SubmitFlag},
+		},
+		"estimate gas": {
+			args: []string{"--" + estimateGasFlag},
+		},
+		"call at block": {
+			args: []string{"--" + callFlag, "--block", "0x5"},
+		},
+		"submit and estimate gas": {
+			args:          []string{"--" + cmd.SubmitFlag, "--" + estimateGasFlag},
+			expectedError: true,
+		},
+		"estimate gas and call": {
+			args:          []string{"--" + estimateGasFlag, "--" + callFlag},
+			expectedError: true,
+		},
+		"estimate gas at block": {
+			args:          []string{"--" + estimateGasFlag, "--block", "0x5"},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			flagSet := flag.NewFlagSet(testName, flag.ContinueOnError)
+			flagSet.Bool(cmd.SubmitFlag, false, "")
+			flagSet.Bool(estimateGasFlag, false, "")
+			flagSet.Bool(callFlag, false, "")
+			flagSet.String("block", "", "")
+			if err := flagSet.Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := modeArgChecker(cli.NewContext(nil, flagSet, nil))
+			if test.expectedError && err == nil {
+				t.Fatal("expected error")
+			}
+			if !test.expectedError && err != nil {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+		})
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	var tests = map[string]struct {
+		value         interface{}
+		expectedValue interface{}
+	}{
+		"byte slice": {
+			value:         []byte{0x01, 0xff},
+			expectedValue: hexutil.Bytes{0x01, 0xff},
+		},
+		"byte array": {
+			value:         [32]byte{31: 0x01},
+			expectedValue: hexutil.Bytes(append(make([]byte, 31), 0x01)),
+		},
+		"other value": {
+			value:         true,
+			expectedValue: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			value := jsonValue(test.value)
+			if !reflect.DeepEqual(test.expectedValue, value) {
+				t.Errorf(
+					"unexpected value\nexpected: [%v]\nactual:   [%v]",
+					test.expectedValue,
+					value,
+				)
+			}
+		})
+	}
+}
@@ -57,10 +57,6 @@ func NewKeepRandomBeaconService(
 	blockCounter *ethlike.BlockCounter,
 	transactionMutex *sync.Mutex,
 ) (*KeepRandomBeaconService, error) {
-	callerOptions := &bind.CallOpts{
-		From: accountKey.Address,
-	}
-
 	// FIXME Switch to bind.NewKeyedTransactorWithChainID when
 	// FIXME celo-org/celo-blockchain merges in changes from upstream
 	// FIXME ethereum/go-ethereum beyond v1.9.25.
@@ -72,6 +68,33 @@ func NewKeepRandomBeaconService(
 		return nil, fmt.Errorf("failed to instantiate transactor: [%v]", err)
 	}
 
+	return NewKeepRandomBeaconServiceWithTransactor(
+		contractAddress,
+		transactorOptions,
+		backend,
+		nonceManager,
+		miningWaiter,
+		blockCounter,
+		transactionMutex,
+	)
+}
+
+// NewKeepRandomBeaconServiceWithTransactor creates the contract handle submitting
+// transactions from the account and with the signer of the given transactor
+// options, for example a signer delegating to an external signing service.
+func NewKeepRandomBeaconServiceWithTransactor(
+	contractAddress common.Address,
+	transactorOptions *bind.TransactOpts,
+	backend bind.ContractBackend,
+	nonceManager *ethlike.NonceManager,
+	miningWaiter *ethlike.MiningWaiter,
+	blockCounter *ethlike.BlockCounter,
+	transactionMutex *sync.Mutex,
+) (*KeepRandomBeaconService, error) {
+	callerOptions := &bind.CallOpts{
+		From: transactorOptions.From,
+	}
+
 	contract, err := abi.NewKeepRandomBeaconServiceImplV1(
 		contractAddress,
 		backend,
@@ -57,10 +57,6 @@ func NewTokenGrant(
 	blockCounter *ethlike.BlockCounter,
 	transactionMutex *sync.Mutex,
 ) (*TokenGrant, error) {
-	callerOptions := &bind.CallOpts{
-		From: accountKey.Address,
-	}
-
 	// FIXME Switch to bind.NewKeyedTransactorWithChainID when
 	// FIXME celo-org/celo-blockchain merges in changes from upstream
 	// FIXME ethereum/go-ethereum beyond v1.9.25.
@@ -72,6 +68,33 @@ func NewTokenGrant(
 		return nil, fmt.Errorf("failed to instantiate transactor: [%v]", err)
 	}
 
+	return NewTokenGrantWithTransactor(
+		contractAddress,
+		transactorOptions,
+		backend,
+		nonceManager,
+		miningWaiter,
+		blockCounter,
+		transactionMutex,
+	)
+}
+
+// NewTokenGrantWithTransactor creates the contract handle submitting
+// transactions from the account and with the signer of the given transactor
+// options, for example a signer delegating to an external signing service.
+func NewTokenGrantWithTransactor(
+	contractAddress common.Address,
+	transactorOptions *bind.TransactOpts,
+	backend bind.ContractBackend,
+	nonceManager *ethlike.NonceManager,
+	miningWaiter *ethlike.MiningWaiter,
+	blockCounter *ethlike.BlockCounter,
+	transactionMutex *sync.Mutex,
+) (*TokenGrant, error) {
+	callerOptions := &bind.CallOpts{
+		From: transactorOptions.From,
+	}
+
 	contract, err := abi.NewTokenGrant(
 		contractAddress,
 		backend,
@@ -0,0 +1,279 @@
+package main
+
+// commandTemplateContent contains the template string from command.go.tmpl
+var commandTemplateContent = `// Code generated - DO NOT EDIT.
+// This file is a generated command and any manual changes will be lost.
+
+package cmd
+
+import (
+    "sync"
+
+    "{{.HostChainModule}}/common"
+    "{{.HostChainModule}}/common/hexutil"
+    "{{.HostChainModule}}/core/types"
+
+    chainutil "{{.ChainUtilPackage}}"
+    "github.com/keep-network/keep-common/pkg/cmd"
+
+    "github.com/urfave/cli"
+)
+
+var {{.Class}}Command cli.Command
+
+var {{.FullVar}}Description = ` + "`" + `The {{.DashedName}} command allows calling the {{.Class}} contract on an
+	ETH-like network. It has subcommands corresponding to each contract method,
+	which respectively each take parameters based on the contract method's
+	parameters.
+
+	Subcommands will submit a non-mutating call to the network and output the
+	result.
+
+	All subcommands can be called against a specific block by passing the
+	-b/--block flag.
+
+	All subcommands can be used to investigate the result of a previous
+	transaction that called that same method by passing the -t/--transaction
+	flag with the transaction hash.
+
+	Subcommands for mutating methods may be submitted as a mutating transaction
+	by passing the -s/--submit flag. In this mode, this command will terminate
+	successfully once the transaction has been submitted, but will not wait for
+	the transaction to be included in a block. They return the transaction hash.
+
+	Subcommands for mutating methods may estimate the gas needed to submit them
+	as a transaction by passing the --estimate-gas flag. By default, or when the
+	--call flag is passed, they are executed as a non-mutating call, which can
+	be used as a dry run of the transaction.
+
+	All subcommands can print their output in JSON format by passing the --json
+	flag.
+
+	Calls that require ether to be paid will get 0 ether by default, which can
+	be changed by passing the -v/--value flag.` + "`" + `
+
+func init() {
+    AvailableCommands = append(AvailableCommands, cli.Command{
+        Name:        "{{.DashedName}}",
+        Usage:       ` + "`" + `Provides access to the {{.Class}} contract.` + "`" + `,
+        Description: {{.FullVar}}Description,
+        Subcommands: []cli.Command{
+            {{- $contract := . -}}
+            {{- range $i, $method := .ConstMethods }}
+            {{- if $method.CommandCallable -}}
+                {
+                    Name: "{{$method.DashedName}}",
+                    Usage: "Calls the {{$method.Modifiers -}} method {{$method.LowerName}} on the {{$contract.Class}} contract.",
+                    ArgsUsage: "{{ range $i, $param := $method.ParamInfos -}} [{{$param.Name}}] {{ end }}",
+                    Action: {{$contract.ShortVar}}{{$method.CapsName}},
+                    Before: cmd.ArgCountChecker({{$method.ParamInfos | len}}),
+                    Flags: ConstFlags,
+                },
+            {{- end -}}
+            {{- end -}}
+            {{- range $i, $method := .NonConstMethods }}
+            {{- if $method.CommandCallable -}}
+                {
+                    Name: "{{$method.DashedName}}",
+                    Usage: "Calls the {{$method.Modifiers -}} method {{$method.LowerName}} on the {{$contract.Class}} contract.",
+                    ArgsUsage: "{{ range $i, $param := $method.ParamInfos -}} [{{$param.Name}}] {{ end }}",
+                    Action: {{$contract.ShortVar}}{{$method.CapsName}},
+                    Before:
+                        {{- if $method.Payable -}}
+                        cli.BeforeFunc(PayableArgsChecker.AndThen(cmd.ArgCountChecker({{$method.ParamInfos | len}})))
+                        {{- else -}}
+                        cli.BeforeFunc(NonConstArgsChecker.AndThen(cmd.ArgCountChecker({{$method.ParamInfos | len}})))
+                        {{- end }},
+                    Flags:
+                        {{- if $method.Payable -}}
+                        PayableFlags
+                        {{- else -}}
+                        NonConstFlags
+                        {{- end }},
+                },
+            {{- end -}}
+            {{- end -}}
+        },
+    })
+}
+
+/// ------------------- Const methods -------------------
+
+{{- $contract := . -}}
+{{- range $i, $method := .ConstMethods -}}
+{{- if $method.CommandCallable }}
+
+func {{$contract.ShortVar}}{{$method.CapsName}}(c *cli.Context) error {
+    contract, err := initialize{{$contract.Class}}(c)
+    if err != nil {
+        return err
+    }
+
+   	{{- range $i, $param := .ParamInfos }}
+   	{{$param.Name}}, err := {{$param.ParsingFn}}(c.Args()[{{$i}}])
+   	if err != nil {
+		return fmt.Errorf(
+			"couldn't parse parameter {{$param.Name}}, a {{$param.Type}}, from passed value %v",
+			c.Args()[{{$i}}],
+		)
+   	}
+   	{{ end }}
+
+    result, err := contract.{{$method.CapsName}}AtBlock(
+        {{$method.Params}}
+        cmd.BlockFlagValue.Uint,
+    )
+
+    if err != nil {
+    	return err
+    }
+
+    return printResult(c, result)
+}
+
+{{- end -}}
+{{- end }}
+
+/// ------------------- Non-const methods -------------------
+
+{{- range $i, $method := .NonConstMethods -}}
+{{- if $method.CommandCallable }}
+
+func {{$contract.ShortVar}}{{$method.CapsName}}(c *cli.Context) error {
+    contract, err := initialize{{$contract.Class}}(c)
+    if err != nil {
+        return err
+    }
+
+    {{ range $i, $param := .ParamInfos }}
+    {{$param.Name}}, err := {{$param.ParsingFn}}(c.Args()[{{$i}}])
+    if err != nil {
+        return fmt.Errorf(
+            "couldn't parse parameter {{$param.Name}}, a {{$param.Type}}, from passed value %v",
+            c.Args()[{{$i}}],
+        )
+    }
+
+    {{ end -}}
+
+    var (
+        transaction *types.Transaction
+        {{ if gt (len $method.Return.Type) 0 -}}
+        result {{$method.Return.Type}}
+        {{ end -}}
+    )
+
+    switch {
+    case c.Bool(cmd.SubmitFlag):
+        // Do a regular submission. Take payable into account.
+        transaction, err = contract.{{$method.CapsName}}(
+            {{$method.Params}}
+            {{- if $method.Payable -}} cmd.ValueFlagValue.Uint, {{- end -}}
+        )
+        if err != nil {
+            return err
+        }
+
+        return printTransaction(c, transaction.Hash())
+    case c.Bool(estimateGasFlag):
+        // Estimate gas needed to submit the transaction.
+        gasEstimate, err := contract.{{$method.CapsName}}GasEstimate(
+            {{$method.Params}}
+        )
+        if err != nil {
+            return err
+        }
+
+        return printGasEstimate(c, gasEstimate)
+    default:
+        // Do a call.
+        {{ if gt (len $method.Return.Type) 0 -}} result, {{ end -}} err = contract.Call{{$method.CapsName}}(
+            {{$method.Params}}
+            {{- if $method.Payable -}} cmd.ValueFlagValue.Uint, {{- end -}}
+            cmd.BlockFlagValue.Uint,
+        )
+        if err != nil {
+            return err
+        }
+
+        {{ if gt (len $method.Return.Type) 0 -}}
+        return printResult(c, result)
+        {{- else -}}
+        return printResult(c, nil)
+        {{- end }}
+    }
+}
+
+{{- end -}}
+{{- end }}
+
+/// ------------------- Initialization -------------------
+
+func initialize{{.Class}}(c *cli.Context) (*contract.{{.Class}}, error) {
+    config, err := {{.ConfigReader}}(c.GlobalString("config"))
+    if err != nil {
+        return nil, fmt.Errorf("error reading config from file: [%v]", err)
+    }
+
+    client, _, _, err := chainutil.ConnectClients(config.URL, config.URLRPC)
+    if err != nil {
+        return nil, fmt.Errorf("error connecting to host chain node: [%v]", err)
+    }
+
+   	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to resolve host chain id: [%v]",
+			err,
+		)
+	}
+
+    key, err := chainutil.DecryptKeyFile(
+        config.Account.KeyFile,
+        config.Account.KeyFilePassword,
+    )
+    if err != nil {
+        return nil, fmt.Errorf(
+            "failed to read KeyFile: %s: [%v]",
+            config.Account.KeyFile,
+            err,
+        )
+    }
+
+	checkInterval := cmd.DefaultMiningCheckInterval
+	maxGasPrice := cmd.DefaultMaxGasPrice
+	if config.MiningCheckInterval != 0 {
+		checkInterval = time.Duration(config.MiningCheckInterval) * time.Second
+	}
+	if config.MaxGasPrice != nil {
+		maxGasPrice = config.MaxGasPrice.Int
+	}
+
+	miningWaiter := chainutil.NewMiningWaiter(
+		client,
+		checkInterval,
+		maxGasPrice,
+	)
+
+	blockCounter, err := chainutil.NewBlockCounter(client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create block counter: [%v]",
+			err,
+		)
+	}
+
+    address := common.HexToAddress(config.ContractAddresses["{{.Class}}"])
+
+    return contract.New{{.Class}}(
+        address,
+        chainID,
+        key,
+        client,
+        chainutil.NewNonceManager(client, key.Address),
+        miningWaiter,
+        blockCounter,
+        &sync.Mutex{},
+    )
+}
+`
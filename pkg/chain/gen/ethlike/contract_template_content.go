@@ -0,0 +1,138 @@
+package main
+
+// contractTemplateContent contains the template string from contract.go.tmpl
+var contractTemplateContent = `// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contract
+
+import (
+	"strings"
+	"sync"
+
+	hostchainabi "{{.HostChainModule}}/accounts/abi"
+	"{{.HostChainModule}}/accounts/abi/bind"
+	"{{.HostChainModule}}/accounts/keystore"
+	"{{.HostChainModule}}/common"
+	"{{.HostChainModule}}/core/types"
+	"{{.HostChainModule}}/crypto"
+	"{{.HostChainModule}}/event"
+
+	"github.com/ipfs/go-log"
+
+	chainutil "{{.ChainUtilPackage}}"
+	"github.com/keep-network/keep-common/pkg/chain/ethlike"
+	"github.com/keep-network/keep-common/pkg/subscription"
+)
+
+// Create a package-level logger for this contract. The logger exists at
+// package level so that the logger is registered at startup and can be
+// included or excluded from logging at startup by name.
+var {{.ShortVar}}Logger = log.Logger("keep-contract-{{.Class}}")
+
+type {{.Class}} struct {
+	contract           *abi.{{.AbiClass}}
+	contractAddress    common.Address
+	contractABI        *hostchainabi.ABI
+	caller             bind.ContractCaller
+	transactor         bind.ContractTransactor
+	callerOptions      *bind.CallOpts
+	transactorOptions  *bind.TransactOpts
+	errorResolver      *chainutil.ErrorResolver
+	nonceManager       *ethlike.NonceManager
+	miningWaiter       *ethlike.MiningWaiter
+	blockCounter	   *ethlike.BlockCounter
+
+	transactionMutex *sync.Mutex
+}
+
+func New{{.Class}}(
+    contractAddress common.Address,
+    chainId *big.Int,
+    accountKey *keystore.Key,
+    backend bind.ContractBackend,
+    nonceManager *ethlike.NonceManager,
+    miningWaiter *ethlike.MiningWaiter,
+    blockCounter *ethlike.BlockCounter,
+    transactionMutex *sync.Mutex,
+) (*{{.Class}}, error) {
+	// FIXME Switch to bind.NewKeyedTransactorWithChainID when
+	// FIXME celo-org/celo-blockchain merges in changes from upstream
+	// FIXME ethereum/go-ethereum beyond v1.9.25.
+	transactorOptions, err := chainutil.NewKeyedTransactorWithChainID(
+		accountKey.PrivateKey,
+		chainId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate transactor: [%v]", err)
+	}
+
+	return New{{.Class}}WithTransactor(
+		contractAddress,
+		transactorOptions,
+		backend,
+		nonceManager,
+		miningWaiter,
+		blockCounter,
+		transactionMutex,
+	)
+}
+
+// New{{.Class}}WithTransactor creates the contract handle submitting
+// transactions from the account and with the signer of the given transactor
+// options, for example a signer delegating to an external signing service.
+func New{{.Class}}WithTransactor(
+	contractAddress common.Address,
+	transactorOptions *bind.TransactOpts,
+	backend bind.ContractBackend,
+	nonceManager *ethlike.NonceManager,
+	miningWaiter *ethlike.MiningWaiter,
+	blockCounter *ethlike.BlockCounter,
+	transactionMutex *sync.Mutex,
+) (*{{.Class}}, error) {
+	callerOptions := &bind.CallOpts{
+		From: transactorOptions.From,
+	}
+
+	contract, err := abi.New{{.AbiClass}}(
+		contractAddress,
+		backend,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate contract at address: %s [%v]",
+			contractAddress.String(),
+			err,
+		)
+	}
+
+	contractABI, err := hostchainabi.JSON(strings.NewReader(abi.{{.AbiClass}}ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate ABI: [%v]", err)
+	}
+
+	return &{{.Class}}{
+		contract:          contract,
+		contractAddress:   contractAddress,
+		contractABI: 	   &contractABI,
+		caller:     	   backend,
+		transactor:        backend,
+		callerOptions:     callerOptions,
+		transactorOptions: transactorOptions,
+		errorResolver:     chainutil.NewErrorResolver(backend, &contractABI, &contractAddress),
+		nonceManager:      nonceManager,
+		miningWaiter:      miningWaiter,
+		blockCounter: 	   blockCounter,
+		transactionMutex:  transactionMutex,
+	}, nil
+}
+
+// ----- Non-const Methods ------
+{{template "contract_non_const_methods.go.tmpl" .}}
+
+// ----- Const Methods ------
+{{template "contract_const_methods.go.tmpl" .}}
+
+// ------ Events -------
+{{template "contract_events.go.tmpl" . -}}
+`
@@ -47,6 +47,11 @@ type Chain interface {
 	// GetRelayEntryTimeoutReports returns an array of blocks which denote at what
 	// block a relay entry timeout occured.
 	GetRelayEntryTimeoutReports() []uint64
+
+	// RequestRelayEntry starts a new relay request at the current block and
+	// notifies relay request handlers about it. It returns the start block
+	// of the request.
+	RequestRelayEntry(previousEntry []byte) (uint64, error)
 }
 
 type localGroup struct {
@@ -80,6 +85,10 @@ type localChain struct {
 	relayEntryTimeoutReportsMutex sync.Mutex
 	relayEntryTimeoutReports      []uint64
 
+	currentRequestMutex      sync.Mutex
+	currentRequestStartBlock uint64
+	isEntryInProgress        bool
+
 	operatorKey *ecdsa.PrivateKey
 
 	minimumStake *big.Int
@@ -203,6 +212,10 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 
 	c.lastSubmittedRelayEntry = newEntry
 
+	c.currentRequestMutex.Lock()
+	c.isEntryInProgress = false
+	c.currentRequestMutex.Unlock()
+
 	return relayEntryPromise
 }
 
@@ -487,6 +500,13 @@ func (c *localChain) GetLastDKGResult() (
 }
 
 func (c *localChain) ReportRelayEntryTimeout() error {
+	c.currentRequestMutex.Lock()
+	defer c.currentRequestMutex.Unlock()
+
+	if !c.isEntryInProgress {
+		return fmt.Errorf("relay entry is not in progress")
+	}
+
 	c.relayEntryTimeoutReportsMutex.Lock()
 	defer c.relayEntryTimeoutReportsMutex.Unlock()
 
@@ -496,15 +516,51 @@ func (c *localChain) ReportRelayEntryTimeout() error {
 	}
 
 	c.relayEntryTimeoutReports = append(c.relayEntryTimeoutReports, currentBlock)
+	c.isEntryInProgress = false
+
 	return nil
 }
 
+func (c *localChain) RequestRelayEntry(previousEntry []byte) (uint64, error) {
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	c.currentRequestMutex.Lock()
+	c.currentRequestStartBlock = currentBlock
+	c.isEntryInProgress = true
+	c.currentRequestMutex.Unlock()
+
+	request := &event.Request{
+		PreviousEntry:  previousEntry,
+		GroupPublicKey: seedGroupPublicKey,
+		BlockNumber:    currentBlock,
+	}
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.relayRequestHandlers {
+		go func(handler func(request *event.Request), request *event.Request) {
+			handler(request)
+		}(handler, request)
+	}
+	c.handlerMutex.Unlock()
+
+	return currentBlock, nil
+}
+
 func (c *localChain) IsEntryInProgress() (bool, error) {
-	panic("not implemented")
+	c.currentRequestMutex.Lock()
+	defer c.currentRequestMutex.Unlock()
+
+	return c.isEntryInProgress, nil
 }
 
 func (c *localChain) CurrentRequestStartBlock() (*big.Int, error) {
-	panic("not implemented")
+	c.currentRequestMutex.Lock()
+	defer c.currentRequestMutex.Unlock()
+
+	return new(big.Int).SetUint64(c.currentRequestStartBlock), nil
 }
 
 func (c *localChain) CurrentRequestPreviousEntry() ([]byte, error) {
@@ -516,6 +572,9 @@ func (c *localChain) CurrentRequestGroupPublicKey() ([]byte, error) {
 }
 
 func (c *localChain) GetRelayEntryTimeoutReports() []uint64 {
+	c.relayEntryTimeoutReportsMutex.Lock()
+	defer c.relayEntryTimeoutReportsMutex.Unlock()
+
 	return c.relayEntryTimeoutReports
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/diagnostics"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/ledger"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 )
@@ -31,32 +32,36 @@ func RegisterConnectedPeersSource(
 	netProvider net.Provider,
 ) {
 	registry.RegisterSource("connected_peers", func() string {
-		connectionManager := netProvider.ConnectionManager()
-		connectedPeers := connectionManager.ConnectedPeers()
-
-		peersList := make([]map[string]interface{}, len(connectedPeers))
-		for i := 0; i < len(connectedPeers); i++ {
-			peer := connectedPeers[i]
-			peerPublicKey, err := connectionManager.GetPeerPublicKey(peer)
-			if err != nil {
-				logger.Error("error on getting peer public key: [%v]", err)
-				continue
-			}
+		return connectedPeers(netProvider)
+	})
+}
 
-			peersList[i] = map[string]interface{}{
-				"network_id":       peer,
-				"ethereum_address": key.NetworkPubKeyToChainAddress(peerPublicKey),
-			}
-		}
+func connectedPeers(netProvider net.Provider) string {
+	connectionManager := netProvider.ConnectionManager()
+	connectedPeers := connectionManager.ConnectedPeers()
 
-		bytes, err := json.Marshal(peersList)
+	peersList := make([]map[string]interface{}, len(connectedPeers))
+	for i := 0; i < len(connectedPeers); i++ {
+		peer := connectedPeers[i]
+		peerPublicKey, err := connectionManager.GetPeerPublicKey(peer)
 		if err != nil {
-			logger.Error("error on serializing peers list to JSON: [%v]", err)
-			return ""
+			logger.Error("error on getting peer public key: [%v]", err)
+			continue
 		}
 
-		return string(bytes)
-	})
+		peersList[i] = map[string]interface{}{
+			"network_id":       peer,
+			"ethereum_address": key.NetworkPubKeyToChainAddress(peerPublicKey),
+		}
+	}
+
+	bytes, err := json.Marshal(peersList)
+	if err != nil {
+		logger.Error("error on serializing peers list to JSON: [%v]", err)
+		return ""
+	}
+
+	return string(bytes)
 }
 
 // RegisterClientInfoSource registers the diagnostics source providing
@@ -66,26 +71,120 @@ func RegisterClientInfoSource(
 	netProvider net.Provider,
 ) {
 	registry.RegisterSource("client_info", func() string {
-		connectionManager := netProvider.ConnectionManager()
+		return clientInfo(netProvider)
+	})
+}
 
-		clientID := netProvider.ID().String()
-		clientPublicKey, err := connectionManager.GetPeerPublicKey(clientID)
-		if err != nil {
-			logger.Error("error on getting client public key: [%v]", err)
-			return ""
-		}
+func clientInfo(netProvider net.Provider) string {
+	connectionManager := netProvider.ConnectionManager()
+
+	clientID := netProvider.ID().String()
+	clientPublicKey, err := connectionManager.GetPeerPublicKey(clientID)
+	if err != nil {
+		logger.Error("error on getting client public key: [%v]", err)
+		return ""
+	}
+
+	clientInfo := map[string]interface{}{
+		"network_id":       clientID,
+		"ethereum_address": key.NetworkPubKeyToChainAddress(clientPublicKey),
+	}
 
-		clientInfo := map[string]interface{}{
-			"network_id":       clientID,
-			"ethereum_address": key.NetworkPubKeyToChainAddress(clientPublicKey),
+	bytes, err := json.Marshal(clientInfo)
+	if err != nil {
+		logger.Error("error on serializing client info to JSON: [%v]", err)
+		return ""
+	}
+
+	return string(bytes)
+}
+
+// maxParticipationLedgerRecords is the maximum number of the most recent
+// participation ledger records exposed by the diagnostics source.
+const maxParticipationLedgerRecords = 100
+
+// RegisterParticipationLedgerSource registers the diagnostics source providing
+// the most recent records of the participation ledger.
+func RegisterParticipationLedgerSource(
+	registry *diagnostics.Registry,
+	participationLedger *ledger.Ledger,
+) {
+	registry.RegisterSource("participation_ledger", func() string {
+		return participationLedgerRecords(participationLedger)
+	})
+}
+
+func participationLedgerRecords(participationLedger *ledger.Ledger) string {
+	records, errors := participationLedger.Records()
+	for _, err := range errors {
+		logger.Errorf("error on reading participation ledger: [%v]", err)
+	}
+
+	if len(records) > maxParticipationLedgerRecords {
+		records = records[len(records)-maxParticipationLedgerRecords:]
+	}
+
+	bytes, err := json.Marshal(records)
+	if err != nil {
+		logger.Errorf(
+			"error on serializing participation ledger to JSON: [%v]",
+			err,
+		)
+		return ""
+	}
+
+	return string(bytes)
+}
+
+// Operator holds the state of one of the operators run by the client
+// exposed by the operators diagnostics source.
+type Operator struct {
+	Address             string
+	NetProvider         net.Provider
+	ParticipationLedger *ledger.Ledger
+}
+
+// RegisterOperatorsSource registers the diagnostics source providing the
+// client info, connected peers and participation ledger of each of the
+// operators run by the client, keyed by the operator address. It is used
+// instead of the client_info, connected_peers and participation_ledger
+// sources when the client runs multiple operators.
+func RegisterOperatorsSource(
+	registry *diagnostics.Registry,
+	operators []*Operator,
+) {
+	registry.RegisterSource("operators", func() string {
+		operatorsInfo := make(map[string]map[string]json.RawMessage)
+		for _, operator := range operators {
+			operatorsInfo[operator.Address] = map[string]json.RawMessage{
+				"client_info": rawJSON(
+					clientInfo(operator.NetProvider),
+				),
+				"connected_peers": rawJSON(
+					connectedPeers(operator.NetProvider),
+				),
+				"participation_ledger": rawJSON(
+					participationLedgerRecords(operator.ParticipationLedger),
+				),
+			}
 		}
 
-		bytes, err := json.Marshal(clientInfo)
+		bytes, err := json.Marshal(operatorsInfo)
 		if err != nil {
-			logger.Error("error on serializing client info to JSON: [%v]", err)
+			logger.Errorf("error on serializing operators to JSON: [%v]", err)
 			return ""
 		}
 
 		return string(bytes)
 	})
 }
+
+// rawJSON returns the given serialized source value as raw JSON. Sources
+// which could not be serialized are represented as null.
+func rawJSON(value string) json.RawMessage {
+	if value == "" {
+		return json.RawMessage("null")
+	}
+
+	return json.RawMessage(value)
+}
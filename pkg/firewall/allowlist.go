@@ -0,0 +1,102 @@
+package firewall
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+var errNotAllowed = fmt.Errorf("remote peer is not on the allow list")
+
+// AllowList is a net.Firewall rule accepting only remote peers whose chain
+// addresses are on the list. It is meant for nodes validating peers without
+// a connection to the chain, with the list of staked operators cached from
+// the chain by other means.
+type AllowList struct {
+	mutex   sync.RWMutex
+	allowed map[string]bool
+}
+
+// NewAllowList creates an AllowList accepting the given chain addresses.
+func NewAllowList(addresses []string) *AllowList {
+	allowList := &AllowList{}
+	allowList.Replace(addresses)
+	return allowList
+}
+
+// Replace replaces all the addresses on the allow list with the given ones.
+func (al *AllowList) Replace(addresses []string) {
+	allowed := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		allowed[strings.ToLower(address)] = true
+	}
+
+	al.mutex.Lock()
+	defer al.mutex.Unlock()
+
+	al.allowed = allowed
+}
+
+// Len returns the number of addresses on the allow list.
+func (al *AllowList) Len() int {
+	al.mutex.RLock()
+	defer al.mutex.RUnlock()
+
+	return len(al.allowed)
+}
+
+// Validate rejects the remote peer if its chain address is not on the list.
+func (al *AllowList) Validate(remotePeerPublicKey *ecdsa.PublicKey) error {
+	networkPublicKey := key.NetworkPublic(*remotePeerPublicKey)
+	address := key.NetworkPubKeyToChainAddress(&networkPublicKey)
+
+	al.mutex.RLock()
+	defer al.mutex.RUnlock()
+
+	if !al.allowed[strings.ToLower(address)] {
+		return errNotAllowed
+	}
+
+	return nil
+}
+
+// ReadAllowListFile reads chain addresses from the file, one address per
+// line. Empty lines and lines starting with `#` are skipped.
+func ReadAllowListFile(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open allow list file: [%v]", err)
+	}
+	defer file.Close()
+
+	var addresses []string
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !common.IsHexAddress(line) {
+			return nil, fmt.Errorf(
+				"invalid address [%v] in line [%v] of allow list file",
+				line,
+				lineNumber,
+			)
+		}
+
+		addresses = append(addresses, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read allow list file: [%v]", err)
+	}
+
+	return addresses, nil
+}
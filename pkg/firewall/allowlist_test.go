@@ -0,0 +1,91 @@
+package firewall
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+func TestAllowList(t *testing.T) {
+	_, allowedPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowedAddress := key.NetworkPubKeyToChainAddress(allowedPublicKey)
+
+	_, otherPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowList := NewAllowList([]string{strings.ToLower(allowedAddress)})
+
+	if err := allowList.Validate(
+		key.NetworkKeyToECDSAKey(allowedPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	if err := allowList.Validate(
+		key.NetworkKeyToECDSAKey(otherPublicKey),
+	); err != errNotAllowed {
+		t.Fatalf(
+			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
+			err,
+			errNotAllowed,
+		)
+	}
+
+	allowList.Replace(nil)
+
+	if err := allowList.Validate(
+		key.NetworkKeyToECDSAKey(allowedPublicKey),
+	); err != errNotAllowed {
+		t.Fatalf(
+			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
+			err,
+			errNotAllowed,
+		)
+	}
+}
+
+func TestReadAllowListFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "allowlist")
+	content := `# staked operators
+0x3712C6fED51CECA83cA953f6FF3458f2339436b4
+
+  0x4bfa10b1538e8e765e995688d8eec39c717b6797
+`
+	if err := ioutil.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	addresses, err := ReadAllowListFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAddresses := []string{
+		"0x3712C6fED51CECA83cA953f6FF3458f2339436b4",
+		"0x4bfa10b1538e8e765e995688d8eec39c717b6797",
+	}
+	if !reflect.DeepEqual(expectedAddresses, addresses) {
+		t.Fatalf(
+			"unexpected addresses\nexpected: [%v]\nactual:   [%v]",
+			expectedAddresses,
+			addresses,
+		)
+	}
+
+	if err := ioutil.WriteFile(filePath, []byte("not-an-address\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadAllowListFile(filePath); err == nil {
+		t.Fatal("expected an error for an invalid address")
+	}
+}
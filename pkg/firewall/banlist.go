@@ -0,0 +1,83 @@
+package firewall
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+// BanList is a net.Firewall rule rejecting remote peers whose chain
+// addresses have been banned by the operator. Remote peers which are not
+// banned are validated against the wrapped firewall. Bans are kept in memory
+// and they do not survive the client restart.
+type BanList struct {
+	mutex  sync.RWMutex
+	banned map[string]bool
+
+	firewall net.Firewall
+}
+
+// NewBanList creates an empty BanList delegating the validation of peers
+// which are not banned to the provided firewall.
+func NewBanList(firewall net.Firewall) *BanList {
+	return &BanList{
+		banned:   make(map[string]bool),
+		firewall: firewall,
+	}
+}
+
+// Ban adds the given chain address to the ban list.
+func (bl *BanList) Ban(address string) {
+	bl.mutex.Lock()
+	defer bl.mutex.Unlock()
+
+	bl.banned[strings.ToLower(address)] = true
+}
+
+// Unban removes the given chain address from the ban list.
+func (bl *BanList) Unban(address string) {
+	bl.mutex.Lock()
+	defer bl.mutex.Unlock()
+
+	delete(bl.banned, strings.ToLower(address))
+}
+
+// IsBanned checks whether the given chain address is on the ban list.
+func (bl *BanList) IsBanned(address string) bool {
+	bl.mutex.RLock()
+	defer bl.mutex.RUnlock()
+
+	return bl.banned[strings.ToLower(address)]
+}
+
+// Banned returns all the chain addresses on the ban list, sorted.
+func (bl *BanList) Banned() []string {
+	bl.mutex.RLock()
+	defer bl.mutex.RUnlock()
+
+	addresses := make([]string, 0, len(bl.banned))
+	for address := range bl.banned {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// Validate rejects the remote peer if its chain address is banned and
+// validates it against the wrapped firewall otherwise.
+func (bl *BanList) Validate(remotePeerPublicKey *ecdsa.PublicKey) error {
+	networkPublicKey := key.NetworkPublic(*remotePeerPublicKey)
+	address := key.NetworkPubKeyToChainAddress(&networkPublicKey)
+
+	if bl.IsBanned(address) {
+		return fmt.Errorf("remote peer [%v] is banned", address)
+	}
+
+	return bl.firewall.Validate(remotePeerPublicKey)
+}
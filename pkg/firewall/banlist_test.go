@@ -0,0 +1,61 @@
+package firewall
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+func TestBanListRejectsBannedPeer(t *testing.T) {
+	banList := NewBanList(Disabled)
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remotePeerAddress := key.NetworkPubKeyToChainAddress(remotePeerPublicKey)
+
+	if err := banList.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	banList.Ban(strings.ToUpper(remotePeerAddress))
+
+	if err := banList.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err == nil {
+		t.Fatal("validation should fail for a banned peer")
+	}
+
+	banList.Unban(remotePeerAddress)
+
+	if err := banList.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass after unban: [%v]", err)
+	}
+}
+
+func TestBanListDelegatesToWrappedFirewall(t *testing.T) {
+	stakeMonitor := local.NewStakeMonitor(minimumStake)
+	banList := NewBanList(MinimumStakePolicy(stakeMonitor))
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := banList.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != errNoMinimumStake {
+		t.Fatalf(
+			"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
+			err,
+			errNoMinimumStake,
+		)
+	}
+}
@@ -147,7 +147,7 @@ func executeDKG(
 	for i := 0; i < relayConfig.GroupSize; i++ {
 		i := i // capture for goroutine
 		go func() {
-			signer, err := dkg.ExecuteDKG(
+			signer, _, err := dkg.ExecuteDKG(
 				seed,
 				uint8(i),
 				relayConfig.GroupSize,
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/metrics"
+)
+
+// LabelledRegistry exposes gauges which share names and differ in labels,
+// such as the same metric observed for each of the operators run by one
+// client or for each of the peer operators observed by the client. The
+// keep-common registry identifies metrics by their names only and can not
+// hold such gauges.
+type LabelledRegistry struct {
+	mutex sync.RWMutex
+	// gauges maps metric names to gauges of the metric by their labels.
+	gauges map[string]map[string]*labelledGauge
+}
+
+// InitializeLabelled sets up the labelled metrics registry and enables the
+// metrics server exposing it.
+func InitializeLabelled(port int) (*LabelledRegistry, bool) {
+	if port == 0 {
+		return nil, false
+	}
+
+	registry := &LabelledRegistry{
+		gauges: make(map[string]map[string]*labelledGauge),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(response http.ResponseWriter, _ *http.Request) {
+		if _, err := io.WriteString(response, registry.expose()); err != nil {
+			logger.Errorf("could not write response: [%v]", err)
+		}
+	})
+
+	server := &http.Server{Addr: ":" + strconv.Itoa(port), Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Errorf("metrics server error: [%v]", err)
+		}
+	}()
+
+	return registry, true
+}
+
+// WithLabel returns the registry of gauges carrying the given label.
+func (lr *LabelledRegistry) WithLabel(name string, value string) Registry {
+	return &labelledRegistryView{
+		registry: lr,
+		labels:   fmt.Sprintf("%v=%q", name, value),
+	}
+}
+
+func (lr *LabelledRegistry) newGauge(
+	name string,
+	labels string,
+) (*labelledGauge, error) {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+
+	gauges, ok := lr.gauges[name]
+	if !ok {
+		gauges = make(map[string]*labelledGauge)
+		lr.gauges[name] = gauges
+	}
+
+	if _, exists := gauges[labels]; exists {
+		return nil, fmt.Errorf(
+			"metric [%v] with labels [%v] already exists",
+			name,
+			labels,
+		)
+	}
+
+	gauge := &labelledGauge{}
+	gauges[labels] = gauge
+
+	return gauge, nil
+}
+
+// expose exposes all the gauges in the text-based exposition format.
+func (lr *LabelledRegistry) expose() string {
+	lr.mutex.RLock()
+	defer lr.mutex.RUnlock()
+
+	names := make([]string, 0, len(lr.gauges))
+	for name := range lr.gauges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	metrics := make([]string, 0, len(names))
+	for _, name := range names {
+		lines := []string{fmt.Sprintf("# TYPE %v gauge", name)}
+
+		gauges := lr.gauges[name]
+		labels := make([]string, 0, len(gauges))
+		for label := range gauges {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			value, timestamp := gauges[label].get()
+
+			if label == "" {
+				lines = append(
+					lines,
+					fmt.Sprintf("%v %v %v", name, value, timestamp),
+				)
+				continue
+			}
+
+			lines = append(
+				lines,
+				fmt.Sprintf("%v{%v} %v %v", name, label, value, timestamp),
+			)
+		}
+
+		metrics = append(metrics, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(metrics, "\n\n")
+}
+
+type labelledRegistryView struct {
+	registry *LabelledRegistry
+	labels   string
+}
+
+func (lrv *labelledRegistryView) NewGauge(
+	name string,
+) (metrics.ObserverOutput, error) {
+	return lrv.registry.newGauge(name, lrv.labels)
+}
+
+func (lrv *labelledRegistryView) WithLabel(name string, value string) Registry {
+	label := fmt.Sprintf("%v=%q", name, value)
+	if lrv.labels != "" {
+		label = lrv.labels + "," + label
+	}
+
+	return &labelledRegistryView{
+		registry: lrv.registry,
+		labels:   label,
+	}
+}
+
+type labelledGauge struct {
+	mutex     sync.RWMutex
+	value     float64
+	timestamp int64 // timestamp expressed as milliseconds
+}
+
+func (lg *labelledGauge) Set(value float64) {
+	lg.mutex.Lock()
+	defer lg.mutex.Unlock()
+
+	lg.value = value
+	lg.timestamp = time.Now().UnixNano() / 1e6
+}
+
+func (lg *labelledGauge) get() (float64, int64) {
+	lg.mutex.RLock()
+	defer lg.mutex.RUnlock()
+
+	return lg.value, lg.timestamp
+}
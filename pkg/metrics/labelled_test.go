@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLabelledRegistryExpose(t *testing.T) {
+	registry := &LabelledRegistry{
+		gauges: make(map[string]map[string]*labelledGauge),
+	}
+
+	operator1 := registry.WithLabel("operator", "0x01")
+	operator2 := registry.WithLabel("operator", "0x02")
+
+	peers1, err := operator1.NewGauge("connected_peers_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peers2, err := operator2.NewGauge("connected_peers_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	connectivity1, err := operator1.NewGauge("eth_connectivity")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := operator1.NewGauge("connected_peers_count"); err == nil {
+		t.Fatal("expected error for duplicated gauge")
+	}
+
+	peers1.Set(5)
+	peers2.Set(7)
+	connectivity1.Set(1)
+
+	timestamp := func(gauge interface{}) int64 {
+		_, timestamp := gauge.(*labelledGauge).get()
+		return timestamp
+	}
+
+	expected := fmt.Sprintf(
+		"# TYPE connected_peers_count gauge\n"+
+			"connected_peers_count{operator=\"0x01\"} 5 %v\n"+
+			"connected_peers_count{operator=\"0x02\"} 7 %v\n"+
+			"\n"+
+			"# TYPE eth_connectivity gauge\n"+
+			"eth_connectivity{operator=\"0x01\"} 1 %v",
+		timestamp(peers1),
+		timestamp(peers2),
+		timestamp(connectivity1),
+	)
+
+	if actual := registry.expose(); actual != expected {
+		t.Errorf("\nexpected:\n%v\nactual:\n%v", expected, actual)
+	}
+}
+
+func TestLabelledRegistryExposeNestedLabels(t *testing.T) {
+	registry := &LabelledRegistry{
+		gauges: make(map[string]map[string]*labelledGauge),
+	}
+
+	unlabelled := &labelledRegistryView{registry: registry}
+	operator := registry.WithLabel("operator", "0x01")
+
+	rate, err := unlabelled.NewGauge("peer_operator_share_delivery_rate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerRate, err := operator.WithLabel("peer_operator", "0x02").NewGauge(
+		"peer_operator_share_delivery_rate",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rate.Set(1)
+	peerRate.Set(0.5)
+
+	timestamp := func(gauge interface{}) int64 {
+		_, timestamp := gauge.(*labelledGauge).get()
+		return timestamp
+	}
+
+	expected := fmt.Sprintf(
+		"# TYPE peer_operator_share_delivery_rate gauge\n"+
+			"peer_operator_share_delivery_rate 1 %v\n"+
+			"peer_operator_share_delivery_rate"+
+			"{operator=\"0x01\",peer_operator=\"0x02\"} 0.5 %v",
+		timestamp(rate),
+		timestamp(peerRate),
+	)
+
+	if actual := registry.expose(); actual != expected {
+		t.Errorf("\nexpected:\n%v\nactual:\n%v", expected, actual)
+	}
+}
@@ -2,10 +2,12 @@ package metrics
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/reliability"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
@@ -21,56 +23,132 @@ const (
 	DefaultEthereumMetricsTick = 10 * time.Minute
 )
 
+// Registry registers gauges exposed by the metrics server.
+type Registry interface {
+	// NewGauge registers a new gauge with the given name.
+	NewGauge(name string) (metrics.ObserverOutput, error)
+	// WithLabel returns the registry of gauges carrying the given label in
+	// addition to the labels of this registry.
+	WithLabel(name string, value string) Registry
+}
+
 // Initialize set up the metrics registry and enables metrics server.
+// Gauges registered directly in the registry are exposed without labels.
 func Initialize(
 	port int,
-) (*metrics.Registry, bool) {
-	if port == 0 {
+) (Registry, bool) {
+	registry, isConfigured := InitializeLabelled(port)
+	if !isConfigured {
 		return nil, false
 	}
 
-	registry := metrics.NewRegistry()
+	return &labelledRegistryView{registry: registry}, true
+}
+
+// Observation is a running observation process of a metric.
+type Observation struct {
+	ctx         context.Context
+	update      func()
+	defaultTick time.Duration
+
+	mutex     sync.Mutex
+	tick      time.Duration
+	cancelCtx context.CancelFunc
+}
+
+func newObservation(
+	ctx context.Context,
+	update func(),
+	tick time.Duration,
+	defaultTick time.Duration,
+) *Observation {
+	observation := &Observation{
+		ctx:         ctx,
+		update:      update,
+		defaultTick: defaultTick,
+	}
+	observation.SetTick(tick)
+
+	return observation
+}
+
+// SetTick changes the duration of the observation tick. If the tick is not
+// positive, the default tick of the metric is used. The observation is
+// restarted only if the effective tick changes.
+func (o *Observation) SetTick(tick time.Duration) {
+	tick = validateTick(tick, o.defaultTick)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.cancelCtx != nil {
+		if o.tick == tick {
+			return
+		}
+		o.cancelCtx()
+	}
+
+	ctx, cancelCtx := context.WithCancel(o.ctx)
+	o.tick = tick
+	o.cancelCtx = cancelCtx
+
+	go o.observe(ctx, tick)
+}
 
-	registry.EnableServer(port)
+func (o *Observation) observe(ctx context.Context, tick time.Duration) {
+	o.update() // execute the first check immediately
 
-	return registry, true
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.update()
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // ObserveConnectedPeersCount triggers an observation process of the
 // connected_peers_count metric.
 func ObserveConnectedPeersCount(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry Registry,
 	netProvider net.Provider,
 	tick time.Duration,
-) {
+) *Observation {
 	input := func() float64 {
 		connectedPeers := netProvider.ConnectionManager().ConnectedPeers()
 		return float64(len(connectedPeers))
 	}
 
-	observe(
+	return observe(
 		ctx,
 		"connected_peers_count",
 		input,
 		registry,
-		validateTick(tick, DefaultNetworkMetricsTick),
+		tick,
+		DefaultNetworkMetricsTick,
 	)
 }
 
 // ObserveConnectedBootstrapCount triggers an observation process of the
-// connected_bootstrap_count metric.
+// connected_bootstrap_count metric. The list of bootstrap peers is obtained
+// from the given function on every observation, so that it can change while
+// the observation runs.
 func ObserveConnectedBootstrapCount(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry Registry,
 	netProvider net.Provider,
-	bootstraps []string,
+	bootstraps func() []string,
 	tick time.Duration,
-) {
+) *Observation {
 	input := func() float64 {
 		currentCount := 0
 
-		for _, address := range bootstraps {
+		for _, address := range bootstraps() {
 			if netProvider.ConnectionManager().IsConnected(address) {
 				currentCount++
 			}
@@ -79,12 +157,13 @@ func ObserveConnectedBootstrapCount(
 		return float64(currentCount)
 	}
 
-	observe(
+	return observe(
 		ctx,
 		"connected_bootstrap_count",
 		input,
 		registry,
-		validateTick(tick, DefaultNetworkMetricsTick),
+		tick,
+		DefaultNetworkMetricsTick,
 	)
 }
 
@@ -92,11 +171,11 @@ func ObserveConnectedBootstrapCount(
 // eth_connectivity metric.
 func ObserveEthConnectivity(
 	ctx context.Context,
-	registry *metrics.Registry,
+	registry Registry,
 	stakeMonitor chain.StakeMonitor,
 	address string,
 	tick time.Duration,
-) {
+) *Observation {
 	input := func() float64 {
 		_, err := stakeMonitor.HasMinimumStake(address)
 
@@ -107,29 +186,273 @@ func ObserveEthConnectivity(
 		return 1
 	}
 
-	observe(
+	return observe(
 		ctx,
 		"eth_connectivity",
 		input,
 		registry,
-		validateTick(tick, DefaultEthereumMetricsTick),
+		tick,
+		DefaultEthereumMetricsTick,
+	)
+}
+
+// RoutingSource provides statistics of a node routing the network traffic
+// of other peers, such as a bootstrap node.
+type RoutingSource interface {
+	// RoutingTableSize returns the number of peers in the routing table.
+	RoutingTableSize() int
+	// ForwardedMessagesCount returns the number of messages forwarded in
+	// topics the node is not subscribed to.
+	ForwardedMessagesCount() uint64
+}
+
+// ObserveRoutingTableSize triggers an observation process of the
+// routing_table_size metric.
+func ObserveRoutingTableSize(
+	ctx context.Context,
+	registry Registry,
+	source RoutingSource,
+	tick time.Duration,
+) *Observation {
+	input := func() float64 {
+		return float64(source.RoutingTableSize())
+	}
+
+	return observe(
+		ctx,
+		"routing_table_size",
+		input,
+		registry,
+		tick,
+		DefaultNetworkMetricsTick,
+	)
+}
+
+// ObserveForwardedMessagesCount triggers an observation process of the
+// forwarded_messages_count metric. The metric is the total number of
+// messages forwarded since the node was started.
+func ObserveForwardedMessagesCount(
+	ctx context.Context,
+	registry Registry,
+	source RoutingSource,
+	tick time.Duration,
+) *Observation {
+	input := func() float64 {
+		return float64(source.ForwardedMessagesCount())
+	}
+
+	return observe(
+		ctx,
+		"forwarded_messages_count",
+		input,
+		registry,
+		tick,
+		DefaultNetworkMetricsTick,
+	)
+}
+
+// ReliabilitySource provides aggregated statistics of the behaviour of other
+// operators observed by the node in the protocol sessions.
+type ReliabilitySource interface {
+	// ObservedOperatorsCount returns the number of observed operators.
+	ObservedOperatorsCount() int
+	// UnreliableOperatorsCount returns the number of observed operators
+	// which are considered unreliable.
+	UnreliableOperatorsCount() int
+	// LowestShareDeliveryRate returns the lowest signature share delivery
+	// rate among the observed operators.
+	LowestShareDeliveryRate() float64
+	// Statistics returns the statistics of each of the observed operators.
+	Statistics() []*reliability.OperatorStatistics
+}
+
+// peerOperatorMetrics are the metrics observed for each of the operators
+// returned by ReliabilitySource.
+var peerOperatorMetrics = []struct {
+	name  string
+	value func(statistics *reliability.OperatorStatistics) float64
+}{
+	{
+		"peer_operator_share_delivery_rate",
+		func(statistics *reliability.OperatorStatistics) float64 {
+			return statistics.ShareDeliveryRate
+		},
+	},
+	{
+		"peer_operator_average_share_latency_blocks",
+		func(statistics *reliability.OperatorStatistics) float64 {
+			return statistics.AverageShareLatencyBlocks
+		},
+	},
+	{
+		"peer_operator_dkg_inactive_members",
+		func(statistics *reliability.OperatorStatistics) float64 {
+			return float64(statistics.DKGInactiveMembers)
+		},
+	},
+	{
+		"peer_operator_dkg_disqualified_members",
+		func(statistics *reliability.OperatorStatistics) float64 {
+			return float64(statistics.DKGDisqualifiedMembers)
+		},
+	},
+	{
+		"peer_operator_unreliable",
+		func(statistics *reliability.OperatorStatistics) float64 {
+			if statistics.Unreliable {
+				return 1
+			}
+			return 0
+		},
+	},
+}
+
+// ObservePeerOperatorsReliability triggers an observation process of the
+// peer_operator_* metrics. The metrics are labelled with the address of the
+// peer operator in the peer_operator label. Gauges of an operator are
+// registered once the operator is observed for the first time.
+func ObservePeerOperatorsReliability(
+	ctx context.Context,
+	registry Registry,
+	source ReliabilitySource,
+	tick time.Duration,
+) *Observation {
+	var mutex sync.Mutex
+	// gauges maps peer operator addresses to their gauges, in the order of
+	// peerOperatorMetrics.
+	gauges := make(map[string][]metrics.ObserverOutput)
+
+	update := func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for _, statistics := range source.Statistics() {
+			operatorGauges, ok := gauges[statistics.Address]
+			if !ok {
+				operatorRegistry := registry.WithLabel(
+					"peer_operator",
+					statistics.Address,
+				)
+
+				operatorGauges = make(
+					[]metrics.ObserverOutput,
+					len(peerOperatorMetrics),
+				)
+				for i, metric := range peerOperatorMetrics {
+					gauge, err := operatorRegistry.NewGauge(metric.name)
+					if err != nil {
+						logger.Warningf(
+							"could not create gauge [%v]: [%v]",
+							metric.name,
+							err,
+						)
+						continue
+					}
+					operatorGauges[i] = gauge
+				}
+
+				gauges[statistics.Address] = operatorGauges
+			}
+
+			for i, metric := range peerOperatorMetrics {
+				if operatorGauges[i] != nil {
+					operatorGauges[i].Set(metric.value(statistics))
+				}
+			}
+		}
+	}
+
+	return newObservation(ctx, update, tick, DefaultNetworkMetricsTick)
+}
+
+// ObserveObservedOperatorsCount triggers an observation process of the
+// observed_operators_count metric.
+func ObserveObservedOperatorsCount(
+	ctx context.Context,
+	registry Registry,
+	source ReliabilitySource,
+	tick time.Duration,
+) *Observation {
+	input := func() float64 {
+		return float64(source.ObservedOperatorsCount())
+	}
+
+	return observe(
+		ctx,
+		"observed_operators_count",
+		input,
+		registry,
+		tick,
+		DefaultNetworkMetricsTick,
 	)
 }
 
+// ObserveUnreliableOperatorsCount triggers an observation process of the
+// unreliable_operators_count metric.
+func ObserveUnreliableOperatorsCount(
+	ctx context.Context,
+	registry Registry,
+	source ReliabilitySource,
+	tick time.Duration,
+) *Observation {
+	input := func() float64 {
+		return float64(source.UnreliableOperatorsCount())
+	}
+
+	return observe(
+		ctx,
+		"unreliable_operators_count",
+		input,
+		registry,
+		tick,
+		DefaultNetworkMetricsTick,
+	)
+}
+
+// ObserveLowestShareDeliveryRate triggers an observation process of the
+// lowest_share_delivery_rate metric.
+func ObserveLowestShareDeliveryRate(
+	ctx context.Context,
+	registry Registry,
+	source ReliabilitySource,
+	tick time.Duration,
+) *Observation {
+	input := func() float64 {
+		return source.LowestShareDeliveryRate()
+	}
+
+	return observe(
+		ctx,
+		"lowest_share_delivery_rate",
+		input,
+		registry,
+		tick,
+		DefaultNetworkMetricsTick,
+	)
+}
+
+// observe starts the observation of the metric. It returns nil if the
+// observation could not be started.
 func observe(
 	ctx context.Context,
 	name string,
 	input metrics.ObserverInput,
-	registry *metrics.Registry,
+	registry Registry,
 	tick time.Duration,
-) {
-	observer, err := registry.NewGaugeObserver(name, input)
+	defaultTick time.Duration,
+) *Observation {
+	gauge, err := registry.NewGauge(name)
 	if err != nil {
-		logger.Warningf("could not create gauge observer [%v]", name)
-		return
+		logger.Warningf("could not create gauge [%v]: [%v]", name, err)
+		return nil
 	}
 
-	observer.Observe(ctx, tick)
+	return newObservation(
+		ctx,
+		func() { gauge.Set(input()) },
+		tick,
+		defaultTick,
+	)
 }
 
 func validateTick(tick time.Duration, defaultTick time.Duration) time.Duration {
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/reliability"
+)
+
+func TestObservePeerOperatorsReliability(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	registry := &LabelledRegistry{
+		gauges: make(map[string]map[string]*labelledGauge),
+	}
+
+	source := &reliabilitySource{
+		statistics: []*reliability.OperatorStatistics{
+			{
+				Address:                   "0x02",
+				SigningSessions:           2,
+				ShareDeliveryRate:         0.5,
+				AverageShareLatencyBlocks: 3,
+				DKGInactiveMembers:        1,
+				Unreliable:                true,
+			},
+		},
+	}
+
+	observation := ObservePeerOperatorsReliability(
+		ctx,
+		registry.WithLabel("operator", "0x01"),
+		source,
+		time.Hour,
+	)
+	if observation == nil {
+		t.Fatal("expected observation")
+	}
+
+	expectedLines := []string{
+		"peer_operator_share_delivery_rate" +
+			"{operator=\"0x01\",peer_operator=\"0x02\"} 0.5 ",
+		"peer_operator_average_share_latency_blocks" +
+			"{operator=\"0x01\",peer_operator=\"0x02\"} 3 ",
+		"peer_operator_dkg_inactive_members" +
+			"{operator=\"0x01\",peer_operator=\"0x02\"} 1 ",
+		"peer_operator_dkg_disqualified_members" +
+			"{operator=\"0x01\",peer_operator=\"0x02\"} 0 ",
+		"peer_operator_unreliable" +
+			"{operator=\"0x01\",peer_operator=\"0x02\"} 1 ",
+	}
+
+	// The first observation is executed asynchronously, right after the
+	// observation starts.
+	containsExpectedLines := func(exposed string) bool {
+		for _, line := range expectedLines {
+			if !strings.Contains(exposed, line) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var exposed string
+	for i := 0; i < 100; i++ {
+		exposed = registry.expose()
+		if containsExpectedLines(exposed) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf(
+		"expected lines:\n%v\nin:\n%v",
+		strings.Join(expectedLines, "\n"),
+		exposed,
+	)
+}
+
+type reliabilitySource struct {
+	statistics []*reliability.OperatorStatistics
+}
+
+func (rs *reliabilitySource) ObservedOperatorsCount() int {
+	return len(rs.statistics)
+}
+
+func (rs *reliabilitySource) UnreliableOperatorsCount() int {
+	return 0
+}
+
+func (rs *reliabilitySource) LowestShareDeliveryRate() float64 {
+	return 1
+}
+
+func (rs *reliabilitySource) Statistics() []*reliability.OperatorStatistics {
+	return rs.statistics
+}
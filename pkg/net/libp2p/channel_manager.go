@@ -3,6 +3,7 @@ package libp2p
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/keep-network/keep-core/pkg/net"
@@ -34,6 +35,10 @@ type channelManager struct {
 
 	forwarderSubscriptionsMutex sync.Mutex
 	forwarderSubscriptions      map[string]*pubsub.Subscription
+
+	// forwardedMessages is the number of messages received by forwarders
+	// and relayed to other peers subscribed to the topic.
+	forwardedMessages uint64
 }
 
 func newChannelManager(
@@ -143,7 +148,9 @@ func (cm *channelManager) newForwarder(name string, ttl time.Duration) error {
 					// Just pull the message from subscription to unblock
 					// the channel and avoid warnings from libp2p. We
 					// are not interested with their content.
-					_, _ = forwarderSubscription.Next(ctx)
+					if _, err := forwarderSubscription.Next(ctx); err == nil {
+						atomic.AddUint64(&cm.forwardedMessages, 1)
+					}
 				}
 			}
 		}()
@@ -154,6 +161,12 @@ func (cm *channelManager) newForwarder(name string, ttl time.Duration) error {
 	return nil
 }
 
+// forwardedMessagesCount returns the number of messages received by
+// forwarders since the channel manager was created.
+func (cm *channelManager) forwardedMessagesCount() uint64 {
+	return atomic.LoadUint64(&cm.forwardedMessages)
+}
+
 func (cm *channelManager) shutdownForwarder(name string) {
 	cm.forwarderSubscriptionsMutex.Lock()
 	defer cm.forwarderSubscriptionsMutex.Unlock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	addrutil "github.com/libp2p/go-addr-util"
 	libp2p "github.com/libp2p/go-libp2p"
 	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	host "github.com/libp2p/go-libp2p-core/host"
 	libp2pnet "github.com/libp2p/go-libp2p-core/network"
 	peer "github.com/libp2p/go-libp2p-core/peer"
@@ -84,10 +86,16 @@ type provider struct {
 	broadcastChannelManager *channelManager
 	unicastChannelManager   *unicastChannelManager
 
-	identity          *identity
-	host              host.Host
-	routing           *dht.IpfsDHT
-	disseminationTime int
+	identity *identity
+	host     host.Host
+	routing  *dht.IpfsDHT
+
+	// reconfigurationMutex guards the values which can be changed while
+	// the provider runs.
+	reconfigurationMutex sync.Mutex
+	bootstrapper         io.Closer
+	bootstrapPeers       []string
+	disseminationTime    int
 
 	connectionManager *connectionManager
 }
@@ -131,12 +139,16 @@ func (p *provider) CreateTransportIdentifier(publicKey ecdsa.PublicKey) (
 }
 
 func (p *provider) BroadcastChannelForwarderFor(name string) {
-	if p.disseminationTime == 0 {
+	p.reconfigurationMutex.Lock()
+	disseminationTime := p.disseminationTime
+	p.reconfigurationMutex.Unlock()
+
+	if disseminationTime == 0 {
 		return
 	}
 
 	logger.Infof("starting message forwarder for channel [%v]", name)
-	timeout := time.Duration(p.disseminationTime) * time.Second
+	timeout := time.Duration(disseminationTime) * time.Second
 
 	if err := p.broadcastChannelManager.newForwarder(name, timeout); err != nil {
 		logger.Warningf(
@@ -147,6 +159,108 @@ func (p *provider) BroadcastChannelForwarderFor(name string) {
 	}
 }
 
+// RoutingTableSize returns the number of peers in the DHT routing table of
+// the provider.
+func (p *provider) RoutingTableSize() int {
+	return p.routing.RoutingTable().Size()
+}
+
+// ForwardedMessagesCount returns the number of messages forwarded in topics
+// we are not subscribed to since the provider was started.
+func (p *provider) ForwardedMessagesCount() uint64 {
+	return p.broadcastChannelManager.forwardedMessagesCount()
+}
+
+// SetDisseminationTime changes the dissemination time of messages in topics
+// we are not subscribed to. The new value applies to message forwarders
+// started from now on; already running forwarders keep their timeout.
+func (p *provider) SetDisseminationTime(disseminationTime int) error {
+	if err := validateDisseminationTime(disseminationTime); err != nil {
+		return err
+	}
+
+	p.reconfigurationMutex.Lock()
+	defer p.reconfigurationMutex.Unlock()
+
+	p.disseminationTime = disseminationTime
+
+	return nil
+}
+
+// SetBootstrapPeers replaces the list of bootstrap peers. Peers which were
+// not on the previous list are dialled immediately; all the peers on the new
+// list are used in the subsequent bootstrap rounds.
+func (p *provider) SetBootstrapPeers(ctx context.Context, peers []string) error {
+	peerInfos, err := extractMultiAddrFromPeers(peers)
+	if err != nil {
+		return err
+	}
+
+	p.reconfigurationMutex.Lock()
+	defer p.reconfigurationMutex.Unlock()
+
+	previousPeers := make(map[peer.ID]bool)
+	previousPeerInfos, err := extractMultiAddrFromPeers(p.bootstrapPeers)
+	if err != nil {
+		return err
+	}
+	for _, peerInfo := range previousPeerInfos {
+		previousPeers[peerInfo.ID] = true
+	}
+
+	previousBootstrapper := p.bootstrapper
+	if err := p.bootstrap(ctx, peers); err != nil {
+		return fmt.Errorf("bootstrap failed: [%v]", err)
+	}
+
+	if previousBootstrapper != nil {
+		if err := previousBootstrapper.Close(); err != nil {
+			logger.Warningf(
+				"could not close the previous bootstrapper: [%v]",
+				err,
+			)
+		}
+	}
+
+	for _, peerInfo := range peerInfos {
+		if previousPeers[peerInfo.ID] {
+			continue
+		}
+
+		go func(peerInfo peerstore.PeerInfo) {
+			if err := p.host.Connect(ctx, peerInfo); err != nil {
+				logger.Warningf(
+					"could not connect to bootstrap peer [%v]: [%v]",
+					peerInfo.ID,
+					err,
+				)
+				return
+			}
+
+			logger.Infof("connected to bootstrap peer [%v]", peerInfo.ID)
+		}(peerInfo)
+	}
+
+	return nil
+}
+
+func (p *provider) Close() error {
+	p.reconfigurationMutex.Lock()
+	defer p.reconfigurationMutex.Unlock()
+
+	if p.bootstrapper != nil {
+		if err := p.bootstrapper.Close(); err != nil {
+			logger.Warningf("could not close the bootstrapper: [%v]", err)
+		}
+	}
+
+	if err := p.routing.Close(); err != nil {
+		logger.Warningf("could not close the routing table: [%v]", err)
+	}
+
+	return p.host.Close()
+}
+
 type connectionManager struct {
 	host.Host
 }
@@ -276,22 +390,23 @@ func WithRoutingTableRefreshPeriod(period time.Duration) ConnectOption {
 // connection is managed in part by the passed context, and provides access to
 // the functionality specified in the net.Provider interface.
 //
+// The static key is the network identity of the operator; usually a
+// key.NetworkPrivate but it can be any secp256k1 key, for example one signing
+// with an external signer.
+//
 // An error is returned if any part of the connection or bootstrap process
 // fails.
 func Connect(
 	ctx context.Context,
 	config Config,
-	staticKey *key.NetworkPrivate,
+	staticKey libp2pcrypto.PrivKey,
 	protocol string,
 	firewall net.Firewall,
 	ticker *retransmission.Ticker,
 	options ...ConnectOption,
 ) (net.Provider, error) {
-	if config.DisseminationTime < 0 || config.DisseminationTime > MaximumDisseminationTime {
-		return nil, fmt.Errorf(
-			"dissemination time mut be in range [0, %v]",
-			MaximumDisseminationTime,
-		)
+	if err := validateDisseminationTime(config.DisseminationTime); err != nil {
+		return nil, err
 	}
 
 	connectOptions := defaultConnectOptions()
@@ -321,8 +436,6 @@ func Connect(
 		return nil, err
 	}
 
-	unicastChannelManager := newUnicastChannelManager(ctx, identity, host)
-
 	dhtDatastore := dssync.MutexWrap(dstore.NewMapDatastore())
 	router, err := dht.New(
 		ctx,
@@ -337,11 +450,16 @@ func Connect(
 		return nil, err
 	}
 
+	// Unicast channels are opened using the routed host so that a peer
+	// known only by its ID can be found through the DHT.
+	routedHost := rhost.Wrap(host, router)
+	unicastChannelManager := newUnicastChannelManager(ctx, identity, routedHost)
+
 	provider := &provider{
 		broadcastChannelManager: broadcastChannelManager,
 		unicastChannelManager:   unicastChannelManager,
 		identity:                identity,
-		host:                    rhost.Wrap(host, router),
+		host:                    routedHost,
 		routing:                 router,
 		disseminationTime:       config.DisseminationTime,
 	}
@@ -367,6 +485,17 @@ func Connect(
 	return provider, nil
 }
 
+func validateDisseminationTime(disseminationTime int) error {
+	if disseminationTime < 0 || disseminationTime > MaximumDisseminationTime {
+		return fmt.Errorf(
+			"dissemination time mut be in range [0, %v]",
+			MaximumDisseminationTime,
+		)
+	}
+
+	return nil
+}
+
 func discoverAndListen(
 	ctx context.Context,
 	identity *identity,
@@ -468,15 +597,20 @@ func (p *provider) bootstrap(
 
 	bootstrapConfig := bootstrap.BootstrapConfigWithPeers(peerInfos)
 
-	// TODO: use the io.Closer to shutdown the bootstrapper when we build out
-	// a shutdown process.
-	_, err = bootstrap.Bootstrap(
+	bootstrapper, err := bootstrap.Bootstrap(
 		p.identity.id,
 		p.host,
 		p.routing,
 		bootstrapConfig,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	p.bootstrapper = bootstrapper
+	p.bootstrapPeers = bootstrapPeers
+
+	return nil
 }
 
 func extractMultiAddrFromPeers(peers []string) ([]peerstore.PeerInfo, error) {
@@ -195,6 +195,91 @@ func TestProviderSetAnnouncedAddresses(t *testing.T) {
 	}
 }
 
+func TestProviderForwardsMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	forwarderKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forwarderConfig := Config{Port: 8081, DisseminationTime: 10}
+	forwarder, err := Connect(
+		ctx,
+		forwarderConfig,
+		forwarderKey,
+		ProtocolBeacon,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	senderKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	senderIdentity, err := createIdentity(senderKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := Connect(
+		ctx,
+		Config{
+			Port:  8082,
+			Peers: []string{fmt.Sprintf("/ip4/127.0.0.1/tcp/8081/ipfs/%v", forwarder.ID())},
+		},
+		senderKey,
+		ProtocolBeacon,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "forwardedchannel"
+	forwarder.BroadcastChannelForwarderFor(name)
+
+	broadcastChannel, err := sender.BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broadcastChannel.SetUnmarshaler(
+		func() net.TaggedUnmarshaler { return &testMessage{} },
+	)
+
+	statistics := forwarder.(*provider)
+
+	// The sender learns about the forwarder subscription asynchronously so
+	// the message is sent until it is forwarded.
+	for statistics.ForwardedMessagesCount() == 0 {
+		if err := broadcastChannel.Send(
+			ctx,
+			&testMessage{Sender: senderIdentity, Payload: "forwarded"},
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("message has not been forwarded")
+		}
+	}
+
+	if statistics.RoutingTableSize() != 1 {
+		t.Fatalf(
+			"expected: routing table size [1]\nactual:   routing table size [%v]",
+			statistics.RoutingTableSize(),
+		)
+	}
+}
+
 type testMessage struct {
 	Sender    *identity
 	Recipient *identity
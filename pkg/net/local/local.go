@@ -73,6 +73,11 @@ func (lp *localProvider) BroadcastChannelForwarderFor(name string) {
 	//no-op
 }
 
+func (lp *localProvider) Close() error {
+	//no-op
+	return nil
+}
+
 // Connect returns a local instance of a net provider that does not go over the
 // network.
 func Connect() Provider {
@@ -65,6 +65,10 @@ type Provider interface {
 
 	// BroadcastChannelForwarderFor creates a message relay for given channel name.
 	BroadcastChannelForwarderFor(name string)
+
+	// Close shuts the provider down and closes all its connections. The
+	// provider must not be used after it has been closed.
+	Close() error
 }
 
 // ConnectionManager is an interface which exposes peers a client is connected
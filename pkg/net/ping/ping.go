@@ -0,0 +1,268 @@
+// Package ping implements a latency probe between two network peers. Probes
+// are sent over a unicast channel so they go through the same handshake and
+// firewall rules as the regular protocol messages exchanged by group members.
+package ping
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+var logger = log.Logger("keep-net-ping")
+
+const (
+	pingMessageType = "net/ping"
+	pongMessageType = "net/pong"
+)
+
+// Respond makes the provider answer probes sent by remote peers over every
+// unicast channel opened against it, for the entire lifetime of the provided
+// context.
+func Respond(ctx context.Context, provider net.Provider) {
+	provider.OnUnicastChannelOpened(func(channel net.UnicastChannel) {
+		channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &pingMessage{}
+		})
+
+		channel.Recv(ctx, func(message net.Message) {
+			ping, ok := message.Payload().(*pingMessage)
+			if !ok {
+				return
+			}
+
+			err := channel.Send(&pongMessage{sequence: ping.sequence})
+			if err != nil {
+				logger.Warningf(
+					"could not respond to probe from [%v]: [%v]",
+					message.TransportSenderID(),
+					err,
+				)
+			}
+		})
+	})
+}
+
+// Result holds the outcome of probing a remote peer.
+type Result struct {
+	Sent           int
+	Received       int
+	RoundTripTimes []time.Duration
+}
+
+// Loss returns the fraction of probes which were not answered in time.
+func (r *Result) Loss() float64 {
+	if r.Sent == 0 {
+		return 0
+	}
+
+	return float64(r.Sent-r.Received) / float64(r.Sent)
+}
+
+// Min returns the shortest round-trip time or zero if no probe was answered.
+func (r *Result) Min() time.Duration {
+	return r.Percentile(0)
+}
+
+// Average returns the mean round-trip time or zero if no probe was answered.
+func (r *Result) Average() time.Duration {
+	if len(r.RoundTripTimes) == 0 {
+		return 0
+	}
+
+	var sum time.Duration
+	for _, rtt := range r.RoundTripTimes {
+		sum += rtt
+	}
+
+	return sum / time.Duration(len(r.RoundTripTimes))
+}
+
+// Percentile returns the round-trip time below or at which the given
+// percentage of answered probes fall, using the nearest-rank method. It
+// returns zero if no probe was answered.
+func (r *Result) Percentile(percentage float64) time.Duration {
+	if len(r.RoundTripTimes) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(r.RoundTripTimes))
+	copy(sorted, r.RoundTripTimes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(percentage / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+
+	return sorted[rank-1]
+}
+
+// Probe opens a unicast channel with the remote peer and sends it count probes,
+// one every interval. A probe not answered within the timeout is considered
+// lost. The remote peer has to answer probes with Respond. The onProbe
+// handler, if not nil, is called with the sequence number of every probe and
+// its round-trip time or with the error explaining why the probe was lost.
+func Probe(
+	ctx context.Context,
+	provider net.Provider,
+	peerID net.TransportIdentifier,
+	count int,
+	interval time.Duration,
+	timeout time.Duration,
+	onProbe func(sequence uint64, rtt time.Duration, err error),
+) (*Result, error) {
+	channel, err := provider.UnicastChannelWith(peerID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not open unicast channel with [%v]: [%v]",
+			peerID,
+			err,
+		)
+	}
+
+	pongs := make(chan uint64, count)
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &pongMessage{}
+	})
+	channel.Recv(ctx, func(message net.Message) {
+		if pong, ok := message.Payload().(*pongMessage); ok {
+			select {
+			case pongs <- pong.sequence:
+			default:
+			}
+		}
+	})
+
+	if onProbe == nil {
+		onProbe = func(uint64, time.Duration, error) {}
+	}
+
+	result := &Result{}
+	for sequence := uint64(1); sequence <= uint64(count); sequence++ {
+		if sequence > 1 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return result, ctx.Err()
+			}
+		}
+
+		result.Sent++
+		rtt, err := probeOnce(ctx, channel, pongs, sequence, timeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			onProbe(sequence, 0, err)
+			continue
+		}
+
+		result.Received++
+		result.RoundTripTimes = append(result.RoundTripTimes, rtt)
+		onProbe(sequence, rtt, nil)
+	}
+
+	return result, nil
+}
+
+func probeOnce(
+	ctx context.Context,
+	channel net.UnicastChannel,
+	pongs <-chan uint64,
+	sequence uint64,
+	timeout time.Duration,
+) (time.Duration, error) {
+	timeoutTimer := time.NewTimer(timeout)
+	defer timeoutTimer.Stop()
+
+	sentAt := time.Now()
+	if err := channel.Send(&pingMessage{sequence: sequence}); err != nil {
+		return 0, fmt.Errorf("could not send probe: [%v]", err)
+	}
+
+	for {
+		select {
+		case received := <-pongs:
+			// Answers to probes which already timed out are ignored.
+			if received == sequence {
+				return time.Since(sentAt), nil
+			}
+		case <-timeoutTimer.C:
+			return 0, fmt.Errorf("no answer within [%v]", timeout)
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+type pingMessage struct {
+	sequence uint64
+}
+
+func (pm *pingMessage) Type() string {
+	return pingMessageType
+}
+
+func (pm *pingMessage) Marshal() ([]byte, error) {
+	return marshalSequence(pm.sequence), nil
+}
+
+func (pm *pingMessage) Unmarshal(bytes []byte) error {
+	sequence, err := unmarshalSequence(bytes)
+	if err != nil {
+		return err
+	}
+
+	pm.sequence = sequence
+	return nil
+}
+
+type pongMessage struct {
+	sequence uint64
+}
+
+func (pm *pongMessage) Type() string {
+	return pongMessageType
+}
+
+func (pm *pongMessage) Marshal() ([]byte, error) {
+	return marshalSequence(pm.sequence), nil
+}
+
+func (pm *pongMessage) Unmarshal(bytes []byte) error {
+	sequence, err := unmarshalSequence(bytes)
+	if err != nil {
+		return err
+	}
+
+	pm.sequence = sequence
+	return nil
+}
+
+func marshalSequence(sequence uint64) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, sequence)
+	return bytes
+}
+
+func unmarshalSequence(bytes []byte) (uint64, error) {
+	if len(bytes) != 8 {
+		return 0, fmt.Errorf(
+			"unexpected probe message length [%v]",
+			len(bytes),
+		)
+	}
+
+	return binary.BigEndian.Uint64(bytes), nil
+}
@@ -0,0 +1,168 @@
+package ping
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestProbe(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	provider, _ := initTestProvider()
+	remoteProvider, remotePeerID := initTestProvider()
+	Respond(ctx, remoteProvider)
+
+	// The local provider notifies about opened channels asynchronously so
+	// the channel is opened and given a moment to be set up before probing.
+	if _, err := provider.UnicastChannelWith(remotePeerID); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	var probed []uint64
+	result, err := Probe(
+		ctx,
+		provider,
+		remotePeerID,
+		5,
+		time.Millisecond,
+		time.Second,
+		func(sequence uint64, rtt time.Duration, err error) {
+			if err != nil {
+				t.Errorf("probe [%v] failed: [%v]", sequence, err)
+			}
+			probed = append(probed, sequence)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Sent != 5 {
+		t.Errorf("unexpected number of sent probes\nexpected: [5]\nactual:   [%v]", result.Sent)
+	}
+	if result.Received != 5 {
+		t.Errorf("unexpected number of answered probes\nexpected: [5]\nactual:   [%v]", result.Received)
+	}
+	if result.Loss() != 0 {
+		t.Errorf("unexpected loss\nexpected: [0]\nactual:   [%v]", result.Loss())
+	}
+	if len(probed) != 5 || probed[0] != 1 || probed[4] != 5 {
+		t.Errorf("unexpected probe notifications [%v]", probed)
+	}
+}
+
+func TestProbeNotResponding(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	provider, _ := initTestProvider()
+	_, remotePeerID := initTestProvider()
+
+	result, err := Probe(
+		ctx,
+		provider,
+		remotePeerID,
+		3,
+		time.Millisecond,
+		10*time.Millisecond,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Sent != 3 {
+		t.Errorf("unexpected number of sent probes\nexpected: [3]\nactual:   [%v]", result.Sent)
+	}
+	if result.Received != 0 {
+		t.Errorf("unexpected number of answered probes\nexpected: [0]\nactual:   [%v]", result.Received)
+	}
+	if result.Loss() != 1 {
+		t.Errorf("unexpected loss\nexpected: [1]\nactual:   [%v]", result.Loss())
+	}
+}
+
+func TestResultStatistics(t *testing.T) {
+	var tests = map[string]struct {
+		result          *Result
+		expectedLoss    float64
+		expectedMin     time.Duration
+		expectedAverage time.Duration
+		expectedP99     time.Duration
+	}{
+		"no probes": {
+			result: &Result{},
+		},
+		"all probes lost": {
+			result:       &Result{Sent: 4},
+			expectedLoss: 1,
+		},
+		"some probes lost": {
+			result: &Result{
+				Sent:     4,
+				Received: 3,
+				RoundTripTimes: []time.Duration{
+					30 * time.Millisecond,
+					10 * time.Millisecond,
+					20 * time.Millisecond,
+				},
+			},
+			expectedLoss:    0.25,
+			expectedMin:     10 * time.Millisecond,
+			expectedAverage: 20 * time.Millisecond,
+			expectedP99:     30 * time.Millisecond,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if loss := test.result.Loss(); loss != test.expectedLoss {
+				t.Errorf("unexpected loss\nexpected: [%v]\nactual:   [%v]", test.expectedLoss, loss)
+			}
+			if min := test.result.Min(); min != test.expectedMin {
+				t.Errorf("unexpected min\nexpected: [%v]\nactual:   [%v]", test.expectedMin, min)
+			}
+			if average := test.result.Average(); average != test.expectedAverage {
+				t.Errorf("unexpected average\nexpected: [%v]\nactual:   [%v]", test.expectedAverage, average)
+			}
+			if p99 := test.result.Percentile(99); p99 != test.expectedP99 {
+				t.Errorf("unexpected p99\nexpected: [%v]\nactual:   [%v]", test.expectedP99, p99)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	result := &Result{}
+	for i := 100; i >= 1; i-- {
+		result.RoundTripTimes = append(
+			result.RoundTripTimes,
+			time.Duration(i)*time.Millisecond,
+		)
+	}
+
+	if p50 := result.Percentile(50); p50 != 50*time.Millisecond {
+		t.Errorf("unexpected p50\nexpected: [50ms]\nactual:   [%v]", p50)
+	}
+	if p99 := result.Percentile(99); p99 != 99*time.Millisecond {
+		t.Errorf("unexpected p99\nexpected: [99ms]\nactual:   [%v]", p99)
+	}
+}
+
+func initTestProvider() (net.Provider, net.TransportIdentifier) {
+	_, staticKey, _ := key.GenerateStaticNetworkKey()
+	provider := local.ConnectWithKey(staticKey)
+
+	peerID, _ := provider.CreateTransportIdentifier(
+		*key.NetworkKeyToECDSAKey(staticKey),
+	)
+
+	return provider, peerID
+}
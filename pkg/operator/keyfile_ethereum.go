@@ -0,0 +1,113 @@
+//+build !celo
+
+package operator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Scrypt parameters used to encrypt key files. They are variables only so
+// that tests can use lighter parameters.
+var (
+	keyFileScryptN = keystore.StandardScryptN
+	keyFileScryptP = keystore.StandardScryptP
+)
+
+// GenerateKeyFile generates a new operator key and stores it in a new key
+// file in the given directory, encrypted with the given password. It returns
+// the path of the created key file.
+func GenerateKeyFile(directory, password string) (string, error) {
+	account, err := keystore.StoreKey(
+		directory,
+		password,
+		keyFileScryptN,
+		keyFileScryptP,
+	)
+	if err != nil {
+		return "", fmt.Errorf("could not store key: [%v]", err)
+	}
+
+	return account.URL.Path, nil
+}
+
+// ReadKeyFile decrypts the key file with the given password and returns the
+// operator key stored in it.
+func ReadKeyFile(path, password string) (*PrivateKey, *PublicKey, error) {
+	key, err := decryptKeyFile(path, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, publicKey := ChainKeyToOperatorKey(key)
+	return privateKey, publicKey, nil
+}
+
+// ChangeKeyFilePassword re-encrypts the key file with the new password. The
+// key file is replaced atomically so it is never left partially written.
+func ChangeKeyFilePassword(path, password, newPassword string) error {
+	key, err := decryptKeyFile(path, password)
+	if err != nil {
+		return err
+	}
+
+	keyJSON, err := keystore.EncryptKey(
+		key,
+		newPassword,
+		keyFileScryptN,
+		keyFileScryptP,
+	)
+	if err != nil {
+		return fmt.Errorf("could not encrypt key: [%v]", err)
+	}
+
+	return replaceKeyFile(path, keyJSON)
+}
+
+// CompressPublicKey returns the operator's public key in the 33-byte
+// compressed format.
+func CompressPublicKey(publicKey *PublicKey) []byte {
+	return crypto.CompressPubkey(publicKey)
+}
+
+func decryptKeyFile(path, password string) (*keystore.Key, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key file: [%v]", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt key file: [%v]", err)
+	}
+
+	return key, nil
+}
+
+func replaceKeyFile(path string, keyJSON []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return fmt.Errorf("could not create temporary key file: [%v]", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(keyJSON); err != nil {
+		file.Close()
+		return fmt.Errorf("could not write temporary key file: [%v]", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not close temporary key file: [%v]", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("could not replace key file: [%v]", err)
+	}
+
+	return nil
+}
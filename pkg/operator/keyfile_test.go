@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestKeyFile(t *testing.T) {
+	// Use the lightest possible parameters to keep the test fast.
+	keyFileScryptN = 2
+	keyFileScryptP = 1
+
+	directory, err := ioutil.TempDir("", "operator-key-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	path, err := GenerateKeyFile(directory, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, _, err := ReadKeyFile(path, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ReadKeyFile(path, "other-password"); err == nil {
+		t.Fatal("expected decryption with invalid password to fail")
+	}
+
+	if err := ChangeKeyFilePassword(path, "password", "new-password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ReadKeyFile(path, "password"); err == nil {
+		t.Fatal("expected decryption with the old password to fail")
+	}
+
+	reencryptedPrivateKey, _, err := ReadKeyFile(path, "new-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(privateKey.D, reencryptedPrivateKey.D) {
+		t.Fatal("key changed after re-encryption")
+	}
+
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the key file in the directory; has: [%v]", len(files))
+	}
+}
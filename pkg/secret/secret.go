@@ -0,0 +1,166 @@
+// Package secret contains providers of secrets, such as passwords, kept
+// outside of the process environment: in files, in external programs and in
+// HTTP secret stores.
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"strings"
+)
+
+// Provider provides a secret.
+type Provider interface {
+	// Secret returns the secret or an error if it could not be obtained.
+	Secret(ctx context.Context) (string, error)
+	// String describes the source of the secret without revealing it.
+	String() string
+}
+
+// File provides the secret stored in a file, such as a Docker or Kubernetes
+// secret mount. Trailing line breaks are not part of the secret.
+type File struct {
+	Path string
+}
+
+// Secret reads the secret from the file.
+func (f *File) Secret(ctx context.Context) (string, error) {
+	content, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("could not read secret file: [%v]", err)
+	}
+
+	return trimLineBreaks(string(content)), nil
+}
+
+func (f *File) String() string {
+	return fmt.Sprintf("file [%v]", f.Path)
+}
+
+// Command provides the secret printed to the standard output by an external
+// command, such as a password manager. Trailing line breaks are not part of
+// the secret.
+type Command struct {
+	Name string
+	Args []string
+}
+
+// Secret runs the command and returns its output.
+func (c *Command) Secret(ctx context.Context) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	// #nosec G204 (subprocess launched with variable)
+	// The command is explicitly configured by the operator.
+	command := exec.CommandContext(ctx, c.Name, c.Args...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		return "", fmt.Errorf(
+			"secret command failed: [%v]; stderr: [%v]",
+			err,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	return trimLineBreaks(stdout.String()), nil
+}
+
+func (c *Command) String() string {
+	return fmt.Sprintf("command [%v]", c.Name)
+}
+
+// HTTP provides the secret served by an HTTP secret store. The request is
+// authenticated with the bearer token read from the token provider, if set.
+// If the field is set, the response is expected to be a JSON object and the
+// secret is the string value under the given dot-separated path, for example
+// "data.data.password". Otherwise, the whole response body without trailing
+// line breaks is the secret.
+type HTTP struct {
+	URL    string
+	Token  Provider
+	Field  string
+	Client *http.Client
+}
+
+// Secret fetches the secret from the secret store.
+func (h *HTTP) Secret(ctx context.Context) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not create secret request: [%v]", err)
+	}
+
+	if h.Token != nil {
+		token, err := h.Token.Secret(ctx)
+		if err != nil {
+			return "", fmt.Errorf("could not get secret store token: [%v]", err)
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("secret request failed: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not read secret response: [%v]", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf(
+			"secret store responded with status [%v]",
+			response.Status,
+		)
+	}
+
+	if h.Field == "" {
+		return trimLineBreaks(string(body)), nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "", fmt.Errorf("could not parse secret response: [%v]", err)
+	}
+
+	for _, key := range strings.Split(h.Field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf(
+				"secret response has no field [%v]",
+				h.Field,
+			)
+		}
+		value = object[key]
+	}
+
+	secret, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf(
+			"field [%v] of secret response is not a string",
+			h.Field,
+		)
+	}
+
+	return secret, nil
+}
+
+func (h *HTTP) String() string {
+	return fmt.Sprintf("secret store [%v]", h.URL)
+}
+
+func trimLineBreaks(value string) string {
+	return strings.TrimRight(value, "\r\n")
+}
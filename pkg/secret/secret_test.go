@@ -0,0 +1,163 @@
+package secret
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	directory, err := ioutil.TempDir("", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "password")
+	if err := ioutil.WriteFile(path, []byte("p4ssw0rd \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &File{Path: path}
+
+	secret, err := provider.Secret(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSecret(t, "p4ssw0rd ", secret)
+
+	provider.Path = filepath.Join(directory, "missing")
+	if _, err := provider.Secret(context.Background()); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestCommand(t *testing.T) {
+	provider := &Command{Name: "sh", Args: []string{"-c", "echo p4ssw0rd"}}
+
+	secret, err := provider.Secret(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSecret(t, "p4ssw0rd", secret)
+}
+
+func TestCommandFailure(t *testing.T) {
+	provider := &Command{
+		Name: "sh",
+		Args: []string{"-c", "echo locked >&2; exit 1"},
+	}
+
+	_, err := provider.Secret(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	expectedError := "secret command failed: [exit status 1]; stderr: [locked]"
+	if err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			if request.Header.Get("Authorization") != "Bearer t0ken" {
+				writer.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			switch request.URL.Path {
+			case "/plain":
+				writer.Write([]byte("p4ssw0rd\n"))
+			case "/json":
+				writer.Write([]byte(`{"data":{"data":{"password":"p4ssw0rd"}}}`))
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		},
+	))
+	defer server.Close()
+
+	token := &Command{Name: "echo", Args: []string{"t0ken"}}
+
+	var tests = map[string]struct {
+		provider       *HTTP
+		expectedSecret string
+		expectedError  bool
+	}{
+		"plain response": {
+			provider:       &HTTP{URL: server.URL + "/plain", Token: token},
+			expectedSecret: "p4ssw0rd",
+		},
+		"json response": {
+			provider: &HTTP{
+				URL:   server.URL + "/json",
+				Token: token,
+				Field: "data.data.password",
+			},
+			expectedSecret: "p4ssw0rd",
+		},
+		"missing json field": {
+			provider: &HTTP{
+				URL:   server.URL + "/json",
+				Token: token,
+				Field: "data.password",
+			},
+			expectedError: true,
+		},
+		"not a string json field": {
+			provider: &HTTP{
+				URL:   server.URL + "/json",
+				Token: token,
+				Field: "data.data",
+			},
+			expectedError: true,
+		},
+		"missing token": {
+			provider:      &HTTP{URL: server.URL + "/plain"},
+			expectedError: true,
+		},
+		"not found": {
+			provider:      &HTTP{URL: server.URL + "/missing", Token: token},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			secret, err := test.provider.Secret(context.Background())
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertSecret(t, test.expectedSecret, secret)
+		})
+	}
+}
+
+func assertSecret(t *testing.T, expected string, actual string) {
+	if expected != actual {
+		t.Errorf(
+			"unexpected secret\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
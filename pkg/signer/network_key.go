@@ -0,0 +1,95 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	pb "github.com/libp2p/go-libp2p-core/crypto/pb"
+)
+
+// networkSigningTimeout is the maximum time the signer has to sign a network
+// message. The network layer does not pass a context along with messages to
+// sign.
+const networkSigningTimeout = 10 * time.Second
+
+// NetworkKey is the network identity key of the operator whose private part
+// is held by the external signer. It can be used by the network layer in
+// place of the static network key; messages are signed by the signer.
+type NetworkKey struct {
+	client    *Client
+	account   common.Address
+	publicKey *key.NetworkPublic
+}
+
+// NewNetworkKey returns the network identity key of the account held by the
+// signer.
+func NewNetworkKey(
+	ctx context.Context,
+	client *Client,
+	account common.Address,
+) (*NetworkKey, error) {
+	publicKey, err := client.PublicKey(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	// libp2p recognizes only the btcec definition of the secp256k1 curve.
+	networkPublicKey := &btcec.PublicKey{
+		Curve: btcec.S256(),
+		X:     publicKey.X,
+		Y:     publicKey.Y,
+	}
+
+	return &NetworkKey{
+		client:    client,
+		account:   account,
+		publicKey: (*key.NetworkPublic)(networkPublicKey),
+	}, nil
+}
+
+// Sign asks the signer to sign the network message.
+func (nk *NetworkKey) Sign(data []byte) ([]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		networkSigningTimeout,
+	)
+	defer cancelCtx()
+
+	return nk.client.SignNetworkMessage(ctx, nk.account, data)
+}
+
+// GetPublic returns the public network key of the operator.
+func (nk *NetworkKey) GetPublic() libp2pcrypto.PubKey {
+	return nk.publicKey
+}
+
+// Bytes always fails as the private key is held by the signer.
+func (nk *NetworkKey) Bytes() ([]byte, error) {
+	return nil, fmt.Errorf("private key is held by the external signer")
+}
+
+// Raw always fails as the private key is held by the signer.
+func (nk *NetworkKey) Raw() ([]byte, error) {
+	return nil, fmt.Errorf("private key is held by the external signer")
+}
+
+// Type returns the type of the key, secp256k1.
+func (nk *NetworkKey) Type() pb.KeyType {
+	return pb.KeyType_Secp256k1
+}
+
+// Equals tells whether the other key is a private key with the same public
+// key.
+func (nk *NetworkKey) Equals(other libp2pcrypto.Key) bool {
+	otherPrivateKey, ok := other.(libp2pcrypto.PrivKey)
+	if !ok {
+		return false
+	}
+
+	return nk.publicKey.Equals(otherPrivateKey.GetPublic())
+}
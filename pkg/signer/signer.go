@@ -0,0 +1,314 @@
+// Package signer contains the client of an external signer holding the
+// operator key, so that the key does not have to be kept by the client
+// process. The signer is accessed over JSON-RPC, usually on a local unix
+// socket, with the account API of Clef: account_version, account_list,
+// account_signTransaction and account_signData. The network identity of the
+// operator is backed by the keep_signNetworkMessage extension method.
+//
+// The package also contains StandIn, a minimal signer implementing the same
+// API with a signing policy, meant for development and tests.
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// textMimeType is the content type of account_signData requests signing
+// a message prefixed with "\x19Ethereum Signed Message:\n" and its length.
+const textMimeType = "text/plain"
+
+// publicKeyChallenge is the message signed to recover the public key of the
+// account, which the signer API does not expose directly.
+var publicKeyChallenge = []byte("keep-client public key challenge")
+
+// Client is a client of an external signer.
+type Client struct {
+	endpoint string
+	client   *rpc.Client
+}
+
+// transactionArgs are the arguments of the account_signTransaction call.
+type transactionArgs struct {
+	From     common.MixedcaseAddress  `json:"from"`
+	To       *common.MixedcaseAddress `json:"to"`
+	Gas      hexutil.Uint64           `json:"gas"`
+	GasPrice hexutil.Big              `json:"gasPrice"`
+	Value    hexutil.Big              `json:"value"`
+	Nonce    hexutil.Uint64           `json:"nonce"`
+	Data     *hexutil.Bytes           `json:"data"`
+	ChainID  *hexutil.Big             `json:"chainId,omitempty"`
+}
+
+// signTransactionResult is the result of the account_signTransaction call.
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Dial connects to the external signer at the given endpoint: a path to the
+// unix socket or an HTTP URL. It fails if the signer does not respond to the
+// version request.
+func Dial(ctx context.Context, endpoint string) (*Client, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not connect to signer [%v]: [%v]",
+			endpoint,
+			err,
+		)
+	}
+
+	signer := &Client{endpoint, client}
+
+	if _, err := signer.Version(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// Version returns the version of the signer API.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var version string
+	if err := c.client.CallContext(ctx, &version, "account_version"); err != nil {
+		return "", fmt.Errorf(
+			"could not get version of signer [%v]: [%v]",
+			c.endpoint,
+			err,
+		)
+	}
+
+	return version, nil
+}
+
+// Accounts returns the addresses of the accounts the signer holds keys of.
+func (c *Client) Accounts(ctx context.Context) ([]common.Address, error) {
+	var accounts []common.Address
+	if err := c.client.CallContext(ctx, &accounts, "account_list"); err != nil {
+		return nil, fmt.Errorf("could not list signer accounts: [%v]", err)
+	}
+
+	return accounts, nil
+}
+
+// Account returns the given account if the signer holds its key. If the
+// given account is empty, the signer has to hold exactly one key and the
+// account of that key is returned.
+func (c *Client) Account(
+	ctx context.Context,
+	account common.Address,
+) (common.Address, error) {
+	accounts, err := c.Accounts(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if account == (common.Address{}) {
+		if len(accounts) != 1 {
+			return common.Address{}, fmt.Errorf(
+				"signer holds [%v] accounts; the account has to be set",
+				len(accounts),
+			)
+		}
+		return accounts[0], nil
+	}
+
+	for _, candidate := range accounts {
+		if candidate == account {
+			return account, nil
+		}
+	}
+
+	return common.Address{}, fmt.Errorf(
+		"signer does not hold account [%v]",
+		account.Hex(),
+	)
+}
+
+// SignTransaction asks the signer to sign the transaction with the key of
+// the account for the given chain. The signer may refuse it according to its
+// policy.
+func (c *Client) SignTransaction(
+	ctx context.Context,
+	account common.Address,
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	data := hexutil.Bytes(transaction.Data())
+	args := &transactionArgs{
+		From:     common.NewMixedcaseAddress(account),
+		Gas:      hexutil.Uint64(transaction.Gas()),
+		GasPrice: hexutil.Big(*transaction.GasPrice()),
+		Value:    hexutil.Big(*transaction.Value()),
+		Nonce:    hexutil.Uint64(transaction.Nonce()),
+		Data:     &data,
+		ChainID:  (*hexutil.Big)(chainID),
+	}
+	if transaction.To() != nil {
+		to := common.NewMixedcaseAddress(*transaction.To())
+		args.To = &to
+	}
+
+	var result signTransactionResult
+	err := c.client.CallContext(ctx, &result, "account_signTransaction", args)
+	if err != nil {
+		return nil, fmt.Errorf("signer refused transaction: [%v]", err)
+	}
+
+	signedTransaction := new(types.Transaction)
+	if err := signedTransaction.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("invalid signed transaction: [%v]", err)
+	}
+
+	// Make sure the signer signed the transaction we asked for, with the
+	// expected key and for the expected chain.
+	sender, err := types.Sender(
+		types.LatestSignerForChainID(chainID),
+		signedTransaction,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed transaction: [%v]", err)
+	}
+	if sender != account {
+		return nil, fmt.Errorf(
+			"transaction signed by [%v] instead of [%v]",
+			sender.Hex(),
+			account.Hex(),
+		)
+	}
+	if !sameTransaction(transaction, signedTransaction) {
+		return nil, fmt.Errorf("signer returned a different transaction")
+	}
+
+	return signedTransaction, nil
+}
+
+func sameTransaction(expected, actual *types.Transaction) bool {
+	sameRecipient := (expected.To() == nil) == (actual.To() == nil) &&
+		(expected.To() == nil || *expected.To() == *actual.To())
+
+	return sameRecipient &&
+		expected.Nonce() == actual.Nonce() &&
+		expected.Gas() == actual.Gas() &&
+		expected.GasPrice().Cmp(actual.GasPrice()) == 0 &&
+		expected.Value().Cmp(actual.Value()) == 0 &&
+		string(expected.Data()) == string(actual.Data())
+}
+
+// SignText asks the signer to sign the message prefixed with
+// "\x19Ethereum Signed Message:\n" and the message length, with the key of
+// the account. The returned signature is in the [R || S || V] format with
+// V in {27, 28}.
+func (c *Client) SignText(
+	ctx context.Context,
+	account common.Address,
+	message []byte,
+) ([]byte, error) {
+	var signature hexutil.Bytes
+	err := c.client.CallContext(
+		ctx,
+		&signature,
+		"account_signData",
+		textMimeType,
+		account,
+		hexutil.Encode(message),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("signer refused message: [%v]", err)
+	}
+
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf(
+			"signature should have [%v] bytes; has: [%v]",
+			crypto.SignatureLength,
+			len(signature),
+		)
+	}
+
+	// Signers differ in the recovery ID they return; the on-chain signature
+	// validation accepts only V in {27, 28}.
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	return signature, nil
+}
+
+// SignNetworkMessage asks the signer to sign the network message with the
+// key of the account the same way the network layer does it: the signature
+// is the DER-encoded secp256k1 signature of the SHA-256 digest of the
+// message. This is an extension of the Clef API.
+func (c *Client) SignNetworkMessage(
+	ctx context.Context,
+	account common.Address,
+	message []byte,
+) ([]byte, error) {
+	var signature hexutil.Bytes
+	err := c.client.CallContext(
+		ctx,
+		&signature,
+		"keep_signNetworkMessage",
+		account,
+		hexutil.Bytes(message),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("signer refused network message: [%v]", err)
+	}
+
+	return signature, nil
+}
+
+// PublicKey recovers the public key of the account from the signature of a
+// challenge message, as the signer API does not expose public keys.
+func (c *Client) PublicKey(
+	ctx context.Context,
+	account common.Address,
+) (*ecdsa.PublicKey, error) {
+	signature, err := c.SignText(ctx, account, publicKeyChallenge)
+	if err != nil {
+		return nil, err
+	}
+
+	recoverable := make([]byte, len(signature))
+	copy(recoverable, signature)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+
+	publicKey, err := crypto.SigToPub(
+		textHash(publicKeyChallenge),
+		recoverable,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover public key: [%v]", err)
+	}
+
+	if crypto.PubkeyToAddress(*publicKey) != account {
+		return nil, fmt.Errorf(
+			"signer signed with a key other than the key of [%v]",
+			account.Hex(),
+		)
+	}
+
+	return publicKey, nil
+}
+
+// Close closes the connection to the signer.
+func (c *Client) Close() {
+	c.client.Close()
+}
+
+// textHash returns the hash signed by account_signData for text messages.
+func textHash(message []byte) []byte {
+	return crypto.Keccak256(
+		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
+		message,
+	)
+}
@@ -0,0 +1,335 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/retransmission"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	chainID         = big.NewInt(1101)
+	operatorAddress = common.HexToAddress("0x440a9a3fcc8eb4a2d73fad7c3ea11d6f40e2f7e3")
+	otherAddress    = common.HexToAddress("0x65ea55c1f10491038425725dc00dffeab2a1e28a")
+)
+
+func TestSignTransaction(t *testing.T) {
+	accountKey, client := startStandIn(t)
+
+	var tests = map[string]struct {
+		recipient     common.Address
+		chainID       *big.Int
+		expectedError bool
+	}{
+		"allowed recipient": {
+			recipient: operatorAddress,
+			chainID:   chainID,
+		},
+		"not allowed recipient": {
+			recipient:     otherAddress,
+			chainID:       chainID,
+			expectedError: true,
+		},
+		"other chain": {
+			recipient:     operatorAddress,
+			chainID:       big.NewInt(1),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			transaction := types.NewTransaction(
+				7,
+				test.recipient,
+				big.NewInt(0),
+				100000,
+				big.NewInt(20000000000),
+				[]byte{0x01, 0x02},
+			)
+
+			signedTransaction, err := client.SignTransaction(
+				context.Background(),
+				accountKey.Address,
+				transaction,
+				test.chainID,
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sender, err := types.Sender(
+				types.NewEIP155Signer(chainID),
+				signedTransaction,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sender != accountKey.Address {
+				t.Errorf(
+					"unexpected sender\nexpected: [%v]\nactual:   [%v]",
+					accountKey.Address.Hex(),
+					sender.Hex(),
+				)
+			}
+			if signedTransaction.Nonce() != transaction.Nonce() {
+				t.Errorf(
+					"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+					transaction.Nonce(),
+					signedTransaction.Nonce(),
+				)
+			}
+		})
+	}
+}
+
+func TestSignText(t *testing.T) {
+	accountKey, client := startStandIn(t)
+
+	message := []byte("dkg result hash")
+
+	signature, err := client.SignText(
+		context.Background(),
+		accountKey.Address,
+		message,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := ethutil.NewSigner(accountKey.PrivateKey).Verify(
+		message,
+		signature,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("signature is not valid")
+	}
+
+	publicKey, err := client.PublicKey(context.Background(), accountKey.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(
+		crypto.FromECDSAPub(publicKey),
+		crypto.FromECDSAPub(&accountKey.PrivateKey.PublicKey),
+	) {
+		t.Error("unexpected public key")
+	}
+}
+
+func TestNetworkKey(t *testing.T) {
+	accountKey, client := startStandIn(t)
+
+	networkKey, err := NewNetworkKey(
+		context.Background(),
+		client,
+		accountKey.Address,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, expectedPublicKey := key.OperatorKeyToNetworkKey(
+		operator.ChainKeyToOperatorKey(accountKey),
+	)
+	if !networkKey.GetPublic().Equals(expectedPublicKey) {
+		t.Fatal("unexpected public network key")
+	}
+
+	message := []byte("network message")
+	signature, err := networkKey.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := expectedPublicKey.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("signature is not valid")
+	}
+}
+
+func TestNetworkKeyHandshake(t *testing.T) {
+	accountKey, client := startStandIn(t)
+
+	networkKey, err := NewNetworkKey(
+		context.Background(),
+		client,
+		accountKey.Address,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkKey.GetPublic())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	ports, err := freePorts(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The handshake of the provider with the key held by the signer is
+	// verified by the other provider like any other handshake.
+	signerProvider, err := libp2p.Connect(
+		ctx,
+		libp2p.Config{Port: ports[0]},
+		networkKey,
+		libp2p.ProtocolBeacon,
+		firewall.Disabled,
+		retransmission.NewTicker(make(chan uint64)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signerProvider.Close()
+
+	otherKey, _, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherProvider, err := libp2p.Connect(
+		ctx,
+		libp2p.Config{
+			Port: ports[1],
+			Peers: []string{
+				fmt.Sprintf("/ip4/127.0.0.1/tcp/%v/ipfs/%v", ports[0], peerID),
+			},
+		},
+		otherKey,
+		libp2p.ProtocolBeacon,
+		firewall.Disabled,
+		retransmission.NewTicker(make(chan uint64)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer otherProvider.Close()
+
+	for {
+		connectedPeers := otherProvider.ConnectionManager().ConnectedPeers()
+		if len(connectedPeers) == 1 && connectedPeers[0] == peerID.String() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatal("peers did not connect")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func TestAccount(t *testing.T) {
+	accountKey, client := startStandIn(t)
+
+	account, err := client.Account(context.Background(), common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account != accountKey.Address {
+		t.Errorf(
+			"unexpected account\nexpected: [%v]\nactual:   [%v]",
+			accountKey.Address.Hex(),
+			account.Hex(),
+		)
+	}
+
+	if _, err := client.Account(context.Background(), otherAddress); err == nil {
+		t.Error("expected error for account not held by the signer")
+	}
+}
+
+func freePorts(count int) ([]int, error) {
+	ports := make([]int, count)
+	for i := range ports {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		ports[i] = listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+	}
+	return ports, nil
+}
+
+func startStandIn(t *testing.T) (*keystore.Key, *Client) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	standIn, err := NewStandIn(
+		accountKey,
+		chainID,
+		Policy{AllowedRecipients: []common.Address{operatorAddress}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(directory, "signer.ipc")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go standIn.Serve(listener)
+
+	client, err := Dial(context.Background(), socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+		standIn.Stop()
+		os.RemoveAll(directory)
+	})
+
+	return accountKey, client
+}
@@ -0,0 +1,220 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// standInVersion is the version of the Clef external API the stand-in
+// implements.
+const standInVersion = "6.1.0"
+
+// Policy decides which requests the stand-in signer signs.
+type Policy struct {
+	// AllowedRecipients are the only addresses transactions can be sent to,
+	// for example the operator contract. Transactions to any address are
+	// signed if not set. Contract creation is never signed.
+	AllowedRecipients []common.Address
+}
+
+func (p *Policy) checkRecipient(recipient *common.MixedcaseAddress) error {
+	if recipient == nil {
+		return fmt.Errorf("contract creation is not allowed")
+	}
+
+	if len(p.AllowedRecipients) == 0 {
+		return nil
+	}
+
+	for _, allowed := range p.AllowedRecipients {
+		if recipient.Address() == allowed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"transactions to [%v] are not allowed",
+		recipient.Address().Hex(),
+	)
+}
+
+// StandIn is a minimal external signer holding one key. It serves the same
+// API the Client uses and signs transactions according to its policy. It is
+// meant for development and tests; it keeps the key unencrypted in memory.
+type StandIn struct {
+	server *rpc.Server
+}
+
+// NewStandIn creates a stand-in signer signing with the given key for the
+// given chain.
+func NewStandIn(
+	accountKey *keystore.Key,
+	chainID *big.Int,
+	policy Policy,
+) (*StandIn, error) {
+	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
+		operator.ChainKeyToOperatorKey(accountKey),
+	)
+
+	api := &standInAPI{
+		accountKey:        accountKey,
+		networkPrivateKey: networkPrivateKey,
+		chainID:           chainID,
+		policy:            policy,
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", api); err != nil {
+		return nil, fmt.Errorf("could not register account API: [%v]", err)
+	}
+	if err := server.RegisterName("keep", &standInKeepAPI{api}); err != nil {
+		return nil, fmt.Errorf("could not register keep API: [%v]", err)
+	}
+
+	return &StandIn{server}, nil
+}
+
+// Serve accepts connections on the listener, such as a unix socket listener,
+// and serves the signer API over them. It returns when the listener is
+// closed.
+func (si *StandIn) Serve(listener net.Listener) error {
+	return si.server.ServeListener(listener)
+}
+
+// Stop stops serving the signer API on all the connections.
+func (si *StandIn) Stop() {
+	si.server.Stop()
+}
+
+// standInAPI implements the account API of Clef.
+type standInAPI struct {
+	accountKey        *keystore.Key
+	networkPrivateKey *key.NetworkPrivate
+	chainID           *big.Int
+	policy            Policy
+}
+
+func (sia *standInAPI) checkAccount(account common.Address) error {
+	if account != sia.accountKey.Address {
+		return fmt.Errorf("unknown account [%v]", account.Hex())
+	}
+
+	return nil
+}
+
+// Version serves account_version.
+func (sia *standInAPI) Version(ctx context.Context) (string, error) {
+	return standInVersion, nil
+}
+
+// List serves account_list.
+func (sia *standInAPI) List(ctx context.Context) ([]common.Address, error) {
+	return []common.Address{sia.accountKey.Address}, nil
+}
+
+// SignTransaction serves account_signTransaction.
+func (sia *standInAPI) SignTransaction(
+	ctx context.Context,
+	args transactionArgs,
+) (*signTransactionResult, error) {
+	if err := sia.checkAccount(args.From.Address()); err != nil {
+		return nil, err
+	}
+
+	if args.ChainID != nil && args.ChainID.ToInt().Cmp(sia.chainID) != 0 {
+		return nil, fmt.Errorf(
+			"transactions for chain [%v] are not allowed",
+			args.ChainID.ToInt(),
+		)
+	}
+
+	if err := sia.policy.checkRecipient(args.To); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if args.Data != nil {
+		data = *args.Data
+	}
+
+	transaction := types.NewTransaction(
+		uint64(args.Nonce),
+		args.To.Address(),
+		args.Value.ToInt(),
+		uint64(args.Gas),
+		args.GasPrice.ToInt(),
+		data,
+	)
+
+	signedTransaction, err := types.SignTx(
+		transaction,
+		types.NewEIP155Signer(sia.chainID),
+		sia.accountKey.PrivateKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signedTransaction.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &signTransactionResult{Raw: raw}, nil
+}
+
+// SignData serves account_signData. Only text messages are signed.
+func (sia *standInAPI) SignData(
+	ctx context.Context,
+	contentType string,
+	account common.MixedcaseAddress,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	if err := sia.checkAccount(account.Address()); err != nil {
+		return nil, err
+	}
+
+	if contentType != textMimeType {
+		return nil, fmt.Errorf(
+			"content type [%v] is not allowed",
+			contentType,
+		)
+	}
+
+	signature, err := crypto.Sign(textHash(data), sia.accountKey.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+// standInKeepAPI implements the keep extension of the signer API.
+type standInKeepAPI struct {
+	api *standInAPI
+}
+
+// SignNetworkMessage serves keep_signNetworkMessage.
+func (skapi *standInKeepAPI) SignNetworkMessage(
+	ctx context.Context,
+	account common.Address,
+	message hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	if err := skapi.api.checkAccount(account); err != nil {
+		return nil, err
+	}
+
+	return skapi.api.networkPrivateKey.Sign(message)
+}
@@ -0,0 +1,159 @@
+// Package support contains the support bundle: a single archive with the
+// troubleshooting information about the client an operator can share when
+// reporting a problem.
+package support
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ProblemsFileName is the name of the bundle file listing the information
+// which could not be collected.
+const ProblemsFileName = "problems.txt"
+
+// Bundle is a gzipped tar archive of troubleshooting information. Only the
+// content explicitly added to the bundle is archived; no files are copied
+// from the client data directory, so the bundle never contains key material
+// or group membership shares.
+type Bundle struct {
+	file       *os.File
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+	createdAt  time.Time
+
+	problems []string
+}
+
+// Create creates a new bundle at the given path. The file must not exist.
+func Create(filePath string) (*Bundle, error) {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not create bundle file: [%v]", err)
+	}
+
+	gzipWriter := gzip.NewWriter(file)
+
+	return &Bundle{
+		file:       file,
+		gzipWriter: gzipWriter,
+		tarWriter:  tar.NewWriter(gzipWriter),
+		createdAt:  time.Now().Truncate(time.Second),
+	}, nil
+}
+
+// AddFile adds a file with the given name and content to the bundle.
+func (b *Bundle) AddFile(name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: b.createdAt,
+	}
+
+	if err := b.tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("could not add [%v] to bundle: [%v]", name, err)
+	}
+	if _, err := b.tarWriter.Write(content); err != nil {
+		return fmt.Errorf("could not add [%v] to bundle: [%v]", name, err)
+	}
+
+	return nil
+}
+
+// AddJSON adds a file with the given name and the value encoded in JSON to
+// the bundle.
+func (b *Bundle) AddJSON(name string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode [%v]: [%v]", name, err)
+	}
+
+	return b.AddFile(name, append(content, '\n'))
+}
+
+// AddProblem records that the given information could not be collected.
+// The problems are written to the bundle when it is closed.
+func (b *Bundle) AddProblem(information string, err error) {
+	b.problems = append(b.problems, fmt.Sprintf("%v: %v", information, err))
+}
+
+// Problems returns the problems recorded so far.
+func (b *Bundle) Problems() []string {
+	return b.problems
+}
+
+// Close writes the recorded problems, if any, to the bundle and closes it.
+func (b *Bundle) Close() error {
+	if len(b.problems) > 0 {
+		content := strings.Join(b.problems, "\n") + "\n"
+		if err := b.AddFile(ProblemsFileName, []byte(content)); err != nil {
+			b.file.Close()
+			return err
+		}
+	}
+
+	if err := b.tarWriter.Close(); err != nil {
+		b.file.Close()
+		return fmt.Errorf("could not close bundle: [%v]", err)
+	}
+	if err := b.gzipWriter.Close(); err != nil {
+		b.file.Close()
+		return fmt.Errorf("could not close bundle: [%v]", err)
+	}
+
+	return b.file.Close()
+}
+
+// TailFile returns up to the given number of the last lines of the file.
+func TailFile(filePath string, lines int) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return tail(file, lines)
+}
+
+func tail(reader io.Reader, lines int) ([]byte, error) {
+	if lines <= 0 {
+		return []byte{}, nil
+	}
+
+	// The ring keeps the last lines read so far; next points to the oldest
+	// one once the ring is full.
+	ring := make([]string, 0, lines)
+	next := 0
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(ring) < lines {
+			ring = append(ring, scanner.Text())
+			continue
+		}
+
+		ring[next] = scanner.Text()
+		next = (next + 1) % lines
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	for i := 0; i < len(ring); i++ {
+		buffer.WriteString(ring[(next+i)%len(ring)])
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes(), nil
+}
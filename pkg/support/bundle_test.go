@@ -0,0 +1,135 @@
+package support
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBundle(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+
+	bundle, err := Create(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bundle.AddFile("version.txt", []byte("1.0.0\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.AddJSON("peers.json", []string{"peer-1"}); err != nil {
+		t.Fatal(err)
+	}
+	bundle.AddProblem("goroutine dump", fmt.Errorf("admin API is not configured"))
+
+	if err := bundle.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := readBundle(t, filePath)
+
+	expectedFiles := map[string]string{
+		"version.txt":    "1.0.0\n",
+		"peers.json":     "[\n  \"peer-1\"\n]\n",
+		ProblemsFileName: "goroutine dump: admin API is not configured\n",
+	}
+	if !reflect.DeepEqual(expectedFiles, files) {
+		t.Errorf(
+			"unexpected bundle content\nexpected: [%v]\nactual:   [%v]",
+			expectedFiles,
+			files,
+		)
+	}
+
+	if _, err := Create(filePath); err == nil {
+		t.Errorf("expected error when the bundle file exists")
+	}
+}
+
+func TestTail(t *testing.T) {
+	var tests = map[string]struct {
+		content  string
+		lines    int
+		expected string
+	}{
+		"fewer lines than requested": {
+			content:  "one\ntwo\n",
+			lines:    5,
+			expected: "one\ntwo\n",
+		},
+		"more lines than requested": {
+			content:  "one\ntwo\nthree\nfour\nfive\n",
+			lines:    2,
+			expected: "four\nfive\n",
+		},
+		"no trailing newline": {
+			content:  "one\ntwo\nthree",
+			lines:    2,
+			expected: "two\nthree\n",
+		},
+		"no lines requested": {
+			content:  "one\n",
+			lines:    0,
+			expected: "",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := tail(strings.NewReader(test.content), test.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != test.expected {
+				t.Errorf(
+					"unexpected tail\nexpected: [%q]\nactual:   [%q]",
+					test.expected,
+					string(actual),
+				)
+			}
+		})
+	}
+}
+
+func readBundle(t *testing.T, filePath string) map[string]string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files[header.Name] = string(content)
+	}
+
+	return files
+}